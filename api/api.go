@@ -207,6 +207,11 @@ type Tariff interface {
 	Type() TariffType
 }
 
+// TariffUpdated provides the time of the last successful tariff rates update
+type TariffUpdated interface {
+	Updated() time.Time
+}
+
 // AuthProvider is the ability to provide OAuth authentication through the ui
 type AuthProvider interface {
 	SetCallbackParams(baseURL, redirectURL string, authenticated chan<- bool)
@@ -20,6 +20,7 @@ package charger
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -32,6 +33,7 @@ import (
 type ABB struct {
 	conn *modbus.Connection
 	curr uint32
+	done chan struct{}
 }
 
 const (
@@ -85,11 +87,21 @@ func NewABB(uri, device, comset string, baudrate int, proto modbus.Protocol, sla
 	wb := &ABB{
 		conn: conn,
 		curr: 6000, // assume min current
+		done: make(chan struct{}),
 	}
 
 	// keep-alive
 	go func() {
-		for range time.Tick(30 * time.Second) {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-wb.done:
+				return
+			case <-ticker.C:
+			}
+
 			_, _ = wb.status()
 		}
 	}()
@@ -97,6 +109,14 @@ func NewABB(uri, device, comset string, baudrate int, proto modbus.Protocol, sla
 	return wb, err
 }
 
+var _ io.Closer = (*ABB)(nil)
+
+// Close implements the io.Closer interface
+func (wb *ABB) Close() error {
+	close(wb.done)
+	return nil
+}
+
 func (wb *ABB) status() (byte, error) {
 	b, err := wb.conn.ReadHoldingRegisters(abbRegStatus, 2)
 	if err != nil {
@@ -20,6 +20,7 @@ package charger
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
 	"math"
 	"sync"
 	"time"
@@ -40,6 +41,7 @@ type Alfen struct {
 	mu      sync.Mutex
 	curr    float64
 	enabled bool
+	done    chan struct{}
 }
 
 const (
@@ -88,6 +90,7 @@ func NewAlfen(uri string, slaveID uint8) (api.Charger, error) {
 	wb := &Alfen{
 		log:  log,
 		conn: conn,
+		done: make(chan struct{}),
 	}
 
 	go wb.heartbeat()
@@ -106,7 +109,16 @@ func NewAlfen(uri string, slaveID uint8) (api.Charger, error) {
 }
 
 func (wb *Alfen) heartbeat() {
-	for range time.Tick(25 * time.Second) {
+	ticker := time.NewTicker(25 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-wb.done:
+			return
+		case <-ticker.C:
+		}
+
 		wb.mu.Lock()
 		var curr float64
 		if wb.enabled {
@@ -120,6 +132,14 @@ func (wb *Alfen) heartbeat() {
 	}
 }
 
+var _ io.Closer = (*Alfen)(nil)
+
+// Close implements the io.Closer interface
+func (wb *Alfen) Close() error {
+	close(wb.done)
+	return nil
+}
+
 // Status implements the api.Charger interface
 func (wb *Alfen) Status() (api.ChargeStatus, error) {
 	b, err := wb.conn.ReadHoldingRegisters(alfenRegStatus, 5)
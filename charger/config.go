@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util/modbus"
 )
 
 type chargerRegistry map[string]func(map[string]interface{}) (api.Charger, error)
@@ -26,15 +27,29 @@ func (r chargerRegistry) Get(name string) (func(map[string]interface{}) (api.Cha
 
 var registry chargerRegistry = make(map[string]func(map[string]interface{}) (api.Charger, error))
 
+// Types returns the list of charger types
+func Types() []string {
+	var res []string
+	for typ := range registry {
+		res = append(res, typ)
+	}
+	return res
+}
+
 // NewFromConfig creates charger from configuration
 func NewFromConfig(typ string, other map[string]interface{}) (v api.Charger, err error) {
 	factory, err := registry.Get(strings.ToLower(typ))
-	if err == nil {
-		if v, err = factory(other); err != nil {
-			err = fmt.Errorf("cannot create charger '%s': %w", typ, err)
-		}
-	} else {
-		err = fmt.Errorf("invalid charger type: %s", typ)
+	if err != nil {
+		return nil, fmt.Errorf("invalid charger type: %s", typ)
+	}
+
+	// modbus priority and frame delay are applied by the connection
+	if other, err = modbus.ExtractSettings(other); err == nil {
+		v, err = factory(other)
+	}
+
+	if err != nil {
+		err = fmt.Errorf("cannot create charger '%s': %w", typ, err)
 	}
 
 	return
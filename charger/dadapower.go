@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -33,6 +34,7 @@ type Dadapower struct {
 	log       *util.Logger
 	conn      *modbus.Connection
 	regOffset uint16
+	done      chan struct{}
 }
 
 func init() {
@@ -63,6 +65,7 @@ func NewDadapower(uri string, id uint8) (*Dadapower, error) {
 	wb := &Dadapower{
 		log:  log,
 		conn: conn,
+		done: make(chan struct{}),
 	}
 
 	// 5min failsafe timeout
@@ -81,13 +84,30 @@ func NewDadapower(uri string, id uint8) (*Dadapower, error) {
 }
 
 func (wb *Dadapower) heartbeat() {
-	for range time.Tick(time.Minute) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-wb.done:
+			return
+		case <-ticker.C:
+		}
+
 		if _, err := wb.conn.ReadInputRegisters(dadapowerRegFailsafeTimeout, 1); err != nil {
 			wb.log.ERROR.Println("heartbeat:", err)
 		}
 	}
 }
 
+var _ io.Closer = (*Dadapower)(nil)
+
+// Close implements the io.Closer interface
+func (wb *Dadapower) Close() error {
+	close(wb.done)
+	return nil
+}
+
 // Status implements the api.Charger interface
 func (wb *Dadapower) Status() (api.ChargeStatus, error) {
 	b, err := wb.conn.ReadInputRegisters(dadapowerRegPlugState+wb.regOffset, 1)
@@ -20,6 +20,7 @@ package charger
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
 	"time"
 	"unicode/utf16"
 	"unicode/utf8"
@@ -34,6 +35,7 @@ type DaheimLadenMB struct {
 	log  *util.Logger
 	conn *modbus.Connection
 	curr uint16
+	done chan struct{}
 }
 
 const (
@@ -87,6 +89,7 @@ func NewDaheimLadenMB(uri string, id uint8) (api.Charger, error) {
 		log:  log,
 		conn: conn,
 		curr: 60, // assume min current
+		done: make(chan struct{}),
 	}
 
 	// get initial state from charger
@@ -109,13 +112,30 @@ func NewDaheimLadenMB(uri string, id uint8) (api.Charger, error) {
 }
 
 func (wb *DaheimLadenMB) heartbeat(timeout time.Duration) {
-	for range time.Tick(timeout) {
+	ticker := time.NewTicker(timeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-wb.done:
+			return
+		case <-ticker.C:
+		}
+
 		if _, err := wb.conn.ReadHoldingRegisters(dlRegSafeCurrent, 1); err != nil {
 			wb.log.ERROR.Println("heartbeat:", err)
 		}
 	}
 }
 
+var _ io.Closer = (*DaheimLadenMB)(nil)
+
+// Close implements the io.Closer interface
+func (wb *DaheimLadenMB) Close() error {
+	close(wb.done)
+	return nil
+}
+
 func (wb *DaheimLadenMB) setCurrent(current uint16) error {
 	b := make([]byte, 2)
 	binary.BigEndian.PutUint16(b, current)
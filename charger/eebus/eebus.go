@@ -14,9 +14,9 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/enbility/cemd/cem"
 	"github.com/enbility/cemd/emobility"
 	"github.com/enbility/eebus-go/service"
+	"github.com/enbility/eebus-go/spine"
 	"github.com/enbility/eebus-go/spine/model"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/machine"
@@ -34,7 +34,8 @@ type EEBusClientCBs struct {
 }
 
 type EEBus struct {
-	Cem *cem.CemImpl
+	service   *service.EEBUSService
+	emobility *emobility.EmobilityScenarioImpl
 
 	mux sync.Mutex
 	log *util.Logger
@@ -116,25 +117,42 @@ func NewServer(other map[string]interface{}) (*EEBus, error) {
 		SKI:     ski,
 	}
 
-	c.Cem = cem.NewCEM(configuration, c, c)
-	if err := c.Cem.Setup(); err != nil {
+	c.service = service.NewEEBUSService(configuration, c)
+	c.service.SetLogging(c)
+	if err := c.service.Setup(); err != nil {
 		return nil, err
 	}
-	c.Cem.EnableEmobility(emobility.EmobilityConfiguration{
+
+	spine.Events.Subscribe(c)
+
+	c.emobility = emobility.NewEMobilityScenario(c.service, model.CurrencyTypeEur, emobility.EmobilityConfiguration{
 		CoordinatedChargingEnabled: false,
 	})
+	c.emobility.AddFeatures()
+	c.emobility.AddUseCases()
 
 	return c, nil
 }
 
-func (c *EEBus) RegisterEVSE(ski, ip string, connectHandler func(string), disconnectHandler func(string), dataProvider emobility.EmobilityDataProvider) *emobility.EMobilityImpl {
+// Service returns the EEBUS service for adding local features and use cases
+func (c *EEBus) Service() *service.EEBUSService {
+	return c.service
+}
+
+// normalizeSKI removes separators from the SKI
+func normalizeSKI(ski string) string {
 	ski = strings.ReplaceAll(ski, "-", "")
 	ski = strings.ReplaceAll(ski, " ", "")
-	ski = strings.ToLower(ski)
+	return strings.ToLower(ski)
+}
+
+// register adds the connection callbacks and returns the remote service details
+func (c *EEBus) register(ski, ip string, connectHandler func(string), disconnectHandler func(string)) *service.ServiceDetails {
+	ski = normalizeSKI(ski)
 	c.log.TRACE.Printf("registering ski: %s", ski)
 
 	if ski == c.SKI {
-		c.log.FATAL.Fatal("The charger SKI can not be identical to the SKI of evcc!")
+		c.log.FATAL.Fatal("The device SKI can not be identical to the SKI of evcc!")
 	}
 
 	serviceDetails := service.NewServiceDetails(ski)
@@ -144,15 +162,56 @@ func (c *EEBus) RegisterEVSE(ski, ip string, connectHandler func(string), discon
 	defer c.mux.Unlock()
 	c.clients[ski] = EEBusClientCBs{onConnect: connectHandler, onDisconnect: disconnectHandler}
 
-	return c.Cem.RegisterEmobilityRemoteDevice(serviceDetails, dataProvider)
+	return serviceDetails
+}
+
+// RegisterDevice pairs a remote device
+func (c *EEBus) RegisterDevice(ski, ip string, connectHandler func(string), disconnectHandler func(string)) {
+	c.service.PairRemoteService(c.register(ski, ip, connectHandler, disconnectHandler))
+}
+
+func (c *EEBus) RegisterEVSE(ski, ip string, connectHandler func(string), disconnectHandler func(string), dataProvider emobility.EmobilityDataProvider) *emobility.EMobilityImpl {
+	serviceDetails := c.register(ski, ip, connectHandler, disconnectHandler)
+
+	return c.emobility.RegisterRemoteDevice(serviceDetails, dataProvider).(*emobility.EMobilityImpl)
 }
 
 func (c *EEBus) Run() {
-	c.Cem.Start()
+	c.service.Start()
 }
 
 func (c *EEBus) Shutdown() {
-	c.Cem.Shutdown()
+	c.service.Shutdown()
+}
+
+// HandleEvent starts and stops sending heartbeats on remote subscription requests
+func (c *EEBus) HandleEvent(payload spine.EventPayload) {
+	if payload.EventType != spine.EventTypeSubscriptionChange {
+		return
+	}
+
+	data, ok := payload.Data.(model.SubscriptionManagementRequestCallType)
+	if !ok || data.ServerFeatureType == nil || *data.ServerFeatureType != model.FeatureTypeTypeDeviceDiagnosis {
+		return
+	}
+
+	remoteDevice := c.service.RemoteDeviceForSki(payload.Ski)
+	if remoteDevice == nil {
+		c.log.DEBUG.Println("no remote device found for ski:", payload.Ski)
+		return
+	}
+
+	sender := c.service.LocalDevice().FeatureByTypeAndRole(model.FeatureTypeTypeDeviceDiagnosis, model.RoleTypeServer)
+	if sender == nil || payload.Feature == nil {
+		return
+	}
+
+	switch payload.ChangeType {
+	case spine.ElementChangeAdd:
+		remoteDevice.StartHeartbeatSend(sender.Address(), payload.Feature.Address())
+	case spine.ElementChangeRemove:
+		remoteDevice.Stopheartbeat()
+	}
 }
 
 // EEBUSServiceHandler
@@ -20,6 +20,7 @@ package charger
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -44,6 +45,7 @@ type Salia struct {
 	uri     string
 	current int64
 	apiG    provider.Cacheable[salia.Api]
+	done    chan struct{}
 }
 
 func init() {
@@ -79,6 +81,7 @@ func NewSalia(uri string, cache time.Duration) (api.Charger, error) {
 		Helper:  request.NewHelper(log),
 		uri:     util.DefaultScheme(uri, "http"),
 		current: 6,
+		done:    make(chan struct{}),
 	}
 
 	wb.apiG = provider.ResettableCached(func() (salia.Api, error) {
@@ -121,15 +124,32 @@ func (wb *Salia) heartbeat() {
 	bo.InitialInterval = 5 * time.Second
 	bo.MaxElapsedTime = time.Minute
 
-	for ; true; <-time.Tick(30 * time.Second) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
 		if err := backoff.Retry(func() error {
 			return wb.post(salia.HeartBeat, "alive")
 		}, bo); err != nil {
 			wb.log.ERROR.Println("heartbeat:", err)
 		}
+
+		select {
+		case <-wb.done:
+			return
+		case <-ticker.C:
+		}
 	}
 }
 
+var _ io.Closer = (*Salia)(nil)
+
+// Close implements the io.Closer interface
+func (wb *Salia) Close() error {
+	close(wb.done)
+	return nil
+}
+
 func (wb *Salia) post(key, val string) error {
 	data := map[string]string{key: val}
 	uri := fmt.Sprintf("%s/secc", wb.uri)
@@ -21,6 +21,7 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"time"
@@ -38,6 +39,7 @@ import (
 type Keba struct {
 	log  *util.Logger
 	conn *modbus.Connection
+	done chan struct{}
 }
 
 const (
@@ -148,19 +150,37 @@ func NewKeba(uri string, slaveID uint8) (*Keba, error) {
 	wb := &Keba{
 		log:  log,
 		conn: conn,
+		done: make(chan struct{}),
 	}
 
 	return wb, err
 }
 
 func (wb *Keba) heartbeat(timeout time.Duration) {
-	for range time.Tick(timeout) {
+	ticker := time.NewTicker(timeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-wb.done:
+			return
+		case <-ticker.C:
+		}
+
 		if _, err := wb.Enabled(); err != nil {
 			wb.log.ERROR.Println("heartbeat:", err)
 		}
 	}
 }
 
+var _ io.Closer = (*Keba)(nil)
+
+// Close implements the io.Closer interface
+func (wb *Keba) Close() error {
+	close(wb.done)
+	return nil
+}
+
 // Status implements the api.Charger interface
 func (wb *Keba) Status() (api.ChargeStatus, error) {
 	b, err := wb.conn.ReadHoldingRegisters(kebaRegCableState, 2)
@@ -246,6 +246,9 @@ func NewOCPP(id string, connector int, idtag string,
 		}
 	}
 
+	// remote transactions are always authorized
+	cp.SetRemoteIdTag(c.idtag)
+
 	// TODO: check for running transaction
 
 	return c, cp.Initialized()
@@ -425,8 +428,9 @@ func (c *OCPP) phases1p3p(phases int) error {
 	return c.updatePeriod(c.current, c.phases)
 }
 
-// // Identify implements the api.Identifier interface
-// Unless charger uses vehicle ID as idTag in authorize.req it is not possible to implement this in ocpp1.6
-// func (c *OCPP) Identify() (string, error) {
-// 	return "", errors.New("not implemented")
-// }
+var _ api.Identifier = (*OCPP)(nil)
+
+// Identify implements the api.Identifier interface
+func (c *OCPP) Identify() (string, error) {
+	return c.cp.IdTag()
+}
@@ -0,0 +1,69 @@
+package ocpp
+
+import (
+	"strings"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/localauth"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"golang.org/x/exp/slices"
+)
+
+// maxIdTagLength is the maximum id tag length supported by OCPP 1.6
+const maxIdTagLength = 20
+
+// Authorization is the central system's id tag authorization list
+type Authorization struct {
+	Accept []string // accepted id tags, all tags are accepted if empty
+	Reject []string // blocked id tags
+}
+
+// Empty returns true if no id tags are configured
+func (a Authorization) Empty() bool {
+	return len(a.Accept) == 0 && len(a.Reject) == 0
+}
+
+func containsIdTag(list []string, idTag string) bool {
+	return slices.ContainsFunc(list, func(s string) bool {
+		return strings.EqualFold(s, idTag)
+	})
+}
+
+// Status returns the authorization status of the id tag
+func (a Authorization) Status(idTag string) types.AuthorizationStatus {
+	switch {
+	case containsIdTag(a.Reject, idTag):
+		return types.AuthorizationStatusBlocked
+	case len(a.Accept) > 0 && !containsIdTag(a.Accept, idTag):
+		return types.AuthorizationStatusInvalid
+	default:
+		return types.AuthorizationStatusAccepted
+	}
+}
+
+// LocalList returns the list of id tags for the chargepoints' local authorization list
+func (a Authorization) LocalList() []localauth.AuthorizationData {
+	var res []localauth.AuthorizationData
+
+	for _, list := range []struct {
+		tags   []string
+		status types.AuthorizationStatus
+	}{
+		{a.Reject, types.AuthorizationStatusBlocked}, // blocking takes precedence
+		{a.Accept, types.AuthorizationStatusAccepted},
+	} {
+		for _, idTag := range list.tags {
+			if len(idTag) > maxIdTagLength || slices.ContainsFunc(res, func(d localauth.AuthorizationData) bool {
+				return strings.EqualFold(d.IdTag, idTag)
+			}) {
+				continue
+			}
+
+			res = append(res, localauth.AuthorizationData{
+				IdTag:     idTag,
+				IdTagInfo: &types.IdTagInfo{Status: list.status},
+			})
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,29 @@
+package ocpp
+
+import (
+	"testing"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/localauth"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorization(t *testing.T) {
+	var auth Authorization
+	assert.True(t, auth.Empty())
+	assert.Equal(t, types.AuthorizationStatusAccepted, auth.Status("any"))
+
+	auth = Authorization{
+		Accept: []string{"AA11", "BB22"},
+		Reject: []string{"bb22"},
+	}
+
+	assert.Equal(t, types.AuthorizationStatusAccepted, auth.Status("aa11"))
+	assert.Equal(t, types.AuthorizationStatusBlocked, auth.Status("BB22"))
+	assert.Equal(t, types.AuthorizationStatusInvalid, auth.Status("CC33"))
+
+	assert.Equal(t, []localauth.AuthorizationData{
+		{IdTag: "bb22", IdTagInfo: &types.IdTagInfo{Status: types.AuthorizationStatusBlocked}},
+		{IdTag: "AA11", IdTagInfo: &types.IdTagInfo{Status: types.AuthorizationStatusAccepted}},
+	}, auth.LocalList())
+}
@@ -11,8 +11,10 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -34,6 +36,20 @@ const (
 	KeyAlfenPlugAndChargeIdentifier = "PlugAndChargeIdentifier"
 )
 
+// maxStatusErrors is the number of status errors kept in the error history
+const maxStatusErrors = 50
+
+// StatusError is an error reported by the chargepoint's status notifications
+type StatusError struct {
+	Timestamp       time.Time                 `json:"timestamp"`
+	Connector       int                       `json:"connector"`
+	Status          core.ChargePointStatus    `json:"status"`
+	ErrorCode       core.ChargePointErrorCode `json:"errorCode"`
+	Info            string                    `json:"info,omitempty"`
+	VendorId        string                    `json:"vendorId,omitempty"`
+	VendorErrorCode string                    `json:"vendorErrorCode,omitempty"`
+}
+
 // TODO support multiple connectors
 // Since ocpp-go interfaces at charge point level, we need to manage multiple connector separately
 
@@ -57,6 +73,14 @@ type CP struct {
 
 	txnCount int // change initial value to the last known global transaction. Needs persistence
 	txnId    int
+
+	authorize   func(idTag string) types.AuthorizationStatus // central system authorization
+	remoteIdTag string                                       // id tag used for remote transactions
+	idTag       string                                       // id tag of the current session
+
+	statusErrors      []StatusError // error history of all connectors
+	diagnosticsStatus firmware.DiagnosticsStatus
+	firmwareStatus    firmware.FirmwareStatus
 }
 
 func NewChargePoint(log *util.Logger, id string, connector int, timeout time.Duration) *CP {
@@ -111,6 +135,13 @@ func (cp *CP) connect(connect bool) {
 	}
 }
 
+func (cp *CP) isConnected() bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	return cp.connected
+}
+
 func (cp *CP) HasConnected() <-chan struct{} {
 	return cp.connectC
 }
@@ -137,6 +168,72 @@ func (cp *CP) Initialized() error {
 	}
 }
 
+// Connected returns true if the chargepoint is connected
+func (cp *CP) Connected() bool {
+	return cp.isConnected()
+}
+
+// StatusErrors returns the error history reported by status notifications, oldest first
+func (cp *CP) StatusErrors() []StatusError {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	return slices.Clone(cp.statusErrors)
+}
+
+// DiagnosticsStatus returns the last reported diagnostics upload status
+func (cp *CP) DiagnosticsStatus() firmware.DiagnosticsStatus {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	return cp.diagnosticsStatus
+}
+
+// FirmwareStatus returns the last reported firmware update status
+func (cp *CP) FirmwareStatus() firmware.FirmwareStatus {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	return cp.firmwareStatus
+}
+
+// SetRemoteIdTag sets the id tag used for remote transactions which is always accepted
+func (cp *CP) SetRemoteIdTag(idTag string) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.remoteIdTag = idTag
+}
+
+// IdTag returns the id tag presented for the current session, excluding the remote id tag
+func (cp *CP) IdTag() (string, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if !cp.connected {
+		return "", api.ErrTimeout
+	}
+
+	if cp.idTag == cp.remoteIdTag {
+		return "", nil
+	}
+
+	return cp.idTag, nil
+}
+
+// authorizationStatus returns the authorization status of the id tag
+func (cp *CP) authorizationStatus(idTag string) types.AuthorizationStatus {
+	cp.mu.Lock()
+	remote := idTag == cp.remoteIdTag
+	cp.mu.Unlock()
+
+	if remote || cp.authorize == nil {
+		return types.AuthorizationStatusAccepted
+	}
+
+	return cp.authorize(idTag)
+}
+
 // TransactionID returns the current transaction id
 func (cp *CP) TransactionID() (int, error) {
 	cp.mu.Lock()
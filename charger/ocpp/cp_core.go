@@ -14,16 +14,34 @@ const (
 )
 
 func (cp *CP) Authorize(request *core.AuthorizeRequest) (*core.AuthorizeConfirmation, error) {
-	// TODO check if this authorizes foreign RFID tags
+	status := types.AuthorizationStatusAccepted
+	if request != nil {
+		status = cp.identify(request.IdTag)
+	}
+
 	res := &core.AuthorizeConfirmation{
 		IdTagInfo: &types.IdTagInfo{
-			Status: types.AuthorizationStatusAccepted,
+			Status: status,
 		},
 	}
 
 	return res, nil
 }
 
+// identify authorizes the id tag and stores it for vehicle identification if accepted
+func (cp *CP) identify(idTag string) types.AuthorizationStatus {
+	status := cp.authorizationStatus(idTag)
+	cp.log.DEBUG.Printf("authorize id tag %s: %s", idTag, status)
+
+	if status == types.AuthorizationStatusAccepted {
+		cp.mu.Lock()
+		cp.idTag = idTag
+		cp.mu.Unlock()
+	}
+
+	return status
+}
+
 func (cp *CP) BootNotification(request *core.BootNotificationRequest) (*core.BootNotificationConfirmation, error) {
 	res := &core.BootNotificationConfirmation{
 		CurrentTime: types.NewDateTime(cp.clock.Now()),
@@ -50,7 +68,42 @@ func (cp *CP) timestampValid(t time.Time) bool {
 	return !t.Before(cp.status.Timestamp.Time)
 }
 
+// recordError adds errors reported by status notifications of any connector to the error history
+func (cp *CP) recordError(request *core.StatusNotificationRequest) {
+	if (request.ErrorCode == "" || request.ErrorCode == core.NoError) && request.VendorErrorCode == "" {
+		return
+	}
+
+	ts := cp.clock.Now()
+	if request.Timestamp != nil {
+		ts = request.Timestamp.Time
+	}
+
+	cp.log.WARN.Printf("connector %d: %s (%s) %s %s", request.ConnectorId, request.ErrorCode, request.Status, request.Info, request.VendorErrorCode)
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.statusErrors = append(cp.statusErrors, StatusError{
+		Timestamp:       ts,
+		Connector:       request.ConnectorId,
+		Status:          request.Status,
+		ErrorCode:       request.ErrorCode,
+		Info:            request.Info,
+		VendorId:        request.VendorId,
+		VendorErrorCode: request.VendorErrorCode,
+	})
+
+	if len(cp.statusErrors) > maxStatusErrors {
+		cp.statusErrors = cp.statusErrors[len(cp.statusErrors)-maxStatusErrors:]
+	}
+}
+
 func (cp *CP) StatusNotification(request *core.StatusNotificationRequest) (*core.StatusNotificationConfirmation, error) {
+	if request != nil {
+		cp.recordError(request)
+	}
+
 	if request != nil && request.ConnectorId == cp.connector {
 		cp.mu.Lock()
 		defer cp.mu.Unlock()
@@ -63,6 +116,11 @@ func (cp *CP) StatusNotification(request *core.StatusNotificationRequest) (*core
 		} else {
 			cp.log.TRACE.Printf("ignoring status: %s < %s", request.Timestamp.Time, cp.status.Timestamp)
 		}
+
+		// vehicle disconnected
+		if cp.status.Status == core.ChargePointStatusAvailable {
+			cp.idTag = ""
+		}
 	}
 
 	return new(core.StatusNotificationConfirmation), nil
@@ -116,12 +174,14 @@ func (cp *CP) StartTransaction(request *core.StartTransactionRequest) (*core.Sta
 		return new(core.StartTransactionConfirmation), nil
 	}
 
+	status := cp.identify(request.IdTag)
+
 	cp.mu.Lock()
 	defer cp.mu.Unlock()
 
 	res := &core.StartTransactionConfirmation{
 		IdTagInfo: &types.IdTagInfo{
-			Status: types.AuthorizationStatusAccepted, // accept
+			Status: status,
 		},
 		TransactionId: 1, // default
 	}
@@ -163,9 +223,25 @@ func (cp *CP) StopTransaction(request *core.StopTransactionRequest) (*core.StopT
 }
 
 func (cp *CP) DiagnosticStatusNotification(request *firmware.DiagnosticsStatusNotificationRequest) (*firmware.DiagnosticsStatusNotificationConfirmation, error) {
+	if request != nil {
+		cp.log.DEBUG.Printf("diagnostics status: %s", request.Status)
+
+		cp.mu.Lock()
+		cp.diagnosticsStatus = request.Status
+		cp.mu.Unlock()
+	}
+
 	return &firmware.DiagnosticsStatusNotificationConfirmation{}, nil
 }
 
 func (cp *CP) FirmwareStatusNotification(request *firmware.FirmwareStatusNotificationRequest) (*firmware.FirmwareStatusNotificationConfirmation, error) {
+	if request != nil {
+		cp.log.DEBUG.Printf("firmware status: %s", request.Status)
+
+		cp.mu.Lock()
+		cp.firmwareStatus = request.Status
+		cp.mu.Unlock()
+	}
+
 	return &firmware.FirmwareStatusNotificationConfirmation{}, nil
 }
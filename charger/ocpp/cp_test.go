@@ -0,0 +1,62 @@
+package ocpp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusErrors(t *testing.T) {
+	clock := clock.NewMock()
+	clock.Set(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	cp := NewChargePoint(util.NewLogger("foo"), "abc", 1, time.Minute)
+	cp.TestClock(clock)
+
+	_, err := cp.StatusNotification(&core.StatusNotificationRequest{
+		ConnectorId: 1,
+		ErrorCode:   core.NoError,
+		Status:      core.ChargePointStatusAvailable,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, cp.StatusErrors())
+
+	_, err = cp.StatusNotification(&core.StatusNotificationRequest{
+		ConnectorId:     0,
+		ErrorCode:       core.OtherError,
+		Status:          core.ChargePointStatusFaulted,
+		VendorId:        "acme",
+		VendorErrorCode: "E42",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []StatusError{{
+		Timestamp:       clock.Now(),
+		Connector:       0,
+		Status:          core.ChargePointStatusFaulted,
+		ErrorCode:       core.OtherError,
+		VendorId:        "acme",
+		VendorErrorCode: "E42",
+	}}, cp.StatusErrors())
+
+	for i := 0; i < maxStatusErrors; i++ {
+		_, err = cp.StatusNotification(&core.StatusNotificationRequest{
+			ConnectorId:     1,
+			ErrorCode:       core.NoError,
+			Status:          core.ChargePointStatusCharging,
+			VendorErrorCode: fmt.Sprintf("W%d", i),
+		})
+		require.NoError(t, err)
+	}
+
+	res := cp.StatusErrors()
+	require.Len(t, res, maxStatusErrors)
+	assert.Equal(t, "W0", res[0].VendorErrorCode)
+	assert.Equal(t, fmt.Sprintf("W%d", maxStatusErrors-1), res[len(res)-1].VendorErrorCode)
+}
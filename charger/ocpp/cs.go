@@ -7,6 +7,7 @@ import (
 
 	"github.com/evcc-io/evcc/util"
 	ocpp16 "github.com/lorenzodonini/ocpp-go/ocpp1.6"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 )
 
 type CS struct {
@@ -14,6 +15,41 @@ type CS struct {
 	log *util.Logger
 	ocpp16.CentralSystem
 	cps map[string]*CP
+
+	authMu      sync.RWMutex
+	auth        Authorization
+	authVersion int
+}
+
+// SetAuthorization updates the id tag authorization list and sends it to all connected chargepoints
+func (cs *CS) SetAuthorization(auth Authorization) {
+	for _, idTag := range append(auth.Accept, auth.Reject...) {
+		if len(idTag) > maxIdTagLength {
+			cs.log.WARN.Printf("id tag exceeds %d characters and is not sent to chargepoints: %s", maxIdTagLength, idTag)
+		}
+	}
+
+	cs.authMu.Lock()
+	cs.auth = auth
+	cs.authVersion++
+	cs.authMu.Unlock()
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	for id, cp := range cs.cps {
+		if cp != nil && cp.isConnected() {
+			go cs.SendLocalListRequest(id)
+		}
+	}
+}
+
+// authorize returns the authorization status of the id tag
+func (cs *CS) authorize(idTag string) types.AuthorizationStatus {
+	cs.authMu.RLock()
+	defer cs.authMu.RUnlock()
+
+	return cs.auth.Status(idTag)
 }
 
 // Register registers a chargepoint with the central system.
@@ -26,9 +62,12 @@ func (cs *CS) Register(id string, cp *CP) error {
 		return errors.New("cannot have >1 chargepoint with empty station id")
 	}
 
+	cp.authorize = cs.authorize
+
 	// trigger unknown chargepoint connected
 	if unknown, ok := cs.cps[id]; ok && unknown == nil {
 		cp.connect(true)
+		go cs.SendLocalListRequest(id)
 	}
 
 	cs.cps[id] = cp
@@ -66,6 +105,7 @@ func (cs *CS) NewChargePoint(chargePoint ocpp16.ChargePointConnection) {
 			delete(cs.cps, "")
 
 			cp.connect(true)
+			go cs.SendLocalListRequest(chargePoint.ID())
 
 			return
 		}
@@ -81,6 +121,7 @@ func (cs *CS) NewChargePoint(chargePoint ocpp16.ChargePointConnection) {
 		// trigger initial connection if chargepoint is already setup
 		if cp != nil {
 			cp.connect(true)
+			go cs.SendLocalListRequest(chargePoint.ID())
 		}
 	}
 }
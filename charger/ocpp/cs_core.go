@@ -3,6 +3,7 @@ package ocpp
 import (
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/localauth"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
 )
 
@@ -50,6 +51,35 @@ func (cs *CS) TriggerMeterValuesRequest(id string, connector int) {
 	})
 }
 
+// SendLocalListRequest sends the full authorization list to the chargepoint if configured
+func (cs *CS) SendLocalListRequest(id string) {
+	cs.authMu.RLock()
+	auth, version := cs.auth, cs.authVersion
+	cs.authMu.RUnlock()
+
+	if version == 0 {
+		return
+	}
+
+	if err := cs.SendLocalList(id, func(request *localauth.SendLocalListConfirmation, err error) {
+		log := cs.log.TRACE
+		if err == nil && request != nil && request.Status != localauth.UpdateStatusAccepted && request.Status != localauth.UpdateStatusNotSupported {
+			log = cs.log.ERROR
+		}
+
+		var status localauth.UpdateStatus
+		if request != nil {
+			status = request.Status
+		}
+
+		log.Printf("SendLocalList version %d for %s: %+v", version, id, status)
+	}, version, localauth.UpdateTypeFull, func(request *localauth.SendLocalListRequest) {
+		request.LocalAuthorizationList = auth.LocalList()
+	}); err != nil {
+		cs.log.ERROR.Printf("send SendLocalList for %s failed: %v", id, err)
+	}
+}
+
 // cp actions
 
 func (cs *CS) OnAuthorize(id string, request *core.AuthorizeRequest) (*core.AuthorizeConfirmation, error) {
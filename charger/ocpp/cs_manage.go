@@ -0,0 +1,115 @@
+package ocpp
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/firmware"
+)
+
+// requestTimeout is the maximum time to wait for a chargepoint's response to management requests
+const requestTimeout = 30 * time.Second
+
+// roundtrip sends a request and waits for the chargepoint's response
+func roundtrip[T any](send func(callback func(T, error)) error) (T, error) {
+	type result struct {
+		res T
+		err error
+	}
+
+	var zero T
+	rc := make(chan result, 1)
+
+	if err := send(func(res T, err error) {
+		rc <- result{res, err}
+	}); err != nil {
+		return zero, err
+	}
+
+	select {
+	case r := <-rc:
+		return r.res, r.err
+	case <-time.After(requestTimeout):
+		return zero, api.ErrTimeout
+	}
+}
+
+// ChargePoints returns the ids of all configured chargepoints
+func (cs *CS) ChargePoints() []string {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	var res []string
+	for id, cp := range cs.cps {
+		if cp != nil && id != "" {
+			res = append(res, id)
+		}
+	}
+	sort.Strings(res)
+
+	return res
+}
+
+// ChargePoint returns the configured chargepoint
+func (cs *CS) ChargePoint(id string) (*CP, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cp := cs.cps[id]; cp != nil && id != "" {
+		return cp, nil
+	}
+
+	return nil, fmt.Errorf("unknown charge point: %s", id)
+}
+
+// Configuration returns the chargepoint's configuration, all keys are returned if keys is empty
+func (cs *CS) Configuration(id string, keys []string) (*core.GetConfigurationConfirmation, error) {
+	return roundtrip(func(callback func(*core.GetConfigurationConfirmation, error)) error {
+		return cs.GetConfiguration(id, callback, keys)
+	})
+}
+
+// SetConfiguration changes the chargepoint's configuration key
+func (cs *CS) SetConfiguration(id, key, value string) (core.ConfigurationStatus, error) {
+	res, err := roundtrip(func(callback func(*core.ChangeConfigurationConfirmation, error)) error {
+		return cs.ChangeConfiguration(id, callback, key, value)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	switch res.Status {
+	case core.ConfigurationStatusAccepted, core.ConfigurationStatusRebootRequired:
+		return res.Status, nil
+	default:
+		return res.Status, fmt.Errorf("change configuration %s: %s", key, res.Status)
+	}
+}
+
+// ResetChargePoint performs a soft or hard reset of the chargepoint
+func (cs *CS) ResetChargePoint(id string, resetType core.ResetType) error {
+	res, err := roundtrip(func(callback func(*core.ResetConfirmation, error)) error {
+		return cs.Reset(id, callback, resetType)
+	})
+
+	if err == nil && res.Status != core.ResetStatusAccepted {
+		err = fmt.Errorf("reset: %s", res.Status)
+	}
+
+	return err
+}
+
+// RequestDiagnostics requests the chargepoint to upload its diagnostics to location and returns the file name
+func (cs *CS) RequestDiagnostics(id, location string) (string, error) {
+	res, err := roundtrip(func(callback func(*firmware.GetDiagnosticsConfirmation, error)) error {
+		return cs.GetDiagnostics(id, callback, location)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return res.FileName, nil
+}
@@ -2,6 +2,7 @@ package ocpp
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/evcc-io/evcc/util"
@@ -13,8 +14,14 @@ import (
 var (
 	once     sync.Once
 	instance *CS
+	running  atomic.Pointer[CS]
 )
 
+// Running returns the central system or nil if it has not been started
+func Running() *CS {
+	return running.Load()
+}
+
 func Instance() *CS {
 	once.Do(func() {
 		timeoutConfig := ws.NewServerTimeoutConfig()
@@ -42,6 +49,8 @@ func Instance() *CS {
 		go cs.Start(8887, "/{ws}")
 
 		time.Sleep(time.Second)
+
+		running.Store(instance)
 	})
 
 	return instance
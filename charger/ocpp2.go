@@ -0,0 +1,447 @@
+package charger
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/charger/ocpp2"
+	"github.com/evcc-io/evcc/util"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/samber/lo"
+)
+
+// OCPP2 charger implementation
+type OCPP2 struct {
+	log               *util.Logger
+	cp                *ocpp2.CP
+	evse              int
+	idtag             string
+	phases            int
+	current           float64
+	meterValuesSample string
+	timeout           time.Duration
+	phaseSwitching    bool
+	remoteStartId     int
+}
+
+func init() {
+	registry.Add("ocpp2", NewOCPP2FromConfig)
+}
+
+// NewOCPP2FromConfig creates a OCPP 2.0.1 charger from generic config
+func NewOCPP2FromConfig(other map[string]interface{}) (api.Charger, error) {
+	cc := struct {
+		StationId        string
+		IdTag            string
+		Evse             int
+		MeterInterval    time.Duration
+		MeterValues      string
+		ConnectTimeout   time.Duration
+		Timeout          time.Duration
+		BootNotification *bool
+		GetVariables     *bool
+	}{
+		Evse:           1,
+		IdTag:          defaultIdTag,
+		ConnectTimeout: ocppConnectTimeout,
+		Timeout:        ocppTimeout,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	boot := cc.BootNotification != nil && *cc.BootNotification
+	noVariables := cc.GetVariables != nil && !*cc.GetVariables
+
+	c, err := NewOCPP2(cc.StationId, cc.Evse, cc.IdTag,
+		cc.MeterValues, cc.MeterInterval,
+		boot, noVariables,
+		cc.ConnectTimeout, cc.Timeout)
+	if err != nil {
+		return c, err
+	}
+
+	var powerG func() (float64, error)
+	if c.hasMeasurement(types.MeasurandPowerActiveImport) {
+		powerG = c.currentPower
+	}
+
+	var totalEnergyG func() (float64, error)
+	if c.hasMeasurement(types.MeasurandEnergyActiveImportRegister) {
+		totalEnergyG = c.totalEnergy
+	}
+
+	var currentsG func() (float64, float64, float64, error)
+	if c.hasMeasurement(types.MeasurandCurrentImport) {
+		currentsG = c.currents
+	}
+
+	var phasesS func(int) error
+	if c.phaseSwitching {
+		phasesS = c.phases1p3p
+	}
+
+	return decorateOCPP2(c, powerG, totalEnergyG, currentsG, phasesS), nil
+}
+
+//go:generate go run ../cmd/tools/decorate.go -f decorateOCPP2 -b *OCPP2 -r api.Charger -t "api.Meter,CurrentPower,func() (float64, error)" -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseSwitcher,Phases1p3p,func(int) error"
+
+// NewOCPP2 creates OCPP 2.0.1 charger
+func NewOCPP2(id string, evse int, idtag string,
+	meterValues string, meterInterval time.Duration,
+	boot, noVariables bool,
+	connectTimeout, timeout time.Duration,
+) (*OCPP2, error) {
+	unit := "ocpp2"
+	if id != "" {
+		unit = id
+	}
+	log := util.NewLogger(unit)
+
+	cp := ocpp2.NewChargePoint(log, id, evse, timeout)
+	if err := ocpp2.Instance().Register(id, cp); err != nil {
+		return nil, err
+	}
+
+	c := &OCPP2{
+		log:     log,
+		cp:      cp,
+		evse:    evse,
+		idtag:   idtag,
+		timeout: timeout,
+	}
+
+	c.log.DEBUG.Printf("waiting for charging station: %v", connectTimeout)
+
+	select {
+	case <-time.After(connectTimeout):
+		return nil, api.ErrTimeout
+	case <-cp.HasConnected():
+	}
+
+	// see who's there
+	if boot {
+		ocpp2.Instance().TriggerMessageRequest(cp.ID(), remotecontrol.MessageTriggerBootNotification)
+	}
+
+	var meterSampleInterval time.Duration
+
+	// noVariables mode disables GetVariables
+	if noVariables {
+		c.meterValuesSample = meterValues
+		if meterInterval == 0 {
+			meterInterval = 10 * time.Second
+		}
+	} else {
+		if err := c.getVariables(); err != nil {
+			return nil, err
+		}
+
+		if val, ok := cp.Variable(ocpp2.ComponentSampledDataCtrlr, ocpp2.VariableTxUpdatedMeasurands); ok {
+			c.meterValuesSample = val
+		}
+
+		if val, ok := cp.Variable(ocpp2.ComponentSampledDataCtrlr, ocpp2.VariableTxUpdatedInterval); ok {
+			i, err := strconv.Atoi(val)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", ocpp2.VariableTxUpdatedInterval, err)
+			}
+			meterSampleInterval = time.Duration(i) * time.Second
+		}
+
+		if val, ok := cp.Variable(ocpp2.ComponentSmartChargingCtrlr, ocpp2.VariablePhases3to1); ok {
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", ocpp2.VariablePhases3to1, err)
+			}
+			c.phaseSwitching = b
+		}
+	}
+
+	if meterValues != "" && meterValues != c.meterValuesSample {
+		if err := c.setVariable(ocpp2.ComponentSampledDataCtrlr, ocpp2.VariableTxUpdatedMeasurands, meterValues); err != nil {
+			return nil, err
+		}
+
+		// configuration activated
+		c.meterValuesSample = meterValues
+	}
+
+	// get initial meter values and configure sample rate
+	if c.hasMeasurement(types.MeasurandPowerActiveImport) || c.hasMeasurement(types.MeasurandEnergyActiveImportRegister) {
+		ocpp2.Instance().TriggerEvseMessageRequest(cp.ID(), cp.Evse(), remotecontrol.MessageTriggerMeterValues)
+
+		if !noVariables && meterSampleInterval > meterInterval && meterInterval > 0 {
+			if err := c.setVariable(ocpp2.ComponentSampledDataCtrlr, ocpp2.VariableTxUpdatedInterval, strconv.Itoa(int(meterInterval.Seconds()))); err != nil {
+				return nil, err
+			}
+		}
+
+		// HACK: setup watchdog for meter values if not happy with config
+		if meterInterval > 0 {
+			c.log.DEBUG.Println("enabling meter watchdog")
+			go cp.WatchDog(meterInterval)
+		}
+	}
+
+	// remote transactions are not reported as vehicle identification
+	cp.SetRemoteIdToken(c.idtag)
+
+	return c, cp.Initialized()
+}
+
+// hasMeasurement checks if meterValuesSample contains given measurement
+func (c *OCPP2) hasMeasurement(val types.Measurand) bool {
+	return lo.ContainsBy(strings.Split(c.meterValuesSample, ","), func(s string) bool {
+		return strings.TrimSpace(s) == string(val)
+	})
+}
+
+// getVariables reads the device model variables used by the charger
+func (c *OCPP2) getVariables() error {
+	var data []provisioning.GetVariableData
+	for _, cv := range []types.ComponentVariable{
+		{Component: types.Component{Name: ocpp2.ComponentSampledDataCtrlr}, Variable: types.Variable{Name: ocpp2.VariableTxUpdatedMeasurands}},
+		{Component: types.Component{Name: ocpp2.ComponentSampledDataCtrlr}, Variable: types.Variable{Name: ocpp2.VariableTxUpdatedInterval}},
+		{Component: types.Component{Name: ocpp2.ComponentSmartChargingCtrlr}, Variable: types.Variable{Name: ocpp2.VariablePhases3to1}},
+	} {
+		data = append(data, provisioning.GetVariableData{Component: cv.Component, Variable: cv.Variable})
+	}
+
+	rc := make(chan error, 1)
+
+	err := ocpp2.Instance().GetVariables(c.cp.ID(), func(resp *provisioning.GetVariablesResponse, err error) {
+		if err == nil {
+			for _, res := range resp.GetVariableResult {
+				if res.AttributeStatus != provisioning.GetVariableStatusAccepted {
+					c.log.DEBUG.Printf("unsupported variable %s.%s: %s", res.Component.Name, res.Variable.Name, res.AttributeStatus)
+					continue
+				}
+
+				c.log.TRACE.Printf("%s.%s: %s", res.Component.Name, res.Variable.Name, res.AttributeValue)
+				c.cp.SetVariable(res.Component.Name, res.Variable.Name, res.AttributeValue)
+			}
+		}
+
+		rc <- err
+	}, data)
+
+	return c.wait(err, rc)
+}
+
+// setVariable updates a device model variable
+func (c *OCPP2) setVariable(component, variable, val string) error {
+	rc := make(chan error, 1)
+
+	err := ocpp2.Instance().SetVariables(c.cp.ID(), func(resp *provisioning.SetVariablesResponse, err error) {
+		if err == nil && resp != nil {
+			for _, res := range resp.SetVariableResult {
+				if res.AttributeStatus != provisioning.SetVariableStatusAccepted {
+					err = fmt.Errorf("SetVariables failed: %s", res.AttributeStatus)
+				}
+			}
+		}
+
+		if err == nil {
+			c.cp.SetVariable(component, variable, val)
+		}
+
+		rc <- err
+	}, []provisioning.SetVariableData{{
+		Component:      types.Component{Name: component},
+		Variable:       types.Variable{Name: variable},
+		AttributeValue: val,
+	}})
+
+	return c.wait(err, rc)
+}
+
+// wait waits for a CP roundtrip with timeout
+func (c *OCPP2) wait(err error, rc chan error) error {
+	if err == nil {
+		select {
+		case err = <-rc:
+			close(rc)
+		case <-time.After(c.timeout):
+			err = api.ErrTimeout
+		}
+	}
+	return err
+}
+
+// Status implements the api.Charger interface
+func (c *OCPP2) Status() (api.ChargeStatus, error) {
+	return c.cp.Status()
+}
+
+// Enabled implements the api.Charger interface
+func (c *OCPP2) Enabled() (bool, error) {
+	return c.cp.Enabled()
+}
+
+// Enable implements the api.Charger interface
+func (c *OCPP2) Enable(enable bool) error {
+	err := c.enable(enable)
+	if err == nil {
+		c.cp.SetEnabled(enable)
+	}
+	return err
+}
+
+func (c *OCPP2) enable(enable bool) error {
+	var err error
+	rc := make(chan error, 1)
+
+	if enable {
+		var txn string
+		if txn, err = c.cp.TransactionID(); err != nil {
+			return err
+		}
+
+		// transaction already started by the charging station, e.g. on ev connect
+		if txn != "" {
+			return c.updatePeriod(c.current, c.phases)
+		}
+
+		c.remoteStartId++
+
+		idToken := types.IdToken{IdToken: c.idtag, Type: types.IdTokenTypeCentral}
+
+		err = ocpp2.Instance().RequestStartTransaction(c.cp.ID(), func(resp *remotecontrol.RequestStartTransactionResponse, err error) {
+			if err == nil && resp != nil && resp.Status != remotecontrol.RequestStartStopStatusAccepted {
+				err = errors.New(string(resp.Status))
+			}
+
+			rc <- err
+		}, c.remoteStartId, idToken, func(request *remotecontrol.RequestStartTransactionRequest) {
+			request.EvseID = &c.evse
+			request.ChargingProfile = getTxChargingProfile2(c.current, c.phases, "")
+		})
+	} else {
+		var txn string
+		txn, err = c.cp.TransactionID()
+		if err != nil {
+			return err
+		}
+
+		if txn == "" {
+			return errors.New("unknown transaction running, cannot disable")
+		}
+
+		err = ocpp2.Instance().RequestStopTransaction(c.cp.ID(), func(resp *remotecontrol.RequestStopTransactionResponse, err error) {
+			if err == nil && resp != nil && resp.Status != remotecontrol.RequestStartStopStatusAccepted {
+				err = errors.New(string(resp.Status))
+			}
+
+			rc <- err
+		}, txn)
+	}
+
+	return c.wait(err, rc)
+}
+
+func (c *OCPP2) setChargingProfile(evse int, profile *types.ChargingProfile) error {
+	rc := make(chan error, 1)
+	err := ocpp2.Instance().SetChargingProfile(c.cp.ID(), func(resp *smartcharging.SetChargingProfileResponse, err error) {
+		if err == nil && resp != nil && resp.Status != smartcharging.ChargingProfileStatusAccepted {
+			err = errors.New(string(resp.Status))
+		}
+
+		rc <- err
+	}, evse, profile)
+
+	return c.wait(err, rc)
+}
+
+// updatePeriod sets a single charging schedule period with given current and phases
+func (c *OCPP2) updatePeriod(current float64, phases int) error {
+	// transaction profiles can only be updated if transaction is active
+	txn, err := c.cp.TransactionID()
+	if err != nil || txn == "" {
+		return err
+	}
+
+	current = math.Trunc(10*current) / 10
+
+	err = c.setChargingProfile(c.evse, getTxChargingProfile2(current, phases, txn))
+	if err != nil {
+		err = fmt.Errorf("set charging profile: %w", err)
+	}
+
+	return err
+}
+
+func getTxChargingProfile2(current float64, phases int, txn string) *types.ChargingProfile {
+	period := types.NewChargingSchedulePeriod(0, current)
+	if phases != 0 {
+		period.NumberPhases = &phases
+	}
+
+	return &types.ChargingProfile{
+		ID:                     1,
+		StackLevel:             0,
+		ChargingProfilePurpose: types.ChargingProfilePurposeTxProfile,
+		ChargingProfileKind:    types.ChargingProfileKindRelative,
+		TransactionID:          txn,
+		ChargingSchedule: []types.ChargingSchedule{
+			*types.NewChargingSchedule(1, types.ChargingRateUnitAmperes, period),
+		},
+	}
+}
+
+// MaxCurrent implements the api.Charger interface
+func (c *OCPP2) MaxCurrent(current int64) error {
+	return c.MaxCurrentMillis(float64(current))
+}
+
+var _ api.ChargerEx = (*OCPP2)(nil)
+
+// MaxCurrentMillis implements the api.ChargerEx interface
+func (c *OCPP2) MaxCurrentMillis(current float64) error {
+	err := c.updatePeriod(current, c.phases)
+	if err == nil {
+		c.current = current
+	}
+	return err
+}
+
+// CurrentPower implements the api.Meter interface
+func (c *OCPP2) currentPower() (float64, error) {
+	return c.cp.CurrentPower()
+}
+
+// TotalEnergy implements the api.MeterTotal interface
+func (c *OCPP2) totalEnergy() (float64, error) {
+	return c.cp.TotalEnergy()
+}
+
+// Currents implements the api.PhaseCurrents interface
+func (c *OCPP2) currents() (float64, float64, float64, error) {
+	return c.cp.Currents()
+}
+
+// Phases1p3p implements the api.PhaseSwitcher interface
+func (c *OCPP2) phases1p3p(phases int) error {
+	// phases are applied with the next transaction profile if no transaction is active
+	err := c.updatePeriod(c.current, phases)
+	if err == nil {
+		c.phases = phases
+	}
+	return err
+}
+
+var _ api.Identifier = (*OCPP2)(nil)
+
+// Identify implements the api.Identifier interface
+func (c *OCPP2) Identify() (string, error) {
+	return c.cp.IdToken()
+}
@@ -0,0 +1,364 @@
+package ocpp2
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+const (
+	// Sampled data controller variables
+	ComponentSampledDataCtrlr   = "SampledDataCtrlr"
+	VariableTxUpdatedMeasurands = "TxUpdatedMeasurands"
+	VariableTxUpdatedInterval   = "TxUpdatedInterval"
+
+	// Smart charging controller variables
+	ComponentSmartChargingCtrlr = "SmartChargingCtrlr"
+	VariablePhases3to1          = "Phases3to1"
+)
+
+// CP is a single EVSE of an OCPP 2.0.1 charging station
+type CP struct {
+	mu    sync.Mutex
+	once  sync.Once
+	clock clock.Clock // mockable time
+	log   *util.Logger
+
+	id   string
+	evse int
+
+	connectC, statusC chan struct{}
+	connected         bool
+	status            *availability.StatusNotificationRequest
+
+	meterUpdated time.Time
+	timeout      time.Duration
+
+	measurements map[string]types.SampledValue
+	variables    map[string]string // device model
+
+	txnId         string
+	chargingState transactions.ChargingState
+	enabled       bool // charging enabled by evcc, reset when the transaction ends
+
+	remoteIdToken string // id token used for remote transactions
+	idToken       string // id token of the current session
+}
+
+func NewChargePoint(log *util.Logger, id string, evse int, timeout time.Duration) *CP {
+	return &CP{
+		clock:        clock.New(),
+		log:          log,
+		id:           id,
+		evse:         evse,
+		connectC:     make(chan struct{}),
+		statusC:      make(chan struct{}),
+		measurements: make(map[string]types.SampledValue),
+		variables:    make(map[string]string),
+		timeout:      timeout,
+	}
+}
+
+func (cp *CP) TestClock(clock clock.Clock) {
+	cp.clock = clock
+}
+
+func (cp *CP) ID() string {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	return cp.id
+}
+
+func (cp *CP) RegisterID(id string) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if cp.id != "" {
+		panic("ocpp2: cannot re-register id")
+	}
+
+	cp.id = id
+}
+
+func (cp *CP) Evse() int {
+	return cp.evse
+}
+
+func (cp *CP) connect(connect bool) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.connected = connect
+
+	if connect {
+		cp.once.Do(func() {
+			close(cp.connectC)
+		})
+	}
+}
+
+func (cp *CP) HasConnected() <-chan struct{} {
+	return cp.connectC
+}
+
+func (cp *CP) Initialized() error {
+	// trigger status
+	time.AfterFunc(cp.timeout/2, func() {
+		select {
+		case <-cp.statusC:
+			return
+		default:
+			Instance().TriggerEvseMessageRequest(cp.ID(), cp.evse, remotecontrol.MessageTriggerStatusNotification)
+		}
+	})
+
+	// wait for status
+	select {
+	case <-cp.statusC:
+		return nil
+	case <-time.After(cp.timeout):
+		return api.ErrTimeout
+	}
+}
+
+func variableKey(component, variable string) string {
+	return strings.ToLower(component + "." + variable)
+}
+
+// SetVariable stores a device model variable
+func (cp *CP) SetVariable(component, variable, value string) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.variables[variableKey(component, variable)] = value
+}
+
+// Variable returns a device model variable as reported by the charging station
+func (cp *CP) Variable(component, variable string) (string, bool) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	val, ok := cp.variables[variableKey(component, variable)]
+	return val, ok
+}
+
+// SetRemoteIdToken sets the id token used for remote transactions
+func (cp *CP) SetRemoteIdToken(idToken string) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.remoteIdToken = idToken
+}
+
+// IdToken returns the id token presented for the current session, excluding the remote id token
+func (cp *CP) IdToken() (string, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if !cp.connected {
+		return "", api.ErrTimeout
+	}
+
+	if cp.idToken == cp.remoteIdToken {
+		return "", nil
+	}
+
+	return cp.idToken, nil
+}
+
+// TransactionID returns the current transaction id
+func (cp *CP) TransactionID() (string, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if !cp.connected {
+		return "", api.ErrTimeout
+	}
+
+	return cp.txnId, nil
+}
+
+// SetEnabled stores the charging state commanded by evcc
+func (cp *CP) SetEnabled(enabled bool) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.enabled = enabled
+}
+
+// Enabled returns the charging state commanded by evcc. Transactions started by the
+// charging station itself, e.g. on ev connect, do not enable charging.
+func (cp *CP) Enabled() (bool, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if !cp.connected {
+		return false, api.ErrTimeout
+	}
+
+	return cp.enabled, nil
+}
+
+func (cp *CP) Status() (api.ChargeStatus, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	res := api.StatusNone
+
+	if !cp.connected {
+		return res, api.ErrTimeout
+	}
+
+	switch cp.status.ConnectorStatus {
+	case availability.ConnectorStatusAvailable, // "Available"
+		availability.ConnectorStatusUnavailable: // "Unavailable"
+		res = api.StatusA
+	case availability.ConnectorStatusOccupied: // "Occupied"
+		// connector status does not distinguish connected and charging
+		res = api.StatusB
+		if cp.txnId != "" && cp.chargingState == transactions.ChargingStateCharging {
+			res = api.StatusC
+		}
+	case availability.ConnectorStatusReserved, // "Reserved"
+		availability.ConnectorStatusFaulted: // "Faulted"
+		return api.StatusF, fmt.Errorf("connector status: %s", cp.status.ConnectorStatus)
+	default:
+		return api.StatusNone, fmt.Errorf("invalid connector status: %s", cp.status.ConnectorStatus)
+	}
+
+	return res, nil
+}
+
+// WatchDog triggers meter values messages if older than timeout.
+// Must be wrapped in a goroutine.
+func (cp *CP) WatchDog(timeout time.Duration) {
+	for ; true; <-time.Tick(timeout) {
+		cp.mu.Lock()
+		update := cp.txnId != "" && cp.clock.Since(cp.meterUpdated) > timeout
+		cp.mu.Unlock()
+
+		if update {
+			Instance().TriggerEvseMessageRequest(cp.ID(), cp.evse, remotecontrol.MessageTriggerMeterValues)
+		}
+	}
+}
+
+func (cp *CP) isTimeout() bool {
+	return cp.timeout > 0 && cp.clock.Since(cp.meterUpdated) > cp.timeout
+}
+
+var _ api.Meter = (*CP)(nil)
+
+func (cp *CP) CurrentPower() (float64, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if !cp.connected {
+		return 0, api.ErrTimeout
+	}
+
+	// zero value on timeout when not charging
+	if cp.isTimeout() {
+		if cp.txnId != "" {
+			return 0, api.ErrTimeout
+		}
+
+		return 0, nil
+	}
+
+	if m, ok := cp.measurements[string(types.MeasurandPowerActiveImport)]; ok {
+		return sampleValue(m), nil
+	}
+
+	return 0, api.ErrNotAvailable
+}
+
+var _ api.MeterEnergy = (*CP)(nil)
+
+func (cp *CP) TotalEnergy() (float64, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if !cp.connected {
+		return 0, api.ErrTimeout
+	}
+
+	// fallthrough for last value on timeout when not charging
+	if cp.txnId != "" && cp.isTimeout() {
+		return 0, api.ErrTimeout
+	}
+
+	if m, ok := cp.measurements[string(types.MeasurandEnergyActiveImportRegister)]; ok {
+		return sampleValue(m) / 1e3, nil
+	}
+
+	return 0, api.ErrNotAvailable
+}
+
+// sampleValue returns the sampled value in base units, i.e. W, Wh or A
+func sampleValue(s types.SampledValue) float64 {
+	f := s.Value
+
+	if u := s.UnitOfMeasure; u != nil {
+		if u.Multiplier != nil {
+			f *= math.Pow10(*u.Multiplier)
+		}
+
+		switch {
+		case strings.HasPrefix(u.Unit, "k"):
+			f *= 1e3
+		case strings.HasPrefix(u.Unit, "m"):
+			f /= 1e3
+		}
+	}
+
+	return f
+}
+
+func getKeyCurrentPhase(phase int) string {
+	return fmt.Sprintf("%s@L%d", types.MeasurandCurrentImport, phase)
+}
+
+var _ api.PhaseCurrents = (*CP)(nil)
+
+func (cp *CP) Currents() (float64, float64, float64, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if !cp.connected {
+		return 0, 0, 0, api.ErrTimeout
+	}
+
+	// zero value on timeout when not charging
+	if cp.isTimeout() {
+		if cp.txnId != "" {
+			return 0, 0, 0, api.ErrTimeout
+		}
+
+		return 0, 0, 0, nil
+	}
+
+	currents := make([]float64, 0, 3)
+
+	for phase := 1; phase <= 3; phase++ {
+		m, ok := cp.measurements[getKeyCurrentPhase(phase)]
+		if !ok {
+			return 0, 0, 0, api.ErrNotAvailable
+		}
+
+		currents = append(currents, sampleValue(m))
+	}
+
+	return currents[0], currents[1], currents[2], nil
+}
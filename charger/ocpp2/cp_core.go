@@ -0,0 +1,207 @@
+package ocpp2
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/authorization"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+const (
+	messageExpiry     = 30 * time.Second
+	transactionExpiry = time.Hour
+)
+
+func (cp *CP) Authorize(request *authorization.AuthorizeRequest) (*authorization.AuthorizeResponse, error) {
+	status := types.AuthorizationStatusAccepted
+	if request != nil {
+		status = cp.identify(request.IdToken)
+	}
+
+	return authorization.NewAuthorizationResponse(*types.NewIdTokenInfo(status)), nil
+}
+
+// identify accepts the id token and stores it for vehicle identification
+func (cp *CP) identify(idToken types.IdToken) types.AuthorizationStatus {
+	cp.log.DEBUG.Printf("authorize id token %s (%s)", idToken.IdToken, idToken.Type)
+
+	if idToken.Type != types.IdTokenTypeNoAuthorization && idToken.IdToken != "" {
+		cp.mu.Lock()
+		cp.idToken = idToken.IdToken
+		cp.mu.Unlock()
+	}
+
+	return types.AuthorizationStatusAccepted
+}
+
+func (cp *CP) BootNotification(request *provisioning.BootNotificationRequest) (*provisioning.BootNotificationResponse, error) {
+	if request != nil {
+		cp.log.DEBUG.Printf("boot notification: %s %s (%s)", request.ChargingStation.VendorName, request.ChargingStation.Model, request.Reason)
+	}
+
+	res := provisioning.NewBootNotificationResponse(types.NewDateTime(cp.clock.Now()), 60, provisioning.RegistrationStatusAccepted) // TODO interval
+
+	return res, nil
+}
+
+// NotifyReport updates the device model from the charging station's report
+func (cp *CP) NotifyReport(request *provisioning.NotifyReportRequest) (*provisioning.NotifyReportResponse, error) {
+	if request != nil {
+		cp.mu.Lock()
+		defer cp.mu.Unlock()
+
+		for _, data := range request.ReportData {
+			// ignore other evses and variable instances
+			if evse := data.Component.EVSE; evse != nil && evse.ID != cp.evse || data.Variable.Instance != "" {
+				continue
+			}
+
+			for _, attr := range data.VariableAttribute {
+				if attr.Type == "" || attr.Type == types.AttributeActual {
+					cp.variables[variableKey(data.Component.Name, data.Variable.Name)] = attr.Value
+				}
+			}
+		}
+	}
+
+	return provisioning.NewNotifyReportResponse(), nil
+}
+
+func (cp *CP) Heartbeat(request *availability.HeartbeatRequest) (*availability.HeartbeatResponse, error) {
+	return availability.NewHeartbeatResponse(*types.NewDateTime(cp.clock.Now())), nil
+}
+
+// timestampValid returns false if status timestamps are outdated
+func (cp *CP) timestampValid(t time.Time) bool {
+	// reject if expired
+	if time.Since(t) > messageExpiry {
+		return false
+	}
+
+	// assume having a timestamp is better than not
+	if cp.status.Timestamp == nil {
+		return true
+	}
+
+	// reject older values than we already have
+	return !t.Before(cp.status.Timestamp.Time)
+}
+
+func (cp *CP) StatusNotification(request *availability.StatusNotificationRequest) (*availability.StatusNotificationResponse, error) {
+	if request != nil && request.EvseID == cp.evse {
+		cp.mu.Lock()
+		defer cp.mu.Unlock()
+
+		if cp.status == nil {
+			cp.status = request
+			close(cp.statusC) // signal initial status received
+		} else if request.Timestamp == nil || cp.timestampValid(request.Timestamp.Time) {
+			cp.status = request
+		} else {
+			cp.log.TRACE.Printf("ignoring status: %s < %s", request.Timestamp.Time, cp.status.Timestamp)
+		}
+
+		// vehicle disconnected
+		if cp.status.ConnectorStatus == availability.ConnectorStatusAvailable {
+			cp.idToken = ""
+		}
+	}
+
+	return availability.NewStatusNotificationResponse(), nil
+}
+
+func (cp *CP) MeterValues(request *meter.MeterValuesRequest) (*meter.MeterValuesResponse, error) {
+	if request != nil && request.EvseID == cp.evse {
+		cp.mu.Lock()
+		defer cp.mu.Unlock()
+
+		cp.updateMeasurements(request.MeterValue)
+	}
+
+	return meter.NewMeterValuesResponse(), nil
+}
+
+// updateMeasurements stores the sampled values. Must be called with lock held.
+func (cp *CP) updateMeasurements(meterValues []types.MeterValue) {
+	for _, meterValue := range meterValues {
+		// ignore old meter value requests
+		if meterValue.Timestamp.Time.After(cp.meterUpdated) {
+			for _, sample := range meterValue.SampledValue {
+				cp.measurements[getSampleKey(sample)] = sample
+				cp.meterUpdated = cp.clock.Now()
+			}
+		}
+	}
+}
+
+func getSampleKey(s types.SampledValue) string {
+	// measurand defaults to energy register
+	measurand := s.Measurand
+	if measurand == "" {
+		measurand = types.MeasurandEnergyActiveImportRegister
+	}
+
+	// phase may be qualified with neutral, i.e. L1-N
+	if s.Phase != "" {
+		phase, _, _ := strings.Cut(string(s.Phase), "-")
+		return string(measurand) + "@" + phase
+	}
+
+	return string(measurand)
+}
+
+func (cp *CP) TransactionEvent(request *transactions.TransactionEventRequest) (*transactions.TransactionEventResponse, error) {
+	res := new(transactions.TransactionEventResponse)
+	if request == nil {
+		return res, nil
+	}
+
+	cp.mu.Lock()
+	// evse is only sent with the first event of a transaction
+	match := request.Evse != nil && request.Evse.ID == cp.evse ||
+		request.Evse == nil && request.TransactionInfo.TransactionID == cp.txnId
+	cp.mu.Unlock()
+
+	if !match {
+		return res, nil
+	}
+
+	if request.IDToken != nil {
+		res.IDTokenInfo = types.NewIdTokenInfo(cp.identify(*request.IDToken))
+	}
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.updateMeasurements(request.MeterValue)
+
+	// only respect transactions in the last hour
+	if request.Timestamp != nil && time.Since(request.Timestamp.Time) > transactionExpiry {
+		return res, nil
+	}
+
+	switch request.EventType {
+	case transactions.TransactionEventStarted, transactions.TransactionEventUpdated:
+		cp.txnId = request.TransactionInfo.TransactionID
+		if state := request.TransactionInfo.ChargingState; state != "" {
+			cp.chargingState = state
+		}
+
+	case transactions.TransactionEventEnded:
+		// log mismatching id but close transaction anyway
+		if request.TransactionInfo.TransactionID != cp.txnId {
+			cp.log.ERROR.Printf("transaction ended: invalid id %s", request.TransactionInfo.TransactionID)
+		}
+
+		cp.txnId = ""
+		cp.chargingState = transactions.ChargingStateIdle
+		cp.enabled = false
+	}
+
+	return res, nil
+}
@@ -0,0 +1,135 @@
+package ocpp2
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/evcc-io/evcc/util"
+	ocpp2 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
+	"golang.org/x/exp/slices"
+)
+
+// station is a charging station with its configured evses
+type station struct {
+	connected bool
+	evses     []*CP
+}
+
+type CS struct {
+	mu  sync.Mutex
+	log *util.Logger
+	ocpp2.CSMS
+	stations map[string]*station
+}
+
+// Register registers an evse of a charging station with the CSMS.
+// The charging station identified by id may already be connected in which case initial connection is triggered.
+func (cs *CS) Register(id string, cp *CP) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	st, ok := cs.stations[id]
+	if !ok {
+		st = new(station)
+		cs.stations[id] = st
+	}
+
+	if slices.ContainsFunc(st.evses, func(c *CP) bool { return c.Evse() == cp.Evse() }) {
+		if id == "" {
+			return fmt.Errorf("cannot have >1 charging station with empty station id and evse %d", cp.Evse())
+		}
+		return fmt.Errorf("cannot have >1 charging station with station id %s and evse %d", id, cp.Evse())
+	}
+
+	st.evses = append(st.evses, cp)
+
+	// trigger charging station already connected
+	if st.connected {
+		cp.connect(true)
+	}
+
+	return nil
+}
+
+// errorHandler logs error channel
+func (cs *CS) errorHandler(errC <-chan error) {
+	for err := range errC {
+		cs.log.ERROR.Println(err)
+	}
+}
+
+// chargingStationByID returns the configured evses of the charging station
+func (cs *CS) chargingStationByID(id string) ([]*CP, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	st, ok := cs.stations[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown charging station: %s", id)
+	}
+	if len(st.evses) == 0 {
+		return nil, fmt.Errorf("charging station not configured: %s", id)
+	}
+	return slices.Clone(st.evses), nil
+}
+
+func (cs *CS) NewChargingStation(cst ocpp2.ChargingStationConnection) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	st, ok := cs.stations[cst.ID()]
+	if !ok {
+		// check for anonymous charging station
+		if st, ok := cs.stations[""]; ok {
+			cs.log.INFO.Printf("charging station connected, registering: %s", cst.ID())
+
+			// update id
+			for _, cp := range st.evses {
+				cp.RegisterID(cst.ID())
+			}
+			cs.stations[cst.ID()] = st
+			delete(cs.stations, "")
+
+			cs.connect(st, true)
+
+			return
+		}
+
+		cs.log.WARN.Printf("charging station connected, unknown: %s", cst.ID())
+
+		// register unknown charging station
+		// when charging station setup is complete, it will eventually be associated with the connected id
+		cs.stations[cst.ID()] = &station{connected: true}
+	} else {
+		cs.log.DEBUG.Printf("charging station connected: %s", cst.ID())
+
+		// trigger initial connection if charging station is already setup
+		cs.connect(st, true)
+	}
+}
+
+func (cs *CS) ChargingStationDisconnected(cst ocpp2.ChargingStationConnection) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if st, ok := cs.stations[cst.ID()]; !ok {
+		cs.log.ERROR.Printf("charging station disconnected: unknown charging station: %s", cst.ID())
+	} else {
+		cs.log.DEBUG.Printf("charging station disconnected: %s", cst.ID())
+
+		if len(st.evses) == 0 {
+			// remove unknown charging station
+			delete(cs.stations, cst.ID())
+		} else {
+			cs.connect(st, false)
+		}
+	}
+}
+
+// connect updates the connection status of the charging station and its evses
+func (cs *CS) connect(st *station, connect bool) {
+	st.connected = connect
+	for _, cp := range st.evses {
+		cp.connect(connect)
+	}
+}
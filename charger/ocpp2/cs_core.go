@@ -0,0 +1,145 @@
+package ocpp2
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/authorization"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+// cs actions
+
+func (cs *CS) TriggerMessageRequest(id string, requestedMessage remotecontrol.MessageTrigger, props ...func(request *remotecontrol.TriggerMessageRequest)) {
+	if err := cs.TriggerMessage(id, func(request *remotecontrol.TriggerMessageResponse, err error) {
+		log := cs.log.TRACE
+		if err == nil && request != nil && request.Status != remotecontrol.TriggerMessageStatusAccepted {
+			log = cs.log.ERROR
+		}
+
+		var status remotecontrol.TriggerMessageStatus
+		if request != nil {
+			status = request.Status
+		}
+
+		log.Printf("TriggerMessage %s for %s: %+v", requestedMessage, id, status)
+	}, requestedMessage, props...); err != nil {
+		cs.log.ERROR.Printf("send TriggerMessage %s for %s failed: %v", requestedMessage, id, err)
+	}
+}
+
+func (cs *CS) TriggerEvseMessageRequest(id string, evse int, requestedMessage remotecontrol.MessageTrigger) {
+	cs.TriggerMessageRequest(id, requestedMessage, func(request *remotecontrol.TriggerMessageRequest) {
+		request.Evse = &types.EVSE{ID: evse}
+	})
+}
+
+// cp actions
+
+// OnAuthorize assigns the id token to the evse if the charging station has a single evse.
+// Otherwise the id token is assigned by the transaction event.
+func (cs *CS) OnAuthorize(id string, request *authorization.AuthorizeRequest) (*authorization.AuthorizeResponse, error) {
+	cps, err := cs.chargingStationByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cps) > 1 {
+		return authorization.NewAuthorizationResponse(*types.NewIdTokenInfo(types.AuthorizationStatusAccepted)), nil
+	}
+
+	return cps[0].Authorize(request)
+}
+
+func (cs *CS) OnBootNotification(id string, request *provisioning.BootNotificationRequest) (*provisioning.BootNotificationResponse, error) {
+	cps, err := cs.chargingStationByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return cps[0].BootNotification(request)
+}
+
+func (cs *CS) OnNotifyReport(id string, request *provisioning.NotifyReportRequest) (*provisioning.NotifyReportResponse, error) {
+	cps, err := cs.chargingStationByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// each evse picks its variables
+	var res *provisioning.NotifyReportResponse
+	for _, cp := range cps {
+		if res, err = cp.NotifyReport(request); err != nil {
+			break
+		}
+	}
+
+	return res, err
+}
+
+func (cs *CS) OnHeartbeat(id string, request *availability.HeartbeatRequest) (*availability.HeartbeatResponse, error) {
+	cps, err := cs.chargingStationByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return cps[0].Heartbeat(request)
+}
+
+func (cs *CS) OnStatusNotification(id string, request *availability.StatusNotificationRequest) (*availability.StatusNotificationResponse, error) {
+	cps, err := cs.chargingStationByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// each evse ignores notifications of other evses
+	var res *availability.StatusNotificationResponse
+	for _, cp := range cps {
+		if res, err = cp.StatusNotification(request); err != nil {
+			break
+		}
+	}
+
+	return res, err
+}
+
+func (cs *CS) OnMeterValues(id string, request *meter.MeterValuesRequest) (*meter.MeterValuesResponse, error) {
+	cps, err := cs.chargingStationByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// each evse ignores meter values of other evses
+	var res *meter.MeterValuesResponse
+	for _, cp := range cps {
+		if res, err = cp.MeterValues(request); err != nil {
+			break
+		}
+	}
+
+	return res, err
+}
+
+func (cs *CS) OnTransactionEvent(id string, request *transactions.TransactionEventRequest) (*transactions.TransactionEventResponse, error) {
+	cps, err := cs.chargingStationByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// each evse ignores transactions of other evses, the matching evse answers the id token
+	var res *transactions.TransactionEventResponse
+	for _, cp := range cps {
+		r, err := cp.TransactionEvent(request)
+		if err != nil {
+			return nil, err
+		}
+
+		if res == nil || r.IDTokenInfo != nil {
+			res = r
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,103 @@
+package ocpp2
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testConnection string
+
+func (c testConnection) ID() string                               { return string(c) }
+func (c testConnection) RemoteAddr() net.Addr                     { return nil }
+func (c testConnection) TLSConnectionState() *tls.ConnectionState { return nil }
+
+func TestMultipleEvses(t *testing.T) {
+	cs := &CS{
+		log:      util.NewLogger("foo"),
+		stations: make(map[string]*station),
+	}
+
+	log := util.NewLogger("foo")
+	evse1 := NewChargePoint(log, "station", 1, time.Minute)
+	evse2 := NewChargePoint(log, "station", 2, time.Minute)
+
+	require.NoError(t, cs.Register("station", evse1))
+	require.NoError(t, cs.Register("station", evse2))
+	assert.Error(t, cs.Register("station", NewChargePoint(log, "station", 2, time.Minute)), "duplicate evse")
+
+	cs.NewChargingStation(testConnection("station"))
+
+	for _, cp := range []*CP{evse1, evse2} {
+		select {
+		case <-cp.HasConnected():
+		default:
+			t.Fatalf("evse %d not connected", cp.Evse())
+		}
+	}
+
+	// status is dispatched by evse
+	for _, evse := range []int{1, 2} {
+		status := availability.ConnectorStatusAvailable
+		if evse == 2 {
+			status = availability.ConnectorStatusOccupied
+		}
+
+		_, err := cs.OnStatusNotification("station", availability.NewStatusNotificationRequest(types.NewDateTime(time.Now()), status, evse, 1))
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, availability.ConnectorStatusAvailable, evse1.status.ConnectorStatus)
+	assert.Equal(t, availability.ConnectorStatusOccupied, evse2.status.ConnectorStatus)
+
+	// transaction is assigned to its evse
+	_, err := cs.OnTransactionEvent("station", &transactions.TransactionEventRequest{
+		EventType:       transactions.TransactionEventStarted,
+		Timestamp:       types.NewDateTime(time.Now()),
+		TransactionInfo: transactions.Transaction{TransactionID: "txn"},
+		Evse:            &types.EVSE{ID: 2},
+	})
+	require.NoError(t, err)
+
+	txn, err := evse1.TransactionID()
+	require.NoError(t, err)
+	assert.Empty(t, txn)
+
+	txn, err = evse2.TransactionID()
+	require.NoError(t, err)
+	assert.Equal(t, "txn", txn)
+
+	// transaction started by the charging station does not enable charging
+	enabled, err := evse2.Enabled()
+	require.NoError(t, err)
+	assert.False(t, enabled)
+
+	evse2.SetEnabled(true)
+
+	_, err = cs.OnTransactionEvent("station", &transactions.TransactionEventRequest{
+		EventType:       transactions.TransactionEventEnded,
+		Timestamp:       types.NewDateTime(time.Now()),
+		TransactionInfo: transactions.Transaction{TransactionID: "txn"},
+	})
+	require.NoError(t, err)
+
+	enabled, err = evse2.Enabled()
+	require.NoError(t, err)
+	assert.False(t, enabled, "transaction ended")
+
+	// disconnect applies to all evses
+	cs.ChargingStationDisconnected(testConnection("station"))
+
+	_, err = evse1.Enabled()
+	assert.Error(t, err)
+	_, err = evse2.Enabled()
+	assert.Error(t, err)
+}
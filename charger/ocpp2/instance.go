@@ -0,0 +1,49 @@
+package ocpp2
+
+import (
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	ocpp2 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
+	"github.com/lorenzodonini/ocpp-go/ws"
+)
+
+var (
+	once     sync.Once
+	instance *CS
+)
+
+// Instance returns the OCPP 2.0.1 CSMS. It is started on first use and listens on port 8888.
+func Instance() *CS {
+	once.Do(func() {
+		timeoutConfig := ws.NewServerTimeoutConfig()
+		timeoutConfig.PingWait = 90 * time.Second
+
+		server := ws.NewServer()
+		server.SetTimeoutConfig(timeoutConfig)
+
+		csms := ocpp2.NewCSMS(nil, server)
+
+		instance = &CS{
+			log:      util.NewLogger("ocpp2"),
+			stations: make(map[string]*station),
+			CSMS:     csms,
+		}
+
+		csms.SetAuthorizationHandler(instance)
+		csms.SetAvailabilityHandler(instance)
+		csms.SetMeterHandler(instance)
+		csms.SetProvisioningHandler(instance)
+		csms.SetTransactionsHandler(instance)
+		csms.SetNewChargingStationHandler(instance.NewChargingStation)
+		csms.SetChargingStationDisconnectedHandler(instance.ChargingStationDisconnected)
+
+		go instance.errorHandler(csms.Errors())
+		go csms.Start(8888, "/{ws}")
+
+		time.Sleep(time.Second)
+	})
+
+	return instance
+}
@@ -0,0 +1,281 @@
+package charger
+
+// Code generated by github.com/evcc-io/evcc/cmd/tools/decorate.go. DO NOT EDIT.
+
+import (
+	"github.com/evcc-io/evcc/api"
+)
+
+func decorateOCPP2(base *OCPP2, meter func() (float64, error), meterEnergy func() (float64, error), phaseCurrents func() (float64, float64, float64, error), phaseSwitcher func(phases int) error) api.Charger {
+	switch {
+	case meter == nil && meterEnergy == nil && phaseCurrents == nil && phaseSwitcher == nil:
+		return base
+
+	case meter != nil && meterEnergy == nil && phaseCurrents == nil && phaseSwitcher == nil:
+		return &struct {
+			*OCPP2
+			api.Meter
+		}{
+			OCPP2: base,
+			Meter: &decorateOCPP2MeterImpl{
+				meter: meter,
+			},
+		}
+
+	case meter == nil && meterEnergy != nil && phaseCurrents == nil && phaseSwitcher == nil:
+		return &struct {
+			*OCPP2
+			api.MeterEnergy
+		}{
+			OCPP2: base,
+			MeterEnergy: &decorateOCPP2MeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case meter != nil && meterEnergy != nil && phaseCurrents == nil && phaseSwitcher == nil:
+		return &struct {
+			*OCPP2
+			api.Meter
+			api.MeterEnergy
+		}{
+			OCPP2: base,
+			Meter: &decorateOCPP2MeterImpl{
+				meter: meter,
+			},
+			MeterEnergy: &decorateOCPP2MeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case meter == nil && meterEnergy == nil && phaseCurrents != nil && phaseSwitcher == nil:
+		return &struct {
+			*OCPP2
+			api.PhaseCurrents
+		}{
+			OCPP2: base,
+			PhaseCurrents: &decorateOCPP2PhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+		}
+
+	case meter != nil && meterEnergy == nil && phaseCurrents != nil && phaseSwitcher == nil:
+		return &struct {
+			*OCPP2
+			api.Meter
+			api.PhaseCurrents
+		}{
+			OCPP2: base,
+			Meter: &decorateOCPP2MeterImpl{
+				meter: meter,
+			},
+			PhaseCurrents: &decorateOCPP2PhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+		}
+
+	case meter == nil && meterEnergy != nil && phaseCurrents != nil && phaseSwitcher == nil:
+		return &struct {
+			*OCPP2
+			api.MeterEnergy
+			api.PhaseCurrents
+		}{
+			OCPP2: base,
+			MeterEnergy: &decorateOCPP2MeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseCurrents: &decorateOCPP2PhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+		}
+
+	case meter != nil && meterEnergy != nil && phaseCurrents != nil && phaseSwitcher == nil:
+		return &struct {
+			*OCPP2
+			api.Meter
+			api.MeterEnergy
+			api.PhaseCurrents
+		}{
+			OCPP2: base,
+			Meter: &decorateOCPP2MeterImpl{
+				meter: meter,
+			},
+			MeterEnergy: &decorateOCPP2MeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseCurrents: &decorateOCPP2PhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+		}
+
+	case meter == nil && meterEnergy == nil && phaseCurrents == nil && phaseSwitcher != nil:
+		return &struct {
+			*OCPP2
+			api.PhaseSwitcher
+		}{
+			OCPP2: base,
+			PhaseSwitcher: &decorateOCPP2PhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case meter != nil && meterEnergy == nil && phaseCurrents == nil && phaseSwitcher != nil:
+		return &struct {
+			*OCPP2
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			OCPP2: base,
+			Meter: &decorateOCPP2MeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateOCPP2PhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case meter == nil && meterEnergy != nil && phaseCurrents == nil && phaseSwitcher != nil:
+		return &struct {
+			*OCPP2
+			api.MeterEnergy
+			api.PhaseSwitcher
+		}{
+			OCPP2: base,
+			MeterEnergy: &decorateOCPP2MeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseSwitcher: &decorateOCPP2PhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case meter != nil && meterEnergy != nil && phaseCurrents == nil && phaseSwitcher != nil:
+		return &struct {
+			*OCPP2
+			api.Meter
+			api.MeterEnergy
+			api.PhaseSwitcher
+		}{
+			OCPP2: base,
+			Meter: &decorateOCPP2MeterImpl{
+				meter: meter,
+			},
+			MeterEnergy: &decorateOCPP2MeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseSwitcher: &decorateOCPP2PhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case meter == nil && meterEnergy == nil && phaseCurrents != nil && phaseSwitcher != nil:
+		return &struct {
+			*OCPP2
+			api.PhaseCurrents
+			api.PhaseSwitcher
+		}{
+			OCPP2: base,
+			PhaseCurrents: &decorateOCPP2PhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhaseSwitcher: &decorateOCPP2PhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case meter != nil && meterEnergy == nil && phaseCurrents != nil && phaseSwitcher != nil:
+		return &struct {
+			*OCPP2
+			api.Meter
+			api.PhaseCurrents
+			api.PhaseSwitcher
+		}{
+			OCPP2: base,
+			Meter: &decorateOCPP2MeterImpl{
+				meter: meter,
+			},
+			PhaseCurrents: &decorateOCPP2PhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhaseSwitcher: &decorateOCPP2PhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case meter == nil && meterEnergy != nil && phaseCurrents != nil && phaseSwitcher != nil:
+		return &struct {
+			*OCPP2
+			api.MeterEnergy
+			api.PhaseCurrents
+			api.PhaseSwitcher
+		}{
+			OCPP2: base,
+			MeterEnergy: &decorateOCPP2MeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseCurrents: &decorateOCPP2PhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhaseSwitcher: &decorateOCPP2PhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case meter != nil && meterEnergy != nil && phaseCurrents != nil && phaseSwitcher != nil:
+		return &struct {
+			*OCPP2
+			api.Meter
+			api.MeterEnergy
+			api.PhaseCurrents
+			api.PhaseSwitcher
+		}{
+			OCPP2: base,
+			Meter: &decorateOCPP2MeterImpl{
+				meter: meter,
+			},
+			MeterEnergy: &decorateOCPP2MeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseCurrents: &decorateOCPP2PhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhaseSwitcher: &decorateOCPP2PhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+	}
+
+	return nil
+}
+
+type decorateOCPP2MeterImpl struct {
+	meter func() (float64, error)
+}
+
+func (impl *decorateOCPP2MeterImpl) CurrentPower() (float64, error) {
+	return impl.meter()
+}
+
+type decorateOCPP2MeterEnergyImpl struct {
+	meterEnergy func() (float64, error)
+}
+
+func (impl *decorateOCPP2MeterEnergyImpl) TotalEnergy() (float64, error) {
+	return impl.meterEnergy()
+}
+
+type decorateOCPP2PhaseCurrentsImpl struct {
+	phaseCurrents func() (float64, float64, float64, error)
+}
+
+func (impl *decorateOCPP2PhaseCurrentsImpl) Currents() (float64, float64, float64, error) {
+	return impl.phaseCurrents()
+}
+
+type decorateOCPP2PhaseSwitcherImpl struct {
+	phaseSwitcher func(int) error
+}
+
+func (impl *decorateOCPP2PhaseSwitcherImpl) Phases1p3p(phases int) error {
+	return impl.phaseSwitcher(phases)
+}
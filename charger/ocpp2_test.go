@@ -0,0 +1,278 @@
+package charger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/charger/ocpp2"
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	ocpp201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	ocpp2TestUrl  = "ws://localhost:8888"
+	ocpp2TestEvse = 1
+	ocpp2TestTxn  = "txn1"
+)
+
+func TestOcpp2(t *testing.T) {
+	suite.Run(t, new(ocpp2TestSuite))
+}
+
+type ocpp2TestSuite struct {
+	suite.Suite
+	clock    *clock.Mock
+	cs       ocpp201.ChargingStation
+	status   availability.ConnectorStatus
+	seqNo    int
+	profileC chan *types.ChargingProfile
+}
+
+func (suite *ocpp2TestSuite) SetupSuite() {
+	// setup csms
+	suite.NotNil(ocpp2.Instance())
+
+	// setup charging station
+	suite.clock = clock.NewMock()
+	suite.status = availability.ConnectorStatusAvailable
+	suite.profileC = make(chan *types.ChargingProfile, 1)
+	cs := ocpp201.NewChargingStation("test", nil, nil)
+
+	// set a handler for all callback functions
+	handler := &ChargingStationHandler{
+		triggerC: make(chan remotecontrol.MessageTrigger, 1),
+		requestC: make(chan ocpp.Request, 1),
+	}
+	cs.SetProvisioningHandler(handler)
+	cs.SetRemoteControlHandler(handler)
+	cs.SetSmartChargingHandler(handler)
+
+	go func() {
+		for {
+			select {
+			case msg := <-handler.triggerC:
+				suite.handleTrigger(msg)
+			case req := <-handler.requestC:
+				suite.handleRequest(req)
+			}
+		}
+	}()
+
+	suite.cs = cs
+}
+
+func (suite *ocpp2TestSuite) handleTrigger(msg remotecontrol.MessageTrigger) {
+	switch msg {
+	case remotecontrol.MessageTriggerBootNotification:
+		if res, err := suite.cs.BootNotification(provisioning.BootReasonTriggered, "demo", "evcc"); err != nil {
+			suite.T().Log("BootNotification:", err)
+		} else {
+			suite.T().Log("BootNotification:", res)
+		}
+
+	case remotecontrol.MessageTriggerStatusNotification:
+		suite.statusNotification()
+
+	case remotecontrol.MessageTriggerMeterValues:
+		if res, err := suite.cs.MeterValues(ocpp2TestEvse, []types.MeterValue{
+			{
+				Timestamp: *types.NewDateTime(suite.clock.Now()),
+				SampledValue: []types.SampledValue{
+					{Measurand: types.MeasurandPowerActiveImport, Value: 1000},
+					{Measurand: types.MeasurandEnergyActiveImportRegister, Value: 1.2, UnitOfMeasure: &types.UnitOfMeasure{Unit: "kWh"}},
+					{Measurand: types.MeasurandCurrentImport, Phase: types.PhaseL1N, Value: 10},
+					{Measurand: types.MeasurandCurrentImport, Phase: types.PhaseL2N, Value: 11},
+					{Measurand: types.MeasurandCurrentImport, Phase: types.PhaseL3N, Value: 12},
+				},
+			},
+		}); err != nil {
+			suite.T().Log("MeterValues:", err)
+		} else {
+			suite.T().Log("MeterValues:", res)
+		}
+
+	default:
+		suite.T().Log(msg)
+	}
+}
+
+func (suite *ocpp2TestSuite) statusNotification() {
+	if res, err := suite.cs.StatusNotification(types.NewDateTime(time.Now()), suite.status, ocpp2TestEvse, 1); err != nil {
+		suite.T().Log("StatusNotification:", err)
+	} else {
+		suite.T().Log("StatusNotification:", res)
+	}
+}
+
+func (suite *ocpp2TestSuite) transactionEvent(event transactions.TransactionEvent, reason transactions.TriggerReason, info transactions.Transaction, props ...func(request *transactions.TransactionEventRequest)) {
+	suite.seqNo++
+	if res, err := suite.cs.TransactionEvent(event, types.NewDateTime(time.Now()), reason, suite.seqNo, info, props...); err != nil {
+		suite.T().Log("TransactionEvent:", err)
+	} else {
+		suite.T().Log("TransactionEvent:", res)
+	}
+}
+
+func (suite *ocpp2TestSuite) handleRequest(req ocpp.Request) {
+	switch req := req.(type) {
+	case *remotecontrol.RequestStartTransactionRequest:
+		suite.status = availability.ConnectorStatusOccupied
+		suite.statusNotification()
+
+		suite.transactionEvent(transactions.TransactionEventStarted, transactions.TriggerReasonRemoteStart, transactions.Transaction{
+			TransactionID: ocpp2TestTxn,
+			ChargingState: transactions.ChargingStateCharging,
+			RemoteStartID: &req.RemoteStartID,
+		}, func(request *transactions.TransactionEventRequest) {
+			request.Evse = &types.EVSE{ID: *req.EvseID}
+			request.IDToken = &req.IDToken
+		})
+
+	case *remotecontrol.RequestStopTransactionRequest:
+		suite.transactionEvent(transactions.TransactionEventEnded, transactions.TriggerReasonRemoteStop, transactions.Transaction{
+			TransactionID: req.TransactionID,
+			StoppedReason: transactions.ReasonRemote,
+		})
+
+	case *smartcharging.SetChargingProfileRequest:
+		suite.profileC <- req.ChargingProfile
+	}
+}
+
+func (suite *ocpp2TestSuite) chargingProfile() *types.ChargingProfile {
+	select {
+	case profile := <-suite.profileC:
+		return profile
+	case <-time.After(ocppTestTimeout):
+		suite.Fail("missing charging profile")
+		return nil
+	}
+}
+
+func (suite *ocpp2TestSuite) TestConnect() {
+	// start charging station client
+	suite.NoError(suite.cs.Start(ocpp2TestUrl))
+	suite.True(suite.cs.IsConnected())
+
+	// start charging station server
+	c, err := NewOCPP2("test", ocpp2TestEvse, defaultIdTag, "", 0, false, false, ocppTestConnectTimeout, ocppTestTimeout)
+	suite.NoError(err)
+
+	if err != nil {
+		return
+	}
+
+	suite.clock.Add(ocppTestTimeout)
+	c.cp.TestClock(suite.clock)
+
+	// device model
+	suite.True(c.phaseSwitching)
+	suite.True(c.hasMeasurement(types.MeasurandCurrentImport))
+
+	status, err := c.Status()
+	suite.NoError(err)
+	suite.Equal(api.StatusA, status)
+
+	// power
+	f, err := c.currentPower()
+	suite.NoError(err)
+	suite.Equal(1e3, f)
+
+	// energy
+	f, err = c.totalEnergy()
+	suite.NoError(err)
+	suite.Equal(1.2, f)
+
+	// currents
+	l1, l2, l3, err := c.currents()
+	suite.NoError(err)
+	suite.Equal([]float64{10, 11, 12}, []float64{l1, l2, l3})
+
+	// transaction started on ev connect does not enable charging
+	suite.status = availability.ConnectorStatusOccupied
+	suite.statusNotification()
+	suite.transactionEvent(transactions.TransactionEventStarted, transactions.TriggerReasonCablePluggedIn, transactions.Transaction{
+		TransactionID: ocpp2TestTxn,
+		ChargingState: transactions.ChargingStateSuspendedEVSE,
+	}, func(request *transactions.TransactionEventRequest) {
+		request.Evse = &types.EVSE{ID: ocpp2TestEvse}
+	})
+
+	enabled, err := c.Enabled()
+	suite.NoError(err)
+	suite.False(enabled)
+
+	// enable running transaction by charging profile
+	suite.NoError(c.Enable(true))
+	if profile := suite.chargingProfile(); profile != nil {
+		suite.Equal(ocpp2TestTxn, profile.TransactionID)
+	}
+
+	enabled, err = c.Enabled()
+	suite.NoError(err)
+	suite.True(enabled)
+
+	// remote stop ends the transaction
+	suite.NoError(c.Enable(false))
+	suite.Eventually(func() bool {
+		txn, err := c.cp.TransactionID()
+		return err == nil && txn == ""
+	}, ocppTestTimeout, 10*time.Millisecond)
+
+	// remote start
+	suite.NoError(c.Enable(true))
+	suite.Eventually(func() bool {
+		enabled, err := c.Enabled()
+		return err == nil && enabled
+	}, ocppTestTimeout, 10*time.Millisecond)
+
+	status, err = c.Status()
+	suite.NoError(err)
+	suite.Equal(api.StatusC, status)
+
+	// remote id token is not an identification
+	id, err := c.Identify()
+	suite.NoError(err)
+	suite.Equal("", id)
+
+	// current
+	suite.NoError(c.MaxCurrent(16))
+	if profile := suite.chargingProfile(); profile != nil {
+		suite.Equal(types.ChargingProfilePurposeTxProfile, profile.ChargingProfilePurpose)
+		suite.Equal(ocpp2TestTxn, profile.TransactionID)
+		suite.Equal(16.0, profile.ChargingSchedule[0].ChargingSchedulePeriod[0].Limit)
+	}
+
+	// phases
+	suite.NoError(c.phases1p3p(1))
+	if profile := suite.chargingProfile(); profile != nil {
+		period := profile.ChargingSchedule[0].ChargingSchedulePeriod[0]
+		suite.Equal(16.0, period.Limit)
+		suite.Equal(1, *period.NumberPhases)
+	}
+
+	// rfid
+	res, err := suite.cs.Authorize("rfid", types.IdTokenTypeISO14443)
+	suite.NoError(err)
+	suite.Equal(types.AuthorizationStatusAccepted, res.IdTokenInfo.Status)
+
+	id, err = c.Identify()
+	suite.NoError(err)
+	suite.Equal("rfid", id)
+
+	// remote stop
+	suite.NoError(c.Enable(false))
+	suite.Eventually(func() bool {
+		enabled, err := c.Enabled()
+		return err == nil && !enabled
+	}, ocppTestTimeout, 10*time.Millisecond)
+}
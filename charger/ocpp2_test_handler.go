@@ -0,0 +1,140 @@
+package charger
+
+import (
+	"fmt"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+type ChargingStationHandler struct {
+	triggerC chan remotecontrol.MessageTrigger
+	requestC chan ocpp.Request
+}
+
+// request forwards requests that need to be answered by charging station messages
+func (handler *ChargingStationHandler) request(request ocpp.Request) {
+	if c := handler.requestC; c != nil {
+		select {
+		case c <- request:
+		default:
+		}
+	}
+}
+
+func (handler *ChargingStationHandler) OnGetBaseReport(request *provisioning.GetBaseReportRequest) (response *provisioning.GetBaseReportResponse, err error) {
+	fmt.Printf("%T %+v\n", request, request)
+	return provisioning.NewGetBaseReportResponse(types.GenericDeviceModelStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnGetReport(request *provisioning.GetReportRequest) (response *provisioning.GetReportResponse, err error) {
+	fmt.Printf("%T %+v\n", request, request)
+	return provisioning.NewGetReportResponse(types.GenericDeviceModelStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnGetVariables(request *provisioning.GetVariablesRequest) (response *provisioning.GetVariablesResponse, err error) {
+	fmt.Printf("%T %+v\n", request, request)
+
+	values := map[string]string{
+		"TxUpdatedMeasurands": "Power.Active.Import,Energy.Active.Import.Register,Current.Import",
+		"TxUpdatedInterval":   "60",
+		"Phases3to1":          "true",
+	}
+
+	var res []provisioning.GetVariableResult
+	for _, data := range request.GetVariableData {
+		result := provisioning.GetVariableResult{
+			AttributeStatus: provisioning.GetVariableStatusUnknownVariable,
+			Component:       data.Component,
+			Variable:        data.Variable,
+		}
+
+		if val, ok := values[data.Variable.Name]; ok {
+			result.AttributeStatus = provisioning.GetVariableStatusAccepted
+			result.AttributeValue = val
+		}
+
+		res = append(res, result)
+	}
+
+	return provisioning.NewGetVariablesResponse(res), nil
+}
+
+func (handler *ChargingStationHandler) OnReset(request *provisioning.ResetRequest) (response *provisioning.ResetResponse, err error) {
+	fmt.Printf("%T %+v\n", request, request)
+	return provisioning.NewResetResponse(provisioning.ResetStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnSetNetworkProfile(request *provisioning.SetNetworkProfileRequest) (response *provisioning.SetNetworkProfileResponse, err error) {
+	fmt.Printf("%T %+v\n", request, request)
+	return provisioning.NewSetNetworkProfileResponse(provisioning.SetNetworkProfileStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnSetVariables(request *provisioning.SetVariablesRequest) (response *provisioning.SetVariablesResponse, err error) {
+	fmt.Printf("%T %+v\n", request, request)
+
+	var res []provisioning.SetVariableResult
+	for _, data := range request.SetVariableData {
+		res = append(res, provisioning.SetVariableResult{
+			AttributeStatus: provisioning.SetVariableStatusAccepted,
+			Component:       data.Component,
+			Variable:        data.Variable,
+		})
+	}
+
+	return provisioning.NewSetVariablesResponse(res), nil
+}
+
+func (handler *ChargingStationHandler) OnRequestStartTransaction(request *remotecontrol.RequestStartTransactionRequest) (response *remotecontrol.RequestStartTransactionResponse, err error) {
+	fmt.Printf("%T %+v\n", request, request)
+	handler.request(request)
+	return remotecontrol.NewRequestStartTransactionResponse(remotecontrol.RequestStartStopStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnRequestStopTransaction(request *remotecontrol.RequestStopTransactionRequest) (response *remotecontrol.RequestStopTransactionResponse, err error) {
+	fmt.Printf("%T %+v\n", request, request)
+	handler.request(request)
+	return remotecontrol.NewRequestStopTransactionResponse(remotecontrol.RequestStartStopStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnTriggerMessage(request *remotecontrol.TriggerMessageRequest) (response *remotecontrol.TriggerMessageResponse, err error) {
+	fmt.Printf("%T %+v\n", request, request)
+
+	if c := handler.triggerC; request != nil && c != nil {
+		select {
+		case c <- request.RequestedMessage:
+		default:
+		}
+	}
+
+	return remotecontrol.NewTriggerMessageResponse(remotecontrol.TriggerMessageStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnUnlockConnector(request *remotecontrol.UnlockConnectorRequest) (response *remotecontrol.UnlockConnectorResponse, err error) {
+	fmt.Printf("%T %+v\n", request, request)
+	return remotecontrol.NewUnlockConnectorResponse(remotecontrol.UnlockStatusUnlocked), nil
+}
+
+func (handler *ChargingStationHandler) OnClearChargingProfile(request *smartcharging.ClearChargingProfileRequest) (response *smartcharging.ClearChargingProfileResponse, err error) {
+	fmt.Printf("%T %+v\n", request, request)
+	return smartcharging.NewClearChargingProfileResponse(smartcharging.ClearChargingProfileStatusAccepted), nil
+}
+
+func (handler *ChargingStationHandler) OnGetChargingProfiles(request *smartcharging.GetChargingProfilesRequest) (response *smartcharging.GetChargingProfilesResponse, err error) {
+	fmt.Printf("%T %+v\n", request, request)
+	return smartcharging.NewGetChargingProfilesResponse(smartcharging.GetChargingProfileStatusNoProfiles), nil
+}
+
+func (handler *ChargingStationHandler) OnGetCompositeSchedule(request *smartcharging.GetCompositeScheduleRequest) (response *smartcharging.GetCompositeScheduleResponse, err error) {
+	fmt.Printf("%T %+v\n", request, request)
+	return smartcharging.NewGetCompositeScheduleResponse(smartcharging.GetCompositeScheduleStatusRejected, request.EvseID), nil
+}
+
+func (handler *ChargingStationHandler) OnSetChargingProfile(request *smartcharging.SetChargingProfileRequest) (response *smartcharging.SetChargingProfileResponse, err error) {
+	fmt.Printf("%T %+v\n", request, request)
+	handler.request(request)
+	return smartcharging.NewSetChargingProfileResponse(smartcharging.ChargingProfileStatusAccepted), nil
+}
@@ -117,4 +117,13 @@ func (suite *ocppTestSuite) TestConnect() {
 	f, err = c.totalEnergy()
 	suite.NoError(err)
 	suite.Equal(1.2, f)
+
+	// rfid
+	res, err := suite.cp.Authorize("rfid")
+	suite.NoError(err)
+	suite.Equal(types.AuthorizationStatusAccepted, res.IdTagInfo.Status)
+
+	id, err := c.Identify()
+	suite.NoError(err)
+	suite.Equal("rfid", id)
 }
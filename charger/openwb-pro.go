@@ -2,6 +2,7 @@ package charger
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -24,6 +25,7 @@ type OpenWBPro struct {
 	uri         string
 	current     float64
 	statusCache provider.Cacheable[pro.Status]
+	done        chan struct{}
 }
 
 // NewOpenWBProFromConfig creates a OpenWBPro charger from generic config
@@ -50,6 +52,7 @@ func NewOpenWBPro(uri string, cache time.Duration) (*OpenWBPro, error) {
 		Helper:  request.NewHelper(log),
 		uri:     strings.TrimRight(uri, "/"),
 		current: 6, // 6A defined value
+		done:    make(chan struct{}),
 	}
 
 	wb.statusCache = provider.ResettableCached(func() (pro.Status, error) {
@@ -65,13 +68,30 @@ func NewOpenWBPro(uri string, cache time.Duration) (*OpenWBPro, error) {
 }
 
 func (wb *OpenWBPro) heartbeat(log *util.Logger) {
-	for range time.Tick(30 * time.Second) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-wb.done:
+			return
+		case <-ticker.C:
+		}
+
 		if _, err := wb.statusCache.Get(); err != nil {
 			log.ERROR.Printf("heartbeat: %v", err)
 		}
 	}
 }
 
+var _ io.Closer = (*OpenWBPro)(nil)
+
+// Close implements the io.Closer interface
+func (wb *OpenWBPro) Close() error {
+	close(wb.done)
+	return nil
+}
+
 func (wb *OpenWBPro) set(payload string) error {
 	uri := fmt.Sprintf("%s/%s", wb.uri, "connect.php")
 	resp, err := wb.Post(uri, "application/x-www-form-urlencoded", strings.NewReader(payload))
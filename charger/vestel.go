@@ -20,6 +20,7 @@ package charger
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -54,6 +55,7 @@ type Vestel struct {
 	log     *util.Logger
 	conn    *modbus.Connection
 	current uint16
+	done    chan struct{}
 }
 
 func init() {
@@ -91,6 +93,7 @@ func NewVestel(uri string, id uint8) (*Vestel, error) {
 		log:     log,
 		conn:    conn,
 		current: 6,
+		done:    make(chan struct{}),
 	}
 
 	go wb.heartbeat()
@@ -99,13 +102,30 @@ func NewVestel(uri string, id uint8) (*Vestel, error) {
 }
 
 func (wb *Vestel) heartbeat() {
-	for range time.Tick(time.Second * 3) {
+	ticker := time.NewTicker(time.Second * 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-wb.done:
+			return
+		case <-ticker.C:
+		}
+
 		if _, err := wb.conn.WriteSingleRegister(vestelRegAlive, 1); err != nil {
 			wb.log.ERROR.Println("heartbeat:", err)
 		}
 	}
 }
 
+var _ io.Closer = (*Vestel)(nil)
+
+// Close implements the io.Closer interface
+func (wb *Vestel) Close() error {
+	close(wb.done)
+	return nil
+}
+
 // Status implements the api.Charger interface
 func (wb *Vestel) Status() (api.ChargeStatus, error) {
 	res := api.StatusA
@@ -20,6 +20,7 @@ package charger
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -34,6 +35,7 @@ type WebastoNext struct {
 	conn    *modbus.Connection
 	current uint16
 	enabled bool
+	done    chan struct{}
 }
 
 const (
@@ -85,6 +87,7 @@ func NewWebastoNext(uri string, id uint8) (api.Charger, error) {
 		log:     log,
 		conn:    conn,
 		current: 6, // assume min current
+		done:    make(chan struct{}),
 	}
 
 	// write heartbeat once for command line testing
@@ -104,13 +107,30 @@ func NewWebastoNext(uri string, id uint8) (api.Charger, error) {
 }
 
 func (wb *WebastoNext) heartbeat(timeout time.Duration) {
-	for range time.Tick(timeout) {
+	ticker := time.NewTicker(timeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-wb.done:
+			return
+		case <-ticker.C:
+		}
+
 		if _, err := wb.conn.WriteSingleRegister(tqRegLifeBit, 1); err != nil {
 			wb.log.ERROR.Println("heartbeat:", err)
 		}
 	}
 }
 
+var _ io.Closer = (*WebastoNext)(nil)
+
+// Close implements the io.Closer interface
+func (wb *WebastoNext) Close() error {
+	close(wb.done)
+	return nil
+}
+
 // Status implements the api.Charger interface
 func (wb *WebastoNext) Status() (api.ChargeStatus, error) {
 	b, err := wb.conn.ReadHoldingRegisters(tqRegChargePointState, 1)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"github.com/dustin/go-humanize"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/charger"
+	"github.com/evcc-io/evcc/charger/ocpp"
 	"github.com/evcc-io/evcc/meter"
 	"github.com/evcc-io/evcc/provider/mqtt"
 	"github.com/evcc-io/evcc/push"
@@ -27,28 +29,35 @@ import (
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
 )
 
-var conf = config{
-	Interval: 10 * time.Second,
-	Log:      "info",
-	Network: networkConfig{
-		Schema: "http",
-		Host:   "evcc.local",
-		Port:   7070,
-	},
-	Mqtt: mqttConfig{
-		Topic: "evcc",
-	},
-	Database: dbConfig{
-		Type: "sqlite",
-		Dsn:  "~/.evcc/evcc.db",
-	},
+var conf = defaultConfig()
+
+// defaultConfig returns the configuration defaults
+func defaultConfig() config {
+	return config{
+		Interval: 10 * time.Second,
+		Log:      "info",
+		Network: networkConfig{
+			Schema: "http",
+			Host:   "evcc.local",
+			Port:   7070,
+		},
+		Mqtt: mqttConfig{
+			Topic: "evcc",
+		},
+		Database: dbConfig{
+			Type: "sqlite",
+			Dsn:  "~/.evcc/evcc.db",
+		},
+	}
 }
 
 type config struct {
 	URI          interface{} // TODO deprecated
+	Include      []string    // additional configuration files
 	Network      networkConfig
 	Log          string
 	SponsorToken string
@@ -61,10 +70,12 @@ type config struct {
 	Database     dbConfig
 	Mqtt         mqttConfig
 	ModbusProxy  []proxyConfig
+	ModbusServer modbusServerConfig
 	Javascript   []javascriptConfig
 	Go           []goConfig
 	Influx       server.InfluxConfig
 	EEBus        map[string]interface{}
+	OCPP         ocppConfig
 	HEMS         typedConfig
 	Messaging    messagingConfig
 	Meters       []qualifiedConfig
@@ -93,9 +104,22 @@ type goConfig struct {
 type proxyConfig struct {
 	Port            int
 	ReadOnly        bool
+	Writable        []string      // writable register addresses or ranges
+	Cache           time.Duration // read cache duration
 	modbus.Settings `mapstructure:",squash"`
 }
 
+type modbusServerConfig struct {
+	Port     int
+	ReadOnly bool
+	Writable []string
+}
+
+type ocppConfig struct {
+	Auth  ocpp.Authorization
+	Token string // management api token
+}
+
 type dbConfig struct {
 	Type string
 	Dsn  string
@@ -113,7 +137,8 @@ type typedConfig struct {
 
 type messagingConfig struct {
 	Events   map[string]push.EventTemplateConfig
-	Services []typedConfig
+	Rules    map[string]push.RuleConfig
+	Services []qualifiedConfig
 }
 
 type tariffConfig struct {
@@ -148,6 +173,36 @@ type ConfigProvider struct {
 	vehicles map[string]api.Vehicle
 	visited  map[string]bool
 	auth     *util.AuthCollection
+
+	// devices available for re-use on reload and the configuration they were created from
+	reuse struct {
+		conf     config
+		meters   map[string]api.Meter
+		chargers map[string]api.Charger
+		vehicles map[string]api.Vehicle
+	}
+}
+
+// Reuse makes devices of a previous config provider available if their configuration is unchanged
+func (cp *ConfigProvider) Reuse(prev *ConfigProvider, conf config) {
+	cp.reuse.conf = conf
+	cp.reuse.meters = prev.meters
+	cp.reuse.chargers = prev.chargers
+	cp.reuse.vehicles = prev.vehicles
+}
+
+// reusable returns a previously created device if its configuration is unchanged
+func reusable[T any](devices map[string]T, configs []qualifiedConfig, cc qualifiedConfig) (T, bool) {
+	dev, ok := devices[cc.Name]
+	return dev, ok && unchanged(configs, cc)
+}
+
+// unchanged returns true if configs contain an identical device configuration
+func unchanged(configs []qualifiedConfig, cc qualifiedConfig) bool {
+	idx := slices.IndexFunc(configs, func(c qualifiedConfig) bool {
+		return c.Name == cc.Name
+	})
+	return idx >= 0 && reflect.DeepEqual(configs[idx], cc)
 }
 
 func (cp *ConfigProvider) TrackVisitors() {
@@ -204,10 +259,13 @@ func (cp *ConfigProvider) configureMeters(conf config) error {
 			return fmt.Errorf("cannot create %s meter: missing name", humanize.Ordinal(id+1))
 		}
 
-		m, err := meter.NewFromConfig(cc.Type, cc.Other)
-		if err != nil {
-			err = fmt.Errorf("cannot create meter '%s': %w", cc.Name, err)
-			return err
+		m, ok := reusable(cp.reuse.meters, cp.reuse.conf.Meters, cc)
+		if !ok {
+			var err error
+			if m, err = meter.NewFromConfig(cc.Type, cc.Other); err != nil {
+				err = fmt.Errorf("cannot create meter '%s': %w", cc.Name, err)
+				return err
+			}
 		}
 
 		if _, exists := cp.meters[cc.Name]; exists {
@@ -233,9 +291,12 @@ func (cp *ConfigProvider) configureChargers(conf config) error {
 		cc := cc
 
 		g.Go(func() error {
-			c, err := charger.NewFromConfig(cc.Type, cc.Other)
-			if err != nil {
-				return fmt.Errorf("cannot create charger '%s': %w", cc.Name, err)
+			c, ok := reusable(cp.reuse.chargers, cp.reuse.conf.Chargers, cc)
+			if !ok {
+				var err error
+				if c, err = charger.NewFromConfig(cc.Type, cc.Other); err != nil {
+					return fmt.Errorf("cannot create charger '%s': %w", cc.Name, err)
+				}
 			}
 
 			mu.Lock()
@@ -266,16 +327,19 @@ func (cp *ConfigProvider) configureVehicles(conf config) error {
 		cc := cc
 
 		g.Go(func() error {
-			v, err := vehicle.NewFromConfig(cc.Type, cc.Other)
-			if err != nil {
-				var ce *util.ConfigError
-				if errors.As(err, &ce) {
-					return fmt.Errorf("cannot create vehicle '%s': %w", cc.Name, err)
+			v, ok := reusable(cp.reuse.vehicles, cp.reuse.conf.Vehicles, cc)
+			if !ok {
+				var err error
+				if v, err = vehicle.NewFromConfig(cc.Type, cc.Other); err != nil {
+					var ce *util.ConfigError
+					if errors.As(err, &ce) {
+						return fmt.Errorf("cannot create vehicle '%s': %w", cc.Name, err)
+					}
+
+					// wrap non-config vehicle errors to prevent fatals
+					log.ERROR.Printf("creating vehicle %s failed: %v", cc.Name, err)
+					v = wrapper.New(cc.Name, cc.Other, err)
 				}
-
-				// wrap non-config vehicle errors to prevent fatals
-				log.ERROR.Printf("creating vehicle %s failed: %v", cc.Name, err)
-				v = wrapper.New(cc.Name, cc.Other, err)
 			}
 
 			// ensure vehicle config has title
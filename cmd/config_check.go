@@ -0,0 +1,506 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/evcc-io/evcc/charger"
+	"github.com/evcc-io/evcc/core"
+	"github.com/evcc-io/evcc/meter"
+	"github.com/evcc-io/evcc/util/templates"
+	"github.com/evcc-io/evcc/vehicle"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigSchema is the json schema of the configuration file
+var ConfigSchema []byte
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Configuration tools",
+}
+
+// configCheckCmd represents the config check command
+var configCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Validate configuration file without contacting any device",
+	Args:  cobra.NoArgs,
+	Run:   runConfigCheck,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configCheckCmd)
+}
+
+// configIssue is a configuration problem found at a specific position
+type configIssue struct {
+	Line, Column int
+	Path         string
+	Warning      bool
+	Message      string
+	node         *yaml.Node
+}
+
+func newConfigIssue(node *yaml.Node, path string, warning bool, format string, a ...any) configIssue {
+	res := configIssue{
+		Path:    path,
+		Warning: warning,
+		Message: fmt.Sprintf(format, a...),
+		node:    node,
+	}
+
+	if node != nil {
+		res.Line, res.Column = node.Line, node.Column
+	}
+
+	return res
+}
+
+func (i configIssue) String() string {
+	var severity string
+	if i.Warning {
+		severity = "warning: "
+	}
+
+	if i.Path == "" {
+		return fmt.Sprintf("%d:%d: %s%s", i.Line, i.Column, severity, i.Message)
+	}
+
+	return fmt.Sprintf("%d:%d: %s%s: %s", i.Line, i.Column, severity, i.Path, i.Message)
+}
+
+func runConfigCheck(cmd *cobra.Command, args []string) {
+	// locate config file, syntax errors are reported with line numbers below
+	if err := viper.ReadInConfig(); errors.As(err, &viper.ConfigFileNotFoundError{}) {
+		log.FATAL.Fatal(err)
+	}
+
+	file := viper.ConfigFileUsed()
+	nodes, err := readConfigNodes(file)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	issues, err := checkConfigNode(nodes.root, ConfigSchema)
+	if err != nil {
+		fmt.Printf("%s: %v\n", file, err)
+		os.Exit(1)
+	}
+
+	// order by file, issues are already ordered by position
+	sort.SliceStable(issues, func(i, j int) bool {
+		return slices.Index(nodes.files, nodes.origin[issues[i].node]) < slices.Index(nodes.files, nodes.origin[issues[j].node])
+	})
+
+	var errs int
+	for _, issue := range issues {
+		if !issue.Warning {
+			errs++
+		}
+
+		origin := file
+		if f, ok := nodes.origin[issue.node]; ok {
+			origin = f
+		}
+
+		fmt.Printf("%s:%s\n", origin, issue)
+	}
+
+	if errs > 0 {
+		fmt.Printf("%d error(s), %d warning(s)\n", errs, len(issues)-errs)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s: configuration valid (%d warning(s))\n", file, len(issues))
+}
+
+// checkConfig statically validates the configuration and returns the issues found sorted by position.
+// An error is returned if the configuration cannot be parsed.
+func checkConfig(b, schema []byte) ([]configIssue, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	return checkConfigNode(resolveNode(&doc), schema)
+}
+
+// checkConfigNode statically validates the configuration root node
+func checkConfigNode(root *yaml.Node, schema []byte) ([]configIssue, error) {
+	if root == nil {
+		return nil, errors.New("empty configuration")
+	}
+
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: configuration must be a map", root.Line)
+	}
+
+	c := new(configChecker)
+
+	if len(schema) > 0 {
+		v, err := newSchemaValidator(schema)
+		if err != nil {
+			return nil, err
+		}
+		c.issues = append(c.issues, v.Validate(root)...)
+	}
+
+	c.checkKeys(root, reflect.TypeOf(config{}), "")
+	c.check(root)
+
+	sort.SliceStable(c.issues, func(i, j int) bool {
+		if c.issues[i].Line != c.issues[j].Line {
+			return c.issues[i].Line < c.issues[j].Line
+		}
+		return c.issues[i].Column < c.issues[j].Column
+	})
+
+	return c.issues, nil
+}
+
+// configChecker validates keys, templates and references
+type configChecker struct {
+	issues []configIssue
+	names  map[templates.Class][]string
+}
+
+func (c *configChecker) errorf(node *yaml.Node, path, format string, a ...any) {
+	c.issues = append(c.issues, newConfigIssue(node, path, false, format, a...))
+}
+
+func (c *configChecker) warnf(node *yaml.Node, path, format string, a ...any) {
+	c.issues = append(c.issues, newConfigIssue(node, path, true, format, a...))
+}
+
+func (c *configChecker) check(root *yaml.Node) {
+	if key, _ := mappingValue(root, "uri"); key != nil {
+		c.warnf(key, "uri", "deprecated, use network instead")
+	}
+
+	c.names = make(map[templates.Class][]string)
+
+	for _, class := range []struct {
+		key   string
+		class templates.Class
+		types []string
+	}{
+		{"meters", templates.Meter, meter.Types()},
+		{"chargers", templates.Charger, charger.Types()},
+		{"vehicles", templates.Vehicle, vehicle.Types()},
+	} {
+		if _, node := mappingValue(root, class.key); node != nil && node.Kind == yaml.SequenceNode {
+			c.checkDevices(node, class.key, class.class, class.types)
+		}
+	}
+
+	if _, node := mappingValue(root, "site"); node != nil {
+		c.checkKeys(node, reflect.TypeOf(core.Site{}), "site")
+		c.checkSite(node)
+	}
+
+	if _, node := mappingValue(root, "loadpoints"); node != nil && node.Kind == yaml.SequenceNode {
+		for i, lp := range node.Content {
+			path := fmt.Sprintf("loadpoints[%d]", i)
+			c.checkKeys(lp, reflect.TypeOf(core.Loadpoint{}), path)
+			c.checkLoadpoint(resolveNode(lp), path)
+		}
+	}
+}
+
+// checkDevices validates device names, types and template parameters
+func (c *configChecker) checkDevices(node *yaml.Node, key string, class templates.Class, types []string) {
+	for i, dev := range node.Content {
+		dev = resolveNode(dev)
+		path := fmt.Sprintf("%s[%d]", key, i)
+
+		if dev.Kind != yaml.MappingNode {
+			continue
+		}
+
+		if nameKey, name := mappingValue(dev, "name"); name != nil && name.Value != "" {
+			if slices.Contains(c.names[class], name.Value) {
+				c.errorf(nameKey, path, "duplicate %s name: %s", strings.ToLower(class.String()), name.Value)
+			}
+			c.names[class] = append(c.names[class], name.Value)
+		}
+
+		_, typ := mappingValue(dev, "type")
+		if typ == nil || typ.Value == "" {
+			continue
+		}
+
+		if !slices.Contains(types, strings.ToLower(typ.Value)) {
+			c.errorf(typ, path, "invalid %s type: %s", strings.ToLower(class.String()), typ.Value)
+			continue
+		}
+
+		if strings.ToLower(typ.Value) == "template" {
+			c.checkTemplate(dev, path, class)
+		}
+	}
+}
+
+// checkTemplate validates template parameters against the template definition
+func (c *configChecker) checkTemplate(dev *yaml.Node, path string, class templates.Class) {
+	_, name := mappingValue(dev, "template")
+	if name == nil || name.Value == "" {
+		c.errorf(dev, path, "missing template")
+		return
+	}
+
+	tmpl, err := templates.ByName(class, name.Value)
+	if err != nil {
+		c.errorf(name, path, "%v", err)
+		return
+	}
+
+	for i := 0; i+1 < len(dev.Content); i += 2 {
+		if key := dev.Content[i]; !tmpl.IsValidKey(key.Value) {
+			c.errorf(key, joinPath(path, key.Value), "invalid parameter for template %s", tmpl.Template)
+		}
+	}
+
+	var usage string
+	if _, node := mappingValue(dev, templates.ParamUsage); node != nil {
+		usage = node.Value
+	}
+
+	for _, p := range tmpl.Params {
+		if !p.IsRequired() || p.IsDeprecated() || p.Default != "" {
+			continue
+		}
+
+		if len(p.Usages) > 0 && !slices.Contains(p.Usages, usage) {
+			continue
+		}
+
+		if _, val := mappingValue(dev, p.Name); val == nil || val.Value == "" && val.Kind == yaml.ScalarNode {
+			c.errorf(dev, path, "missing required parameter %s for template %s", p.Name, tmpl.Template)
+		}
+	}
+}
+
+// checkSite validates the site meter references
+func (c *configChecker) checkSite(site *yaml.Node) {
+	site = resolveNode(site)
+
+	_, meters := mappingValue(site, "meters")
+	if meters == nil || meters.Kind != yaml.MappingNode {
+		return
+	}
+
+	for _, ref := range []struct {
+		key, replacement string
+	}{
+		{"grid", ""},
+		{"pv", ""},
+		{"pvs", "pv"},
+		{"battery", ""},
+		{"batteries", "battery"},
+		{"aux", ""},
+	} {
+		key, val := mappingValue(meters, ref.key)
+		if key == nil {
+			continue
+		}
+
+		path := joinPath("site.meters", ref.key)
+		if ref.replacement != "" {
+			c.warnf(key, path, "deprecated, use %s instead", ref.replacement)
+		}
+
+		c.checkRefs(val, path, templates.Meter)
+	}
+}
+
+// checkLoadpoint validates loadpoint device references and deprecated settings
+func (c *configChecker) checkLoadpoint(lp *yaml.Node, path string) {
+	if lp.Kind != yaml.MappingNode {
+		return
+	}
+
+	for _, ref := range []struct {
+		key   string
+		class templates.Class
+	}{
+		{"charger", templates.Charger},
+		{"meter", templates.Meter},
+		{"vehicle", templates.Vehicle},
+		{"vehicles", templates.Vehicle},
+	} {
+		key, val := mappingValue(lp, ref.key)
+		if key == nil {
+			continue
+		}
+
+		if ref.key == "vehicles" {
+			c.warnf(key, joinPath(path, ref.key), "deprecated, use vehicle instead")
+		}
+
+		c.checkRefs(val, joinPath(path, ref.key), ref.class)
+	}
+
+	if _, soc := mappingValue(lp, "soc"); soc != nil {
+		for _, key := range []string{"min", "target"} {
+			if node, _ := mappingValue(resolveNode(soc), key); node != nil {
+				c.warnf(node, joinPath(path, "soc."+key), "deprecated, configure per vehicle instead")
+			}
+		}
+	}
+}
+
+// checkRefs validates that a single or list of device references are defined
+func (c *configChecker) checkRefs(node *yaml.Node, path string, class templates.Class) {
+	node = resolveNode(node)
+	if node == nil {
+		return
+	}
+
+	refs := []*yaml.Node{node}
+	if node.Kind == yaml.SequenceNode {
+		refs = node.Content
+	}
+
+	for _, ref := range refs {
+		if ref = resolveNode(ref); ref.Kind != yaml.ScalarNode || ref.Value == "" {
+			continue
+		}
+
+		if !slices.Contains(c.names[class], ref.Value) {
+			c.errorf(ref, path, "undefined %s: %s", strings.ToLower(class.String()), ref.Value)
+		}
+	}
+}
+
+// checkKeys reports mapping keys that are not decoded into typ
+func (c *configChecker) checkKeys(node *yaml.Node, typ reflect.Type, path string) {
+	node = resolveNode(node)
+	if node == nil {
+		return
+	}
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+
+		fields, remain := structKeys(typ)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, val := node.Content[i], node.Content[i+1]
+
+			if ft, ok := fields[strings.ToLower(key.Value)]; ok {
+				c.checkKeys(val, ft, joinPath(path, key.Value))
+			} else if !remain {
+				c.errorf(key, joinPath(path, key.Value), "unknown key")
+			}
+		}
+
+	case reflect.Slice:
+		if node.Kind == yaml.SequenceNode {
+			for i, item := range node.Content {
+				c.checkKeys(item, typ.Elem(), fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+
+	case reflect.Map:
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				c.checkKeys(node.Content[i+1], typ.Elem(), joinPath(path, node.Content[i].Value))
+			}
+		}
+	}
+}
+
+// structKeys returns the lower case configuration keys of a struct type and if it accepts remaining keys
+func structKeys(typ reflect.Type) (map[string]reflect.Type, bool) {
+	res := make(map[string]reflect.Type)
+	var remain bool
+
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+
+		switch {
+		case opts == "remain":
+			remain = true
+
+		case opts == "squash":
+			fields, r := structKeys(f.Type)
+			for k, v := range fields {
+				res[k] = v
+			}
+			remain = remain || r
+
+		case f.Anonymous || name == "-":
+			// embedded types like mutexes are not part of the configuration
+
+		default:
+			if name == "" {
+				name = f.Name
+			}
+			res[strings.ToLower(name)] = f.Type
+		}
+	}
+
+	return res, remain
+}
+
+// resolveNode unwraps document and alias nodes
+func resolveNode(node *yaml.Node) *yaml.Node {
+	for node != nil {
+		switch node.Kind {
+		case yaml.DocumentNode:
+			if len(node.Content) == 0 {
+				return nil
+			}
+			node = node.Content[0]
+		case yaml.AliasNode:
+			node = node.Alias
+		default:
+			return node
+		}
+	}
+
+	return nil
+}
+
+// mappingValue returns the key and value nodes for key, matched case-insensitively
+func mappingValue(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if strings.EqualFold(node.Content[i].Value, key) {
+			return node.Content[i], resolveNode(node.Content[i+1])
+		}
+	}
+
+	return nil, nil
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigCheck(t *testing.T) {
+	schema, err := os.ReadFile("../schema.json")
+	require.NoError(t, err)
+
+	conf := `
+log: verbose
+intervall: 10s
+meters:
+- name: grid
+  type: template
+  template: shelly-1pm
+  hots: 1.2.3.4
+- name: grid
+  type: foo
+chargers:
+- name: wallbox
+  type: custom
+site:
+  meters:
+    grid: grid
+    pvs: [pv]
+loadpoints:
+- charger: wallbox
+  vehicle: car
+  soc:
+    min: 20
+  enable:
+    delay: 1x
+`
+
+	issues, err := checkConfig([]byte(conf), schema)
+	require.NoError(t, err)
+
+	var res []string
+	for _, issue := range issues {
+		res = append(res, issue.String())
+	}
+
+	assert.Equal(t, []string{
+		`2:6: log: invalid value "verbose", must be one of trace, debug, info, warn, error, fatal`,
+		`3:1: intervall: unknown key`,
+		`8:3: meters[0].hots: invalid parameter for template shelly-1pm`,
+		`9:3: meters[1]: duplicate meter name: grid`,
+		`10:9: meters[1]: invalid meter type: foo`,
+		`17:5: warning: site.meters.pvs: deprecated, use pv instead`,
+		`17:11: site.meters.pvs: undefined meter: pv`,
+		`20:12: loadpoints[0].vehicle: undefined vehicle: car`,
+		`22:5: warning: loadpoints[0].soc.min: deprecated, configure per vehicle instead`,
+		`24:12: loadpoints[0].enable.delay: invalid value "1x"`,
+	}, res)
+}
+
+func TestConfigCheckDemo(t *testing.T) {
+	schema, err := os.ReadFile("../schema.json")
+	require.NoError(t, err)
+
+	issues, err := checkConfig([]byte(demoYaml), schema)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+func TestConfigCheckSyntax(t *testing.T) {
+	_, err := checkConfig([]byte("site:\n  title: [foo"), nil)
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "line"), err)
+}
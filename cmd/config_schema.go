@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+// jsonSchema is the subset of JSON schema draft-07 used by schema.json
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 schemaType             `json:"type"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	AdditionalProperties *additionalProperties  `json:"additionalProperties"`
+	Required             []string               `json:"required"`
+	Items                *jsonSchema            `json:"items"`
+	Enum                 []any                  `json:"enum"`
+	Pattern              string                 `json:"pattern"`
+	MinItems             int                    `json:"minItems"`
+	UniqueItems          bool                   `json:"uniqueItems"`
+	Definitions          map[string]*jsonSchema `json:"definitions"`
+}
+
+// schemaType is either a single type or a list of types
+type schemaType []string
+
+func (t *schemaType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = []string{s}
+		return nil
+	}
+
+	var l []string
+	err := json.Unmarshal(b, &l)
+	*t = l
+
+	return err
+}
+
+// additionalProperties is either a boolean or a schema
+type additionalProperties struct {
+	Forbidden bool
+	Schema    *jsonSchema
+}
+
+func (a *additionalProperties) UnmarshalJSON(b []byte) error {
+	var allowed bool
+	if err := json.Unmarshal(b, &allowed); err == nil {
+		a.Forbidden = !allowed
+		return nil
+	}
+
+	return json.Unmarshal(b, &a.Schema)
+}
+
+// schemaValidator validates yaml nodes against a json schema
+type schemaValidator struct {
+	root   *jsonSchema
+	issues []configIssue
+}
+
+func newSchemaValidator(b []byte) (*schemaValidator, error) {
+	var root jsonSchema
+	if err := json.Unmarshal(b, &root); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	return &schemaValidator{root: &root}, nil
+}
+
+func (v *schemaValidator) errorf(node *yaml.Node, path, format string, a ...any) {
+	v.issues = append(v.issues, newConfigIssue(node, path, false, format, a...))
+}
+
+// resolve returns the definition referenced by ref
+func (v *schemaValidator) resolve(ref string) (*jsonSchema, error) {
+	name, ok := strings.CutPrefix(ref, "#/definitions/")
+	if !ok {
+		return nil, fmt.Errorf("unsupported schema reference: %s", ref)
+	}
+
+	s, ok := v.root.Definitions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown schema reference: %s", ref)
+	}
+
+	return s, nil
+}
+
+// Validate validates the yaml node against the root schema
+func (v *schemaValidator) Validate(node *yaml.Node) []configIssue {
+	v.issues = nil
+	v.validate(v.root, node, "")
+	return v.issues
+}
+
+func (v *schemaValidator) validate(s *jsonSchema, node *yaml.Node, path string) {
+	node = resolveNode(node)
+
+	// empty values are treated as not set
+	if node == nil || node.Tag == "!!null" {
+		return
+	}
+
+	if s.Ref != "" {
+		ref, err := v.resolve(s.Ref)
+		if err != nil {
+			v.errorf(node, path, "%v", err)
+			return
+		}
+		v.validate(ref, node, path)
+	}
+
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(typ string) bool {
+		return matchesType(typ, node)
+	}) {
+		v.errorf(node, path, "expected %s, got %s", strings.Join(s.Type, " or "), nodeType(node))
+		return
+	}
+
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool {
+		return node.Kind == yaml.ScalarNode && strings.EqualFold(fmt.Sprint(e), node.Value)
+	}) {
+		var enum []string
+		for _, e := range s.Enum {
+			enum = append(enum, fmt.Sprint(e))
+		}
+		v.errorf(node, path, "invalid value %q, must be one of %s", node.Value, strings.Join(enum, ", "))
+	}
+
+	if s.Pattern != "" && node.Kind == yaml.ScalarNode {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			v.errorf(node, path, "invalid schema pattern: %v", err)
+		} else if !re.MatchString(node.Value) {
+			v.errorf(node, path, "invalid value %q", node.Value)
+		}
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		v.validateObject(s, node, path)
+	case yaml.SequenceNode:
+		v.validateArray(s, node, path)
+	}
+}
+
+func (v *schemaValidator) validateObject(s *jsonSchema, node *yaml.Node, path string) {
+	for _, req := range s.Required {
+		if _, val := mappingValue(node, req); val == nil {
+			v.errorf(node, path, "missing required key: %s", req)
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, val := node.Content[i], node.Content[i+1]
+		keyPath := joinPath(path, key.Value)
+
+		if prop := schemaProperty(s.Properties, key.Value); prop != nil {
+			v.validate(prop, val, keyPath)
+			continue
+		}
+
+		if s.AdditionalProperties != nil {
+			if s.AdditionalProperties.Forbidden {
+				v.errorf(key, keyPath, "unknown key")
+			} else if s.AdditionalProperties.Schema != nil {
+				v.validate(s.AdditionalProperties.Schema, val, keyPath)
+			}
+		}
+	}
+}
+
+func (v *schemaValidator) validateArray(s *jsonSchema, node *yaml.Node, path string) {
+	if len(node.Content) < s.MinItems {
+		v.errorf(node, path, "expected at least %d items", s.MinItems)
+	}
+
+	var seen []string
+	for i, item := range node.Content {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+
+		if s.UniqueItems {
+			if item := resolveNode(item); item.Kind == yaml.ScalarNode {
+				if slices.Contains(seen, item.Value) {
+					v.errorf(item, itemPath, "duplicate item %q", item.Value)
+				}
+				seen = append(seen, item.Value)
+			}
+		}
+
+		if s.Items != nil {
+			v.validate(s.Items, item, itemPath)
+		}
+	}
+}
+
+// schemaProperty returns the property schema matching key case-insensitively
+func schemaProperty(props map[string]*jsonSchema, key string) *jsonSchema {
+	if s, ok := props[key]; ok {
+		return s
+	}
+
+	for k, s := range props {
+		if strings.EqualFold(k, key) {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// matchesType checks if the node matches the json schema type. Scalars are weakly typed like the config decoder.
+func matchesType(typ string, node *yaml.Node) bool {
+	switch typ {
+	case "object":
+		return node.Kind == yaml.MappingNode
+	case "array":
+		return node.Kind == yaml.SequenceNode
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return false
+	}
+
+	switch typ {
+	case "string":
+		return true
+	case "integer":
+		_, err := strconv.ParseInt(node.Value, 0, 64)
+		return node.Tag == "!!int" || err == nil
+	case "number":
+		_, err := strconv.ParseFloat(node.Value, 64)
+		return node.Tag == "!!int" || node.Tag == "!!float" || err == nil
+	case "boolean":
+		_, err := strconv.ParseBool(node.Value)
+		return node.Tag == "!!bool" || err == nil
+	}
+
+	return false
+}
+
+// nodeType returns a json schema like type name for the node
+func nodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+
+	switch node.Tag {
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	default:
+		return "string"
+	}
+}
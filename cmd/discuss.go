@@ -9,6 +9,7 @@ import (
 	"text/template"
 
 	"github.com/evcc-io/evcc/server"
+	"github.com/evcc-io/evcc/util"
 	"github.com/pkg/browser"
 	"github.com/spf13/cobra"
 )
@@ -52,7 +53,7 @@ func runDiscuss(cmd *cobra.Command, args []string) {
 	out := new(bytes.Buffer)
 	_ = tmpl.Execute(out, map[string]any{
 		"CfgFile":    file,
-		"CfgError":   errorString(cfgErr),
+		"CfgError":   util.RedactSecrets(errorString(cfgErr)),
 		"CfgContent": redacted,
 		"Version":    server.FormattedVersion(),
 	})
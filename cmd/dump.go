@@ -11,6 +11,7 @@ import (
 	"github.com/Masterminds/sprig/v3"
 	"github.com/evcc-io/evcc/core"
 	"github.com/evcc-io/evcc/server"
+	"github.com/evcc-io/evcc/util"
 	"github.com/spf13/cobra"
 )
 
@@ -74,7 +75,7 @@ func runDump(cmd *cobra.Command, args []string) {
 		out := new(bytes.Buffer)
 		_ = tmpl.Execute(out, map[string]any{
 			"CfgFile":    file,
-			"CfgError":   errorString(err),
+			"CfgError":   util.RedactSecrets(errorString(err)),
 			"CfgContent": redacted,
 			"Version":    server.FormattedVersion(),
 		})
@@ -3,15 +3,27 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
 	"github.com/fatih/structs"
 )
 
+// redactWriter removes resolved secrets from the output
+type redactWriter struct {
+	io.Writer
+}
+
+func (w redactWriter) Write(p []byte) (int, error) {
+	_, err := w.Writer.Write([]byte(util.RedactSecrets(string(p))))
+	return len(p), err
+}
+
 type dumper struct {
 	len int
 }
@@ -34,7 +46,7 @@ func (d *dumper) DumpWithHeader(name string, device interface{}) {
 }
 
 func (d *dumper) Dump(name string, v interface{}) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	w := tabwriter.NewWriter(redactWriter{os.Stdout}, 0, 0, 1, ' ', 0)
 
 	// meter
 
@@ -223,7 +235,7 @@ func (d *dumper) Dump(name string, v interface{}) {
 }
 
 func (d *dumper) DumpDiagnosis(v interface{}) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	w := tabwriter.NewWriter(redactWriter{os.Stdout}, 0, 0, 1, ' ', 0)
 
 	if v, ok := v.(api.Diagnosis); ok {
 		fmt.Fprintln(w, "Diagnostic dump:")
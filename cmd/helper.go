@@ -58,9 +58,9 @@ func redact(src string) string {
 		"ain", "secret", "serial", "deviceid", "machineid", "idtag", // devices
 		"app", "chats", "recipients", // push messaging
 		"vin"} // vehicles
-	return regexp.
+	return util.RedactSecrets(regexp.
 		MustCompile(fmt.Sprintf(`(?i)\b(%s)\b.*?:.*`, strings.Join(secrets, "|"))).
-		ReplaceAllString(src, "$1: *****")
+		ReplaceAllString(src, "$1: *****"))
 }
 
 func publishErrorInfo(valueChan chan<- util.Param, cfgFile string, err error) {
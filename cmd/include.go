@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+// configNodes is the configuration file merged with its included files.
+// Lists are appended in include order, maps are merged recursively and
+// scalar values must not be defined more than once.
+type configNodes struct {
+	root   *yaml.Node
+	files  []string
+	origin map[*yaml.Node]string
+}
+
+// position returns the file and line of a node
+func (c *configNodes) position(node *yaml.Node) string {
+	return fmt.Sprintf("%s:%d", c.origin[node], node.Line)
+}
+
+// readConfigNodes reads the configuration file and merges all included files
+func readConfigNodes(file string) (*configNodes, error) {
+	c := &configNodes{
+		origin: make(map[*yaml.Node]string),
+	}
+
+	root, err := c.parse(file)
+	if err != nil {
+		return nil, err
+	}
+
+	c.root = root
+	if root == nil {
+		return c, nil
+	}
+
+	includes, err := c.includes(file)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, inc := range includes {
+		node, err := c.parse(inc)
+		if err != nil {
+			return nil, err
+		}
+
+		if node == nil {
+			continue
+		}
+
+		if key, _ := mappingValue(node, "include"); key != nil {
+			return nil, fmt.Errorf("%s: nested includes are not supported", c.position(key))
+		}
+
+		if err := c.merge(root, node, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// parse reads a yaml file and records the origin of all nodes
+func (c *configNodes) parse(file string) (*yaml.Node, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	c.files = append(c.files, file)
+	c.record(&doc, file)
+
+	root := resolveNode(&doc)
+	if root != nil && root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s:%d: configuration must be a map", file, root.Line)
+	}
+
+	return root, nil
+}
+
+func (c *configNodes) record(node *yaml.Node, file string) {
+	c.origin[node] = file
+	for _, n := range node.Content {
+		c.record(n, file)
+	}
+}
+
+// includes returns the files matching the include patterns, relative to the configuration file
+func (c *configNodes) includes(file string) ([]string, error) {
+	key, node := mappingValue(c.root, "include")
+	if key == nil || node == nil || node.Tag == "!!null" {
+		return nil, nil
+	}
+
+	patterns := []*yaml.Node{node}
+	if node.Kind == yaml.SequenceNode {
+		patterns = node.Content
+	}
+
+	var res []string
+	for _, pattern := range patterns {
+		if pattern = resolveNode(pattern); pattern.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("%s: include must be a file name or pattern", c.position(pattern))
+		}
+
+		path := pattern.Value
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(file), path)
+		}
+
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid include pattern: %w", c.position(pattern), err)
+		}
+
+		// patterns may be empty, files must exist
+		if len(matches) == 0 && !strings.ContainsAny(pattern.Value, `*?[\`) {
+			return nil, fmt.Errorf("%s: include file not found: %s", c.position(pattern), pattern.Value)
+		}
+
+		for _, match := range matches {
+			if same, _ := sameFile(match, file); !same && !slices.Contains(res, match) {
+				res = append(res, match)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// merge merges the src mapping into dst
+func (c *configNodes) merge(dst, src *yaml.Node, path string) error {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, val := src.Content[i], resolveNode(src.Content[i+1])
+		keyPath := joinPath(path, key.Value)
+
+		idx := -1
+		for j := 0; j+1 < len(dst.Content); j += 2 {
+			if strings.EqualFold(dst.Content[j].Value, key.Value) {
+				idx = j
+				break
+			}
+		}
+
+		if idx < 0 {
+			dst.Content = append(dst.Content, key, src.Content[i+1])
+			continue
+		}
+
+		dval := resolveNode(dst.Content[idx+1])
+
+		switch {
+		case dval.Kind == yaml.MappingNode && val.Kind == yaml.MappingNode:
+			if err := c.merge(dval, val, keyPath); err != nil {
+				return err
+			}
+
+		case dval.Kind == yaml.SequenceNode && val.Kind == yaml.SequenceNode:
+			dval.Content = append(dval.Content, val.Content...)
+
+		default:
+			return fmt.Errorf("%s: %s already defined at %s", c.position(key), keyPath, c.position(dst.Content[idx]))
+		}
+	}
+
+	return nil
+}
+
+// duplicates returns an error for each meter, charger or vehicle name defined more than once
+func (c *configNodes) duplicates() error {
+	var errs []error
+
+	for _, class := range []string{"meters", "chargers", "vehicles"} {
+		_, node := mappingValue(c.root, class)
+		if node == nil || node.Kind != yaml.SequenceNode {
+			continue
+		}
+
+		seen := make(map[string]*yaml.Node)
+		for _, dev := range node.Content {
+			_, name := mappingValue(resolveNode(dev), "name")
+			if name == nil || name.Value == "" {
+				continue
+			}
+
+			if first, ok := seen[name.Value]; ok {
+				errs = append(errs, fmt.Errorf("%s: duplicate %s name %s, first defined at %s",
+					c.position(name), strings.TrimSuffix(class, "s"), name.Value, c.position(first)))
+				continue
+			}
+
+			seen[name.Value] = name
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// mergeIncludes merges included files into the configuration read by viper
+func mergeIncludes(file string) error {
+	c, err := readConfigNodes(file)
+	if err != nil {
+		return err
+	}
+
+	if err := c.duplicates(); err != nil {
+		return err
+	}
+
+	if len(c.files) < 2 {
+		return nil
+	}
+
+	var settings map[string]any
+	if err := c.root.Decode(&settings); err != nil {
+		return err
+	}
+
+	return viper.MergeConfigMap(settings)
+}
+
+func sameFile(a, b string) (bool, error) {
+	fa, err := os.Stat(a)
+	if err != nil {
+		return false, err
+	}
+
+	fb, err := os.Stat(b)
+	if err != nil {
+		return false, err
+	}
+
+	return os.SameFile(fa, fb), nil
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/evcc-io/evcc/util"
+)
+
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestIncludeMerge(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"evcc.yaml": `
+include: conf.d/*.yaml
+meters:
+- name: grid
+  type: custom
+site:
+  title: Home
+`,
+		"conf.d/a.yaml": `
+meters:
+- name: pv
+  type: custom
+site:
+  meters:
+    grid: grid
+`,
+		"conf.d/b.yaml": `
+meters:
+- name: battery
+  type: custom
+`,
+	})
+
+	c, err := readConfigNodes(filepath.Join(dir, "evcc.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.duplicates(); err != nil {
+		t.Fatal(err)
+	}
+
+	var settings map[string]any
+	if err := c.root.Decode(&settings); err != nil {
+		t.Fatal(err)
+	}
+
+	var res config
+	if err := util.DecodeOther(settings, &res); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, m := range res.Meters {
+		names = append(names, m.Name)
+	}
+
+	if exp := "grid,pv,battery"; strings.Join(names, ",") != exp {
+		t.Errorf("expected meters %s, got %v", exp, names)
+	}
+
+	if res.Site["title"] != "Home" || res.Site["meters"] == nil {
+		t.Errorf("expected merged site, got %v", res.Site)
+	}
+}
+
+func TestIncludeErrors(t *testing.T) {
+	for _, tc := range []struct {
+		files map[string]string
+		err   string
+	}{
+		{map[string]string{
+			"evcc.yaml":  "include: [other.yaml]\nsite:\n  title: Home\n",
+			"other.yaml": "site:\n  title: Garage\n",
+		}, "other.yaml:2: site.title already defined at "},
+		{map[string]string{
+			"evcc.yaml":  "include: other.yaml\nmeters:\n- name: grid\n  type: custom\n",
+			"other.yaml": "meters:\n- name: grid\n  type: custom\n",
+		}, "other.yaml:2: duplicate meter name grid, first defined at "},
+		{map[string]string{
+			"evcc.yaml": "include: missing.yaml\n",
+		}, "evcc.yaml:1: include file not found: missing.yaml"},
+		{map[string]string{
+			"evcc.yaml":  "include: other.yaml\n",
+			"other.yaml": "include: evcc.yaml\n",
+		}, "other.yaml:1: nested includes are not supported"},
+	} {
+		dir := writeConfigFiles(t, tc.files)
+
+		c, err := readConfigNodes(filepath.Join(dir, "evcc.yaml"))
+		if err == nil {
+			err = c.duplicates()
+		}
+
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("expected error %q, got %v", tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagAPI   = "api"
+	flagToken = "token"
+)
+
+// ocppCmd represents the ocpp command
+var ocppCmd = &cobra.Command{
+	Use:   "ocpp",
+	Short: "Manage OCPP chargepoints of the running instance",
+}
+
+func init() {
+	rootCmd.AddCommand(ocppCmd)
+	ocppCmd.PersistentFlags().String(flagAPI, "", "evcc api address (default http://localhost:<network port>)")
+	ocppCmd.PersistentFlags().String(flagToken, "", "ocpp management token (default ocpp token from config file)")
+
+	ocppResetCmd.Flags().Bool("hard", false, "Hard reset")
+	ocppDiagnosticsCmd.Flags().String("location", "", "Upload location (default evcc diagnostics receiver)")
+
+	ocppCmd.AddCommand(ocppListCmd, ocppConfigCmd, ocppSetCmd, ocppResetCmd, ocppDiagnosticsCmd, ocppErrorsCmd)
+}
+
+var ocppListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List OCPP chargepoints",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		var res []struct {
+			ID        string
+			Connected bool
+		}
+		ocppRequest(cmd, http.MethodGet, "", nil, &res)
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+		for _, cp := range res {
+			fmt.Fprintf(tw, "%s\tconnected: %v\n", cp.ID, cp.Connected)
+		}
+		tw.Flush()
+	},
+}
+
+var ocppConfigCmd = &cobra.Command{
+	Use:   "config <chargepoint> [key...]",
+	Short: "Show chargepoint configuration",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		q := url.Values{"key": args[1:]}
+
+		var res struct {
+			ConfigurationKey []struct {
+				Key      string
+				Readonly bool
+				Value    *string
+			}
+			UnknownKey []string
+		}
+		ocppRequest(cmd, http.MethodGet, "/"+url.PathEscape(args[0])+"/configuration?"+q.Encode(), nil, &res)
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+		for _, kv := range res.ConfigurationKey {
+			var val string
+			if kv.Value != nil {
+				val = *kv.Value
+			}
+
+			var ro string
+			if kv.Readonly {
+				ro = "(readonly)"
+			}
+
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", kv.Key, val, ro)
+		}
+		for _, key := range res.UnknownKey {
+			fmt.Fprintf(tw, "%s\t\t(unknown)\n", key)
+		}
+		tw.Flush()
+	},
+}
+
+var ocppSetCmd = &cobra.Command{
+	Use:   "set <chargepoint> <key> <value>",
+	Short: "Change chargepoint configuration key",
+	Args:  cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		var res string
+		ocppRequest(cmd, http.MethodPost, "/"+url.PathEscape(args[0])+"/configuration/"+url.PathEscape(args[1]), map[string]string{"value": args[2]}, &res)
+		fmt.Println(res)
+	},
+}
+
+var ocppResetCmd = &cobra.Command{
+	Use:   "reset <chargepoint>",
+	Short: "Reset chargepoint",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		typ := "soft"
+		if hard, _ := cmd.Flags().GetBool("hard"); hard {
+			typ = "hard"
+		}
+
+		var res string
+		ocppRequest(cmd, http.MethodPost, "/"+url.PathEscape(args[0])+"/reset/"+typ, nil, &res)
+		fmt.Println(res, "reset accepted")
+	},
+}
+
+var ocppDiagnosticsCmd = &cobra.Command{
+	Use:   "diagnostics <chargepoint>",
+	Short: "Request chargepoint diagnostics upload",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		location, _ := cmd.Flags().GetString("location")
+
+		var res struct {
+			Location string
+			FileName string
+		}
+		ocppRequest(cmd, http.MethodPost, "/"+url.PathEscape(args[0])+"/diagnostics", map[string]string{"location": location}, &res)
+
+		fmt.Println("uploading to:", res.Location)
+		if res.FileName != "" {
+			fmt.Println("file:", res.FileName)
+		}
+	},
+}
+
+var ocppErrorsCmd = &cobra.Command{
+	Use:   "errors <chargepoint>",
+	Short: "Show chargepoint status notification errors",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var res []struct {
+			Timestamp       time.Time
+			Connector       int
+			Status          string
+			ErrorCode       string
+			Info            string
+			VendorId        string
+			VendorErrorCode string
+		}
+		ocppRequest(cmd, http.MethodGet, "/"+url.PathEscape(args[0])+"/errors", nil, &res)
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, e := range res {
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n", e.Timestamp.Local().Format(time.DateTime), e.Connector, e.Status, e.ErrorCode, e.VendorId, e.VendorErrorCode, e.Info)
+		}
+		tw.Flush()
+	},
+}
+
+// ocppAPI returns the address of the running instance's api
+func ocppAPI(cmd *cobra.Command) string {
+	if uri, _ := cmd.Flags().GetString(flagAPI); uri != "" {
+		return strings.TrimSuffix(uri, "/")
+	}
+
+	// network port from config file if available
+	_ = loadConfigFile(&conf)
+
+	return fmt.Sprintf("http://localhost:%d", conf.Network.Port)
+}
+
+// ocppToken returns the management token required for chargepoint configuration, diagnostics and changes
+func ocppToken(cmd *cobra.Command) string {
+	if token, _ := cmd.Flags().GetString(flagToken); token != "" {
+		return token
+	}
+
+	_ = loadConfigFile(&conf)
+
+	return conf.OCPP.Token
+}
+
+// ocppRequest performs an ocpp api request and decodes the result
+func ocppRequest(cmd *cobra.Command, method, path string, body, res any) {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			fatal(err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, ocppAPI(cmd)+"/api/ocpp"+path, reader)
+	if err != nil {
+		fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token := ocppToken(cmd); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := &http.Client{Timeout: time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Result json.RawMessage
+		Error  string
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		fatal(fmt.Errorf("%s: %w", resp.Status, err))
+	}
+
+	if envelope.Error != "" {
+		fatal(errors.New(envelope.Error))
+	}
+
+	if err := json.Unmarshal(envelope.Result, res); err != nil {
+		fatal(err)
+	}
+}
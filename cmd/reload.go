@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/evcc-io/evcc/util"
+	"github.com/spf13/viper"
+	"golang.org/x/exp/slices"
+)
+
+// reloadMu serializes configuration reloads and guards cp and conf once the site is running
+var reloadMu sync.Mutex
+
+// restartRequired returns the configuration sections that changed and cannot be applied without restart
+func restartRequired(prev, next config) []string {
+	var res []string
+
+	for _, section := range []struct {
+		name string
+		a, b any
+	}{
+		{"network", prev.Network, next.Network},
+		{"sponsortoken", prev.SponsorToken, next.SponsorToken},
+		{"plant", prev.Plant, next.Plant},
+		{"telemetry", prev.Telemetry, next.Telemetry},
+		{"interval", prev.Interval, next.Interval},
+		{"database", prev.Database, next.Database},
+		{"mqtt", prev.Mqtt, next.Mqtt},
+		{"modbusproxy", prev.ModbusProxy, next.ModbusProxy},
+		{"modbusserver", prev.ModbusServer, next.ModbusServer},
+		{"javascript", prev.Javascript, next.Javascript},
+		{"go", prev.Go, next.Go},
+		{"influx", prev.Influx, next.Influx},
+		{"eebus", prev.EEBus, next.EEBus},
+		{"hems", prev.HEMS, next.HEMS},
+		{"messaging", prev.Messaging, next.Messaging},
+		{"ocpp token", prev.OCPP.Token, next.OCPP.Token},
+	} {
+		if !reflect.DeepEqual(section.a, section.b) {
+			res = append(res, section.name)
+		}
+	}
+
+	return res
+}
+
+// changedDevices returns the names of added, removed or modified devices
+func changedDevices(prev, next []qualifiedConfig) []string {
+	var res []string
+
+	for _, cc := range next {
+		if !unchanged(prev, cc) {
+			res = append(res, cc.Name)
+		}
+	}
+
+	for _, cc := range prev {
+		if !unchanged(next, cc) && !slices.Contains(res, cc.Name) {
+			res = append(res, cc.Name)
+		}
+	}
+
+	return res
+}
+
+// reloadConfig re-reads the configuration file and applies the changes to the running site.
+// Unchanged meters, chargers, vehicles and tariffs are kept, changed ones are re-created.
+func reloadConfig(site *core.Site) error {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	if err := viper.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed reading config file: %w", err)
+	}
+
+	if err := mergeIncludes(viper.ConfigFileUsed()); err != nil {
+		return err
+	}
+
+	if err := resolveSecrets(); err != nil {
+		return err
+	}
+
+	next := defaultConfig()
+	if err := viper.UnmarshalExact(&next); err != nil {
+		return fmt.Errorf("failed parsing config file: %w", err)
+	}
+
+	if sections := restartRequired(conf, next); len(sections) > 0 {
+		return fmt.Errorf("changed configuration requires restart: %s", strings.Join(sections, ", "))
+	}
+
+	for _, class := range []struct {
+		name       string
+		prev, next []qualifiedConfig
+	}{
+		{"meters", conf.Meters, next.Meters},
+		{"chargers", conf.Chargers, next.Chargers},
+		{"vehicles", conf.Vehicles, next.Vehicles},
+	} {
+		if changed := changedDevices(class.prev, class.next); len(changed) > 0 {
+			log.INFO.Printf("reload: changed %s: %s", class.name, strings.Join(changed, ", "))
+		}
+	}
+
+	lpConfigs, err := loadpointConfigs()
+	if err != nil {
+		return err
+	}
+
+	// loadpoint devices cannot be replaced while running
+	changedChargers := changedDevices(conf.Chargers, next.Chargers)
+	changedMeters := changedDevices(conf.Meters, next.Meters)
+
+	for id, lpc := range lpConfigs {
+		var refs struct{ Charger, Meter string }
+		if err := util.DecodeOther(lpc, &refs); err != nil {
+			return err
+		}
+
+		if slices.Contains(changedChargers, refs.Charger) || slices.Contains(changedMeters, refs.Meter) {
+			return fmt.Errorf("changed loadpoint %d devices require restart", id+1)
+		}
+	}
+
+	ncp := new(ConfigProvider)
+	ncp.Reuse(cp, conf)
+
+	// close devices created for a configuration that could not be applied
+	applied := false
+	defer func() {
+		if !applied {
+			ncp.closeUnused(cp)
+		}
+	}()
+
+	if err := ncp.configure(next); err != nil {
+		return err
+	}
+
+	// vehicle authorization routes are bound to the previous instances
+	for name, v := range ncp.vehicles {
+		if _, ok := v.(api.AuthProvider); ok && v != cp.vehicles[name] {
+			log.WARN.Printf("reload: vehicle %s requires restart for authorization", name)
+		}
+	}
+
+	var tariffs *tariff.Tariffs
+	if !reflect.DeepEqual(conf.Tariffs, next.Tariffs) {
+		log.INFO.Println("reload: changed tariffs")
+
+		t, err := configureTariffs(next.Tariffs)
+		if err != nil {
+			return err
+		}
+		tariffs = &t
+	}
+
+	if err := site.Reload(ncp, next.Site, lpConfigs, ncp.vehicleList(), tariffs); err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(conf.OCPP.Auth, next.OCPP.Auth) {
+		log.INFO.Println("reload: changed ocpp authorization")
+		configureOCPP(next.OCPP)
+	}
+
+	ncp.auth = cp.auth
+	prev := cp
+	cp, conf = ncp, next
+	applied = true
+
+	// stop replaced devices
+	prev.closeUnused(cp)
+
+	parseLogLevels()
+
+	return nil
+}
+
+// closeUnused closes all devices that are not used by the next config provider
+func (cp *ConfigProvider) closeUnused(next *ConfigProvider) {
+	closeUnused(cp.meters, next.meters)
+	closeUnused(cp.chargers, next.chargers)
+	closeUnused(cp.vehicles, next.vehicles)
+}
+
+// closeUnused closes devices that implement io.Closer unless they are contained in next
+func closeUnused[T any](devices, next map[string]T) {
+	for name, dev := range devices {
+		if n, ok := next[name]; ok && any(n) == any(dev) {
+			continue
+		}
+
+		if c, ok := any(dev).(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				log.ERROR.Printf("reload: closing %s: %v", name, err)
+			}
+		}
+	}
+}
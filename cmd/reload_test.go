@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedDevices(t *testing.T) {
+	prev := []qualifiedConfig{
+		{Name: "grid", Type: "template", Other: map[string]interface{}{"host": "1.2.3.4"}},
+		{Name: "pv", Type: "template", Other: map[string]interface{}{"host": "1.2.3.5"}},
+		{Name: "aux", Type: "custom"},
+	}
+
+	next := []qualifiedConfig{
+		{Name: "grid", Type: "template", Other: map[string]interface{}{"host": "1.2.3.4"}},
+		{Name: "pv", Type: "template", Other: map[string]interface{}{"host": "1.2.3.6"}},
+		{Name: "battery", Type: "custom"},
+	}
+
+	res := changedDevices(prev, next)
+	if exp := []string{"pv", "battery", "aux"}; !reflect.DeepEqual(res, exp) {
+		t.Errorf("expected %v, got %v", exp, res)
+	}
+}
+
+func TestRestartRequired(t *testing.T) {
+	prev := defaultConfig()
+	next := defaultConfig()
+
+	next.Meters = []qualifiedConfig{{Name: "grid"}}
+	next.Tariffs.Currency = "DKK"
+
+	if res := restartRequired(prev, next); len(res) > 0 {
+		t.Errorf("expected no restart, got %v", res)
+	}
+
+	next.Network.Port = 8080
+	next.HEMS.Type = "semp"
+
+	res := restartRequired(prev, next)
+	if exp := []string{"network", "hems"}; !reflect.DeepEqual(res, exp) {
+		t.Errorf("expected %v, got %v", exp, res)
+	}
+}
+
+func TestReusable(t *testing.T) {
+	configs := []qualifiedConfig{
+		{Name: "foo", Type: "template", Other: map[string]interface{}{"host": "1.2.3.4"}},
+	}
+	devices := map[string]int{"foo": 1}
+
+	if dev, ok := reusable(devices, configs, configs[0]); !ok || dev != 1 {
+		t.Errorf("expected reuse of unchanged device")
+	}
+
+	changed := qualifiedConfig{Name: "foo", Type: "template", Other: map[string]interface{}{"host": "1.2.3.5"}}
+	if _, ok := reusable(devices, configs, changed); ok {
+		t.Errorf("expected changed device not to be reused")
+	}
+}
+
+type testCloser struct{ closed bool }
+
+func (c *testCloser) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestCloseUnused(t *testing.T) {
+	kept, replaced, removed := new(testCloser), new(testCloser), new(testCloser)
+
+	prev := map[string]any{"kept": kept, "replaced": replaced, "removed": removed}
+	next := map[string]any{"kept": kept, "replaced": new(testCloser)}
+
+	closeUnused(prev, next)
+
+	if kept.closed || !replaced.closed || !removed.closed {
+		t.Errorf("expected only unused devices to be closed")
+	}
+}
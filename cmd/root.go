@@ -178,7 +178,7 @@ func runRoot(cmd *cobra.Command, args []string) {
 	// setup modbus proxy
 	if err == nil {
 		for _, cfg := range conf.ModbusProxy {
-			if err = modbus.StartProxy(cfg.Port, cfg.Settings, cfg.ReadOnly); err != nil {
+			if err = modbus.StartProxy(cfg.Port, cfg.Settings, cfg.ReadOnly, cfg.Writable, cfg.Cache); err != nil {
 				break
 			}
 		}
@@ -202,6 +202,11 @@ func runRoot(cmd *cobra.Command, args []string) {
 		go publisher.Run(site, pipe.NewDropper(append(ignoreMqtt, ignoreEmpty)...).Pipe(tee.Attach()))
 	}
 
+	// setup modbus server
+	if err == nil && conf.ModbusServer.Port != 0 {
+		err = configureModbusServer(conf.ModbusServer, site, pipe.NewDropper(ignoreEmpty).Pipe(tee.Attach()))
+	}
+
 	// announce on mDNS
 	if err == nil && strings.HasSuffix(conf.Network.Host, ".local") {
 		err = configureMDNS(conf.Network)
@@ -215,7 +220,7 @@ func runRoot(cmd *cobra.Command, args []string) {
 	// setup messaging
 	var pushChan chan push.Event
 	if err == nil {
-		pushChan, err = configureMessengers(conf.Messaging, valueChan, cache)
+		pushChan, err = configureMessengers(conf.Messaging, site, valueChan, cache)
 	}
 
 	// run shutdown functions on stop
@@ -232,12 +237,13 @@ func runRoot(cmd *cobra.Command, args []string) {
 	}()
 
 	// wait for shutdown
+	interval := conf.Interval // conf is replaced on reload
 	go func() {
 		<-stopC
 
 		select {
 		case <-shutdownDoneC(): // wait for shutdown
-		case <-time.After(conf.Interval):
+		case <-time.After(interval):
 		}
 
 		if err != nil {
@@ -255,6 +261,27 @@ func runRoot(cmd *cobra.Command, args []string) {
 			once.Do(func() { close(stopC) }) // signal loop to end
 		})
 
+		// reload configuration on request or SIGHUP
+		reload := func() error {
+			err := reloadConfig(site)
+			if err != nil {
+				log.ERROR.Printf("reload: %v", err)
+			}
+			return err
+		}
+		httpd.RegisterReloadHandler(reload)
+		httpd.RegisterOCPPHandlers(conf.Network.URI(), conf.OCPP.Token)
+		httpd.RegisterModbusHandlers()
+
+		go func() {
+			hupC := make(chan os.Signal, 1)
+			signal.Notify(hupC, syscall.SIGHUP)
+
+			for range hupC {
+				_ = reload()
+			}
+		}()
+
 		// set channels
 		site.DumpConfig()
 		site.Prepare(valueChan, pushChan)
@@ -276,10 +303,12 @@ func runRoot(cmd *cobra.Command, args []string) {
 		}
 
 		// allow web access for vehicles
+		reloadMu.Lock()
 		cp.webControl(conf.Network, httpd.Router(), valueChan)
+		reloadMu.Unlock()
 
 		go func() {
-			site.Run(stopC, conf.Interval)
+			site.Run(stopC, interval)
 		}()
 	} else {
 		httpd.RegisterShutdownHandler(func() {
@@ -6,6 +6,8 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/spf13/viper"
 )
 
 func TestUnwrap(t *testing.T) {
@@ -37,3 +39,36 @@ func TestRedact(t *testing.T) {
 		t.Errorf("secret exposed: %v", res)
 	}
 }
+
+func TestResolveSecrets(t *testing.T) {
+	t.Setenv("EVCC_TEST_PASSWORD", "geheim")
+	defer viper.Reset()
+
+	viper.SetConfigType("yaml")
+	if err := viper.ReadConfig(strings.NewReader(`
+vehicles:
+- name: car
+  type: template
+  template: vw
+  password: ${env:EVCC_TEST_PASSWORD}
+`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := resolveSecrets(); err != nil {
+		t.Fatal(err)
+	}
+
+	var res config
+	if err := viper.UnmarshalExact(&res); err != nil {
+		t.Fatal(err)
+	}
+
+	if pass := res.Vehicles[0].Other["password"]; pass != "geheim" {
+		t.Errorf("expected resolved password, got %v", pass)
+	}
+
+	if res := redact("password geheim"); strings.Contains(res, "geheim") {
+		t.Errorf("secret not redacted: %s", res)
+	}
+}
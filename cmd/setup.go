@@ -10,6 +10,7 @@ import (
 	paho "github.com/eclipse/paho.mqtt.golang"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/charger/eebus"
+	"github.com/evcc-io/evcc/charger/ocpp"
 	"github.com/evcc-io/evcc/cmd/shutdown"
 	"github.com/evcc-io/evcc/core"
 	"github.com/evcc-io/evcc/core/site"
@@ -21,6 +22,7 @@ import (
 	"github.com/evcc-io/evcc/server"
 	"github.com/evcc-io/evcc/server/db"
 	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/server/modbus"
 	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/locale"
@@ -47,6 +49,14 @@ func loadConfigFile(conf *config) error {
 
 	log.INFO.Println("using config file:", cfgFile)
 
+	if err == nil {
+		err = mergeIncludes(cfgFile)
+	}
+
+	if err == nil {
+		err = resolveSecrets()
+	}
+
 	if err == nil {
 		if err = viper.UnmarshalExact(&conf); err != nil {
 			err = fmt.Errorf("failed parsing config file: %w", err)
@@ -61,6 +71,16 @@ func loadConfigFile(conf *config) error {
 	return err
 }
 
+// resolveSecrets replaces ${env:NAME} and ${file:/path} references in the configuration
+func resolveSecrets() error {
+	settings, err := util.ResolveSecrets(viper.AllSettings())
+	if err != nil {
+		return fmt.Errorf("failed resolving secrets: %w", err)
+	}
+
+	return viper.MergeConfigMap(settings.(map[string]any))
+}
+
 func configureEnvironment(cmd *cobra.Command, conf config) (err error) {
 	// full http request log
 	if cmd.Flags().Lookup(flagHeaders).Changed {
@@ -107,6 +127,11 @@ func configureEnvironment(cmd *cobra.Command, conf config) (err error) {
 		err = configureEEBus(conf.EEBus)
 	}
 
+	// setup OCPP authorization
+	if err == nil && !conf.OCPP.Auth.Empty() {
+		configureOCPP(conf.OCPP)
+	}
+
 	return
 }
 
@@ -120,11 +145,20 @@ func configureDatabase(conf dbConfig) error {
 		return err
 	}
 
-	shutdown.Register(func() {
+	persist := func() {
 		if err := settings.Persist(); err != nil {
 			log.ERROR.Println("cannot save settings:", err)
 		}
-	})
+	}
+
+	// persist periodically to survive unclean shutdowns
+	go func() {
+		for range time.Tick(time.Minute) {
+			persist()
+		}
+	}()
+
+	shutdown.Register(persist)
 
 	return nil
 }
@@ -182,6 +216,21 @@ func configureGo(conf []goConfig) error {
 	return nil
 }
 
+// setup modbus server
+func configureModbusServer(conf modbusServerConfig, site site.API, in <-chan util.Param) error {
+	srv, err := modbus.NewServer(site, conf.ReadOnly, conf.Writable)
+	if err == nil {
+		err = srv.Listen(conf.Port)
+	}
+	if err != nil {
+		return fmt.Errorf("failed configuring modbus server: %w", err)
+	}
+
+	go srv.Run(in)
+
+	return nil
+}
+
 // setup HEMS
 func configureHEMS(conf typedConfig, site *core.Site, httpd *server.HTTPd) error {
 	hems, err := hems.NewFromConfig(conf.Type, conf.Other, site, httpd)
@@ -221,11 +270,16 @@ func configureEEBus(conf map[string]interface{}) error {
 	return nil
 }
 
+// setup OCPP
+func configureOCPP(conf ocppConfig) {
+	ocpp.Instance().SetAuthorization(conf.Auth)
+}
+
 // setup messaging
-func configureMessengers(conf messagingConfig, valueChan chan util.Param, cache *util.Cache) (chan push.Event, error) {
+func configureMessengers(conf messagingConfig, site site.API, valueChan chan util.Param, cache *util.Cache) (chan push.Event, error) {
 	messageChan := make(chan push.Event, 1)
 
-	messageHub, err := push.NewHub(conf.Events, cache)
+	messageHub, err := push.NewHub(conf.Events, conf.Rules, cache)
 	if err != nil {
 		return messageChan, fmt.Errorf("failed configuring push services: %w", err)
 	}
@@ -235,7 +289,18 @@ func configureMessengers(conf messagingConfig, valueChan chan util.Param, cache
 		if err != nil {
 			return messageChan, fmt.Errorf("failed configuring push service %s: %w", service.Type, err)
 		}
-		messageHub.Add(impl)
+
+		// allow messenger to control the site
+		if c, ok := impl.(push.Controller); ok {
+			c.Control(site, cache)
+		}
+
+		name := service.Name
+		if name == "" {
+			name = service.Type
+		}
+
+		messageHub.Add(name, impl)
 	}
 
 	go messageHub.Run(messageChan, valueChan)
@@ -306,7 +371,11 @@ func configureSiteAndLoadpoints(conf config) (*core.Site, error) {
 		return nil, err
 	}
 
-	// list of vehicles ordered by name
+	return configureSite(conf.Site, cp, loadpoints, cp.vehicleList(), tariffs)
+}
+
+// vehicleList returns the list of vehicles ordered by name
+func (cp *ConfigProvider) vehicleList() []api.Vehicle {
 	keys := maps.Keys(cp.vehicles)
 	slices.Sort(keys)
 
@@ -315,7 +384,7 @@ func configureSiteAndLoadpoints(conf config) (*core.Site, error) {
 		vehicles = append(vehicles, cp.vehicles[k])
 	}
 
-	return configureSite(conf.Site, cp, loadpoints, vehicles, tariffs)
+	return vehicles
 }
 
 func configureSite(conf map[string]interface{}, cp *ConfigProvider, loadpoints []*core.Loadpoint, vehicles []api.Vehicle, tariffs tariff.Tariffs) (*core.Site, error) {
@@ -327,18 +396,33 @@ func configureSite(conf map[string]interface{}, cp *ConfigProvider, loadpoints [
 	return site, nil
 }
 
-func configureLoadpoints(conf config, cp *ConfigProvider) (loadpoints []*core.Loadpoint, err error) {
+// loadpointConfigs returns the loadpoint configurations
+func loadpointConfigs() ([]map[string]interface{}, error) {
 	lpInterfaces, ok := viper.AllSettings()["loadpoints"].([]interface{})
 	if !ok || len(lpInterfaces) == 0 {
 		return nil, errors.New("missing loadpoints")
 	}
 
-	for id, lpcI := range lpInterfaces {
+	res := make([]map[string]interface{}, 0, len(lpInterfaces))
+	for _, lpcI := range lpInterfaces {
 		var lpc map[string]interface{}
 		if err := util.DecodeOther(lpcI, &lpc); err != nil {
 			return nil, fmt.Errorf("failed decoding loadpoint configuration: %w", err)
 		}
 
+		res = append(res, lpc)
+	}
+
+	return res, nil
+}
+
+func configureLoadpoints(conf config, cp *ConfigProvider) (loadpoints []*core.Loadpoint, err error) {
+	lpConfigs, err := loadpointConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	for id, lpc := range lpConfigs {
 		log := util.NewLogger("lp-" + strconv.Itoa(id+1))
 		lp, err := core.NewLoadpointFromConfig(log, cp, lpc)
 		if err != nil {
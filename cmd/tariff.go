@@ -53,7 +53,11 @@ func runTariff(cmd *cobra.Command, args []string) {
 		}
 
 		rates, err := tf.Rates()
-		if err != nil {
+
+		// outdated rates remain usable until they expire
+		if tariff.Outdated(rates, err) {
+			fmt.Println("rates are outdated")
+		} else if err != nil {
 			fatal(err)
 		}
 
@@ -28,4 +28,6 @@ const (
 	targetTime              = "targetTime"              // target charging finish time goal
 	planActive              = "planActive"              // target charging plan has determined current slot to be an active slot
 	planProjectedStart      = "planProjectedStart"      // target charging plan start time (earliest slot)
+
+	decision = "decision" // charging decision of the last update cycle
 )
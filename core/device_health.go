@@ -0,0 +1,152 @@
+package core
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/push"
+)
+
+const (
+	evDeviceError     = "deviceError"     // device became unhealthy
+	evDeviceRecovered = "deviceRecovered" // device recovered
+)
+
+// DeviceHealthConfig configures when a failing device is considered unhealthy
+type DeviceHealthConfig struct {
+	Errors   int           // consecutive errors
+	Duration time.Duration // minimum duration since first error
+}
+
+// deviceState is the failure state of a single device
+type deviceState struct {
+	errors    int
+	since     time.Time // first error
+	unhealthy bool
+}
+
+// deviceHealth tracks device failures and sends push events when devices become unhealthy or recover
+type deviceHealth struct {
+	mu       sync.Mutex
+	clock    clock.Clock
+	config   DeviceHealthConfig
+	pushChan chan<- push.Event
+	devices  map[string]*deviceState
+}
+
+func newDeviceHealth(config DeviceHealthConfig, pushChan chan<- push.Event) *deviceHealth {
+	return &deviceHealth{
+		clock:    clock.New(),
+		config:   config,
+		pushChan: pushChan,
+		devices:  make(map[string]*deviceState),
+	}
+}
+
+// reason classifies the device error
+func reason(err error) string {
+	switch {
+	case errors.Is(err, api.ErrTimeout):
+		return "timeout"
+	case errors.Is(err, api.ErrOutdated):
+		return "outdated"
+	default:
+		return "error"
+	}
+}
+
+// Update records the result of a device operation
+func (h *deviceHealth) Update(device string, err error) {
+	if h == nil {
+		return
+	}
+
+	if err == nil {
+		h.recover(device)
+		return
+	}
+
+	h.fail(device, reason(err), err, false)
+}
+
+// Offline marks the device unhealthy regardless of thresholds
+func (h *deviceHealth) Offline(device string, err error) {
+	if h == nil || err == nil {
+		return
+	}
+
+	h.fail(device, "offline", err, true)
+}
+
+func (h *deviceHealth) fail(device, reason string, err error, force bool) {
+	h.mu.Lock()
+
+	now := h.clock.Now()
+
+	s, ok := h.devices[device]
+	if !ok {
+		s = new(deviceState)
+		h.devices[device] = s
+	}
+
+	if s.errors == 0 {
+		s.since = now
+	}
+	s.errors++
+
+	count := s.errors
+
+	notify := !s.unhealthy && (force || count >= h.config.Errors && now.Sub(s.since) >= h.config.Duration)
+	if notify {
+		s.unhealthy = true
+	}
+
+	h.mu.Unlock()
+
+	if notify {
+		var msg string
+		if err != nil {
+			msg = err.Error()
+		}
+
+		h.pushChan <- push.Event{
+			Event: evDeviceError,
+			Attributes: map[string]any{
+				"device": device,
+				"error":  msg,
+				"reason": reason,
+				"errors": count,
+			},
+		}
+	}
+}
+
+func (h *deviceHealth) recover(device string) {
+	h.mu.Lock()
+
+	s, ok := h.devices[device]
+	if !ok || s.errors == 0 {
+		h.mu.Unlock()
+		return
+	}
+
+	notify := s.unhealthy
+	duration := h.clock.Since(s.since).Round(time.Second)
+
+	*s = deviceState{}
+
+	h.mu.Unlock()
+
+	if notify {
+		h.pushChan <- push.Event{
+			Event: evDeviceRecovered,
+			Attributes: map[string]any{
+				"device":   device,
+				"duration": duration,
+			},
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/vehicle"
+	"github.com/evcc-io/evcc/vehicle/wrapper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceHealth(t *testing.T) {
+	pushChan := make(chan push.Event, 1)
+	clck := clock.NewMock()
+
+	h := newDeviceHealth(DeviceHealthConfig{Errors: 2, Duration: time.Minute}, pushChan)
+	h.clock = clck
+
+	noEvent := func() {
+		t.Helper()
+		select {
+		case ev := <-pushChan:
+			t.Fatalf("unexpected event: %v", ev)
+		default:
+		}
+	}
+
+	// transient error
+	h.Update("grid meter", errors.New("foo"))
+	h.Update("grid meter", nil)
+	noEvent()
+
+	// error count reached, duration not
+	h.Update("grid meter", errors.New("foo"))
+	h.Update("grid meter", errors.New("foo"))
+	noEvent()
+
+	clck.Add(time.Minute)
+	h.Update("grid meter", fmt.Errorf("read: %w", api.ErrTimeout))
+
+	ev := <-pushChan
+	assert.Equal(t, evDeviceError, ev.Event)
+	assert.Equal(t, "grid meter", ev.Attributes["device"])
+	assert.Equal(t, "timeout", ev.Attributes["reason"])
+	assert.Equal(t, 3, ev.Attributes["errors"])
+
+	// no repeated error
+	h.Update("grid meter", errors.New("foo"))
+	noEvent()
+
+	clck.Add(time.Minute)
+	h.Update("grid meter", nil)
+
+	ev = <-pushChan
+	assert.Equal(t, evDeviceRecovered, ev.Event)
+	assert.Equal(t, 2*time.Minute, ev.Attributes["duration"])
+
+	h.Update("grid meter", nil)
+	noEvent()
+
+	// offline ignores thresholds
+	h.Offline("vehicle foo", errors.New("vehicle not available"))
+	ev = <-pushChan
+	assert.Equal(t, "offline", ev.Attributes["reason"])
+
+	// offline without error is not reported
+	h.Offline("vehicle bar", nil)
+	noEvent()
+
+	require.NotPanics(t, func() { h.fail("vehicle bar", "offline", nil, true) })
+	ev = <-pushChan
+	assert.Equal(t, "", ev.Attributes["error"])
+
+	// nil-safe
+	var nh *deviceHealth
+	require.NotPanics(t, func() { nh.Update("charger", errors.New("foo")) })
+}
+
+func TestReportOfflineVehicles(t *testing.T) {
+	pushChan := make(chan push.Event, 2)
+
+	site := &Site{
+		log:    util.NewLogger("foo"),
+		health: newDeviceHealth(DeviceHealthConfig{}, pushChan),
+	}
+
+	// offline template has the offline feature but is not failing
+	offline, err := vehicle.NewFromConfig("template", map[string]interface{}{
+		"template": "offline",
+		"title":    "foo",
+		"capacity": 50,
+	})
+	require.NoError(t, err)
+
+	failed := wrapper.New("bar", nil, errors.New("login failed"))
+
+	require.NotPanics(t, func() { site.reportOffline([]api.Vehicle{offline, failed}) })
+
+	ev := <-pushChan
+	assert.Equal(t, evDeviceError, ev.Event)
+	assert.Equal(t, "vehicle Bar (offline)", ev.Attributes["device"])
+	assert.Equal(t, "offline", ev.Attributes["reason"])
+
+	select {
+	case ev := <-pushChan:
+		t.Fatalf("unexpected event: %v", ev)
+	default:
+	}
+}
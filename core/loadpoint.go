@@ -101,6 +101,7 @@ type Loadpoint struct {
 	uiChan   chan<- util.Param // client push messages
 	lpChan   chan<- *Loadpoint // update requests
 	log      *util.Logger
+	health   *deviceHealth // device failure notifications
 
 	// exposed public configuration
 	sync.Mutex                // guard status
@@ -151,14 +152,21 @@ type Loadpoint struct {
 	planActive  bool      // plan is active
 
 	// cached state
-	status         api.ChargeStatus       // Charger status
-	remoteDemand   loadpoint.RemoteDemand // External status demand
-	chargePower    float64                // Charging power
-	chargeCurrents []float64              // Phase currents
-	connectedTime  time.Time              // Time when vehicle was connected
-	pvTimer        time.Time              // PV enabled/disable timer
-	phaseTimer     time.Time              // 1p3p switch timer
-	wakeUpTimer    *Timer                 // Vehicle wake-up timeout
+	status         api.ChargeStatus                  // Charger status
+	remoteDemand   loadpoint.RemoteDemand            // External status demand
+	remoteDemands  map[string]loadpoint.RemoteDemand // External status demands by source
+	remoteLimits   map[string]float64                // External current limits by source
+	chargePower    float64                           // Charging power
+	chargeCurrents []float64                         // Phase currents
+	connectedTime  time.Time                         // Time when vehicle was connected
+	pvTimer        time.Time                         // PV enabled/disable timer
+	phaseTimer     time.Time                         // 1p3p switch timer
+	wakeUpTimer    *Timer                            // Vehicle wake-up timeout
+
+	// charging decisions
+	pvTimerState    timerState           // last published pv timer state
+	phaseTimerState timerState           // last published phase timer state
+	decisions       []loadpoint.Decision // recent charging decisions, guarded by mutex
 
 	// charge progress
 	vehicleSoc              float64        // Vehicle Soc
@@ -649,6 +657,12 @@ func (lp *Loadpoint) syncCharger() error {
 
 // setLimit applies charger current limits and enables/disables accordingly
 func (lp *Loadpoint) setLimit(chargeCurrent float64, force bool) error {
+	// external current limit
+	if limit, ok := lp.remoteMaxCurrent(); ok && chargeCurrent > limit {
+		lp.log.DEBUG.Printf("remote current limit: %.3gA", limit)
+		chargeCurrent = limit
+	}
+
 	// full amps only?
 	if _, ok := lp.charger.(api.ChargerEx); !ok || lp.vehicleHasFeature(api.CoarseCurrent) {
 		chargeCurrent = math.Trunc(chargeCurrent)
@@ -794,6 +808,21 @@ func (lp *Loadpoint) remoteControlled(demand loadpoint.RemoteDemand) bool {
 	return lp.remoteDemand == demand
 }
 
+// remoteMaxCurrent returns the lowest external current limit
+func (lp *Loadpoint) remoteMaxCurrent() (float64, bool) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	res, ok := 0.0, false
+	for _, limit := range lp.remoteLimits {
+		if !ok || limit < res {
+			res, ok = limit, true
+		}
+	}
+
+	return res, ok
+}
+
 // statusEvents converts the observed charger status change into a logical sequence of events
 func statusEvents(prevStatus, status api.ChargeStatus) []string {
 	res := make([]string, 0, 2)
@@ -824,6 +853,8 @@ func statusEvents(prevStatus, status api.ChargeStatus) []string {
 // updateChargerStatus updates charger status and detects car connected/disconnected events
 func (lp *Loadpoint) updateChargerStatus() error {
 	status, err := lp.charger.Status()
+	lp.health.Update("charger", err)
+
 	if err != nil {
 		return err
 	}
@@ -1060,6 +1091,13 @@ func (lp *Loadpoint) publishTimer(name string, delay time.Duration, action strin
 		remaining = 0
 	}
 
+	switch name {
+	case pvTimer:
+		lp.pvTimerState = timerState{action: action, remaining: remaining}
+	case phaseTimer:
+		lp.phaseTimerState = timerState{action: action, remaining: remaining}
+	}
+
 	lp.publish(name+"Action", action)
 	lp.publish(name+"Remaining", remaining)
 
@@ -1194,6 +1232,8 @@ func (lp *Loadpoint) UpdateChargePower() {
 
 		return nil
 	}, retryOptions...)
+	lp.health.Update("charge meter", err)
+
 	if err != nil {
 		lp.log.ERROR.Printf("charge meter: %v", err)
 	}
@@ -1324,6 +1364,11 @@ func (lp *Loadpoint) publishSocAndRange() {
 		lp.socUpdated = lp.clock.Now()
 
 		f, err := lp.socEstimator.Soc(lp.getChargedEnergy())
+
+		if lp.health != nil && lp.vehicle != nil && !errors.Is(err, api.ErrMustRetry) {
+			lp.health.Update("vehicle "+lp.vehicle.Title(), err)
+		}
+
 		if err != nil {
 			if errors.Is(err, api.ErrMustRetry) {
 				lp.socUpdated = time.Time{}
@@ -1440,6 +1485,19 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 
 	lp.sessionEnergy.SetEnvironment(greenShare, effPrice, effCo2)
 
+	// record decision inputs
+	d := loadpoint.Decision{
+		Created:          lp.clock.Now(),
+		Mode:             mode,
+		SitePower:        sitePower,
+		EnableThreshold:  lp.GetEnableThreshold(),
+		DisableThreshold: lp.GetDisableThreshold(),
+		TargetTime:       lp.GetTargetTime(),
+		AutoCharge:       autoCharge,
+		BatteryBuffered:  batteryBuffered,
+		BatteryStart:     batteryStart,
+	}
+
 	// update ChargeRater here to make sure initial meter update is caught
 	lp.bus.Publish(evChargeCurrent, lp.chargeCurrent)
 	lp.bus.Publish(evChargePower, lp.chargePower)
@@ -1450,6 +1508,8 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 	// read and publish status
 	if err := lp.updateChargerStatus(); err != nil {
 		lp.log.ERROR.Printf("charger: %v", err)
+		d.Reason = loadpoint.ReasonChargerError
+		lp.publishDecision(d, err)
 		return
 	}
 
@@ -1475,6 +1535,8 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 	// sync settings with charger
 	if err := lp.syncCharger(); err != nil {
 		lp.log.ERROR.Printf("charger: %v", err)
+		d.Reason = loadpoint.ReasonChargerError
+		lp.publishDecision(d, err)
 		return
 	}
 
@@ -1489,34 +1551,45 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 	case !lp.connected():
 		// always disable charger if not connected
 		// https://github.com/evcc-io/evcc/issues/105
+		d.Reason = loadpoint.ReasonDisconnected
 		err = lp.setLimit(0, false)
 
 	case lp.scalePhasesRequired():
+		d.Reason = loadpoint.ReasonPhaseSwitch
 		if err = lp.scalePhases(lp.ConfiguredPhases); err == nil {
 			lp.log.DEBUG.Printf("switched phases: %dp", lp.ConfiguredPhases)
 		}
 
 	case lp.targetEnergyReached():
 		lp.log.DEBUG.Printf("targetEnergy reached: %.0fkWh > %0.1fkWh", lp.getChargedEnergy()/1e3, lp.targetEnergy)
+		d.Reason = loadpoint.ReasonTargetEnergy
 		err = lp.disableUnlessClimater()
 
 	case lp.targetSocReached():
 		lp.log.DEBUG.Printf("targetSoc reached: %.1f%% > %d%%", lp.vehicleSoc, lp.Soc.target)
+		d.Reason = loadpoint.ReasonTargetSoc
 		err = lp.disableUnlessClimater()
 
 	case lp.remoteControlled(loadpoint.RemoteHardDisable):
 		remoteDisabled = loadpoint.RemoteHardDisable
-		fallthrough
+		d.Reason = loadpoint.ReasonRemoteHardDisable
+		err = lp.setLimit(0, true)
 
 	case mode == api.ModeOff:
+		d.Reason = loadpoint.ReasonModeOff
 		err = lp.setLimit(0, true)
 
 	// immediate charging
 	case mode == api.ModeNow:
+		d.Reason = loadpoint.ReasonModeNow
 		err = lp.fastCharging()
 
 	// minimum or target charging
 	case lp.minSocNotReached() || lp.plannerActive():
+		d.Reason = loadpoint.ReasonPlan
+		if lp.minSocNotReached() {
+			d.Reason = loadpoint.ReasonMinSoc
+		}
 		err = lp.fastCharging()
 		lp.resetPhaseTimer()
 		lp.elapsePVTimer() // let PV mode disable immediately afterwards
@@ -1524,16 +1597,19 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 	case mode == api.ModeMinPV || mode == api.ModePV:
 		// cheap tariff
 		if autoCharge && lp.GetTargetTime().IsZero() {
+			d.Reason = loadpoint.ReasonCheapTariff
 			err = lp.fastCharging()
 			lp.resetPhaseTimer()
 			lp.elapsePVTimer() // let PV mode disable immediately afterwards
 			break
 		}
 
+		d.Reason = loadpoint.ReasonPV
 		targetCurrent := lp.pvMaxCurrent(mode, sitePower, batteryBuffered, batteryStart)
 
 		var required bool // false
 		if targetCurrent == 0 && lp.vehicleClimateActive() {
+			d.Reason = loadpoint.ReasonClimater
 			targetCurrent = lp.GetMinCurrent()
 			required = true
 		}
@@ -1541,6 +1617,7 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 		// Sunny Home Manager
 		if lp.remoteControlled(loadpoint.RemoteSoftDisable) {
 			remoteDisabled = loadpoint.RemoteSoftDisable
+			d.Reason = loadpoint.ReasonRemoteSoftDisable
 			targetCurrent = 0
 			required = true
 		}
@@ -1559,6 +1636,8 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 		lp.publish("remoteDisabled", remoteDisabled)
 	}
 
+	lp.publishDecision(d, err)
+
 	// log any error
 	if err != nil {
 		lp.log.ERROR.Println(err)
@@ -25,6 +25,8 @@ type API interface {
 
 	// GetStatus returns the charging status
 	GetStatus() api.ChargeStatus
+	// GetDecisions returns the recent charging decisions
+	GetDecisions() []Decision
 
 	//
 	// settings
@@ -66,8 +68,10 @@ type API interface {
 	// SetDisableThreshold sets loadpoint disable threshold
 	SetDisableThreshold(threshold float64)
 
-	// RemoteControl sets remote status demand
+	// RemoteControl sets remote status demand on behalf of an external source, RemoteEnable removes the source's demand
 	RemoteControl(string, RemoteDemand)
+	// SetRemoteMaxCurrent limits the charge current on behalf of an external source, RemoteNoLimit removes the limit
+	SetRemoteMaxCurrent(string, float64)
 
 	//
 	// power and energy
@@ -100,6 +104,8 @@ type API interface {
 	GetRemainingDuration() time.Duration
 	// GetRemainingEnergy is the remaining charge energy in Wh
 	GetRemainingEnergy() float64
+	// GetVehicleSoc returns the vehicle soc
+	GetVehicleSoc() float64
 
 	//
 	// vehicles
@@ -0,0 +1,62 @@
+package loadpoint
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// Reason is the branch taken by the loadpoint control cycle
+type Reason string
+
+// charging decision reasons
+const (
+	ReasonDisconnected      Reason = "disconnected"      // vehicle not connected
+	ReasonChargerError      Reason = "chargerError"      // charger could not be read or synced
+	ReasonPhaseSwitch       Reason = "phaseSwitch"       // configured phases differ from enabled phases
+	ReasonTargetEnergy      Reason = "targetEnergy"      // target energy reached
+	ReasonTargetSoc         Reason = "targetSoc"         // target soc reached
+	ReasonRemoteHardDisable Reason = "remoteHardDisable" // disabled by external hard demand
+	ReasonModeOff           Reason = "modeOff"           // charge mode off
+	ReasonModeNow           Reason = "modeNow"           // charge mode now
+	ReasonMinSoc            Reason = "minSoc"            // min soc not reached
+	ReasonPlan              Reason = "plan"              // planner active
+	ReasonCheapTariff       Reason = "cheapTariff"       // cheap or green tariff
+	ReasonPV                Reason = "pv"                // pv or min+pv charging
+	ReasonClimater          Reason = "climater"          // vehicle climater active
+	ReasonRemoteSoftDisable Reason = "remoteSoftDisable" // disabled by external soft demand
+)
+
+// Decision records inputs and result of a single loadpoint control cycle
+type Decision struct {
+	Created          time.Time        `json:"created"`
+	Reason           Reason           `json:"reason"`
+	Mode             api.ChargeMode   `json:"mode"`
+	Status           api.ChargeStatus `json:"status"`
+	SitePower        float64          `json:"sitePower"`
+	EnableThreshold  float64          `json:"enableThreshold"`
+	DisableThreshold float64          `json:"disableThreshold"`
+	PVAction         string           `json:"pvAction"`
+	PVRemaining      int64            `json:"pvRemaining"` // seconds
+	PhaseAction      string           `json:"phaseAction"`
+	PhaseRemaining   int64            `json:"phaseRemaining"` // seconds
+	PlanActive       bool             `json:"planActive"`
+	PlanSlotEnd      time.Time        `json:"planSlotEnd"`
+	TargetTime       time.Time        `json:"targetTime"`
+	AutoCharge       bool             `json:"autoCharge"`
+	BatteryBuffered  bool             `json:"batteryBuffered"`
+	BatteryStart     bool             `json:"batteryStart"`
+	Enabled          bool             `json:"enabled"`
+	ChargeCurrent    float64          `json:"chargeCurrent"`
+	Phases           int              `json:"phases"`
+	Error            string           `json:"error,omitempty"`
+}
+
+// String returns a short human-readable summary of the decision
+func (d Decision) String() string {
+	if !d.Enabled {
+		return fmt.Sprintf("%s: disabled", d.Reason)
+	}
+	return fmt.Sprintf("%s: %.3gA @ %dp", d.Reason, d.ChargeCurrent, d.Phases)
+}
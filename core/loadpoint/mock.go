@@ -63,6 +63,20 @@ func (mr *MockAPIMockRecorder) GetChargePowerFlexibility() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChargePowerFlexibility", reflect.TypeOf((*MockAPI)(nil).GetChargePowerFlexibility))
 }
 
+// GetDecisions mocks base method.
+func (m *MockAPI) GetDecisions() []Decision {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDecisions")
+	ret0, _ := ret[0].([]Decision)
+	return ret0
+}
+
+// GetDecisions indicates an expected call of GetDecisions.
+func (mr *MockAPIMockRecorder) GetDecisions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDecisions", reflect.TypeOf((*MockAPI)(nil).GetDecisions))
+}
+
 // GetDisableThreshold mocks base method.
 func (m *MockAPI) GetDisableThreshold() float64 {
 	m.ctrl.T.Helper()
@@ -303,6 +317,20 @@ func (mr *MockAPIMockRecorder) GetVehicle() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicle", reflect.TypeOf((*MockAPI)(nil).GetVehicle))
 }
 
+// GetVehicleSoc mocks base method.
+func (m *MockAPI) GetVehicleSoc() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVehicleSoc")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetVehicleSoc indicates an expected call of GetVehicleSoc.
+func (mr *MockAPIMockRecorder) GetVehicleSoc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicleSoc", reflect.TypeOf((*MockAPI)(nil).GetVehicleSoc))
+}
+
 // HasChargeMeter mocks base method.
 func (m *MockAPI) HasChargeMeter() bool {
 	m.ctrl.T.Helper()
@@ -429,6 +457,18 @@ func (mr *MockAPIMockRecorder) SetPhases(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPhases", reflect.TypeOf((*MockAPI)(nil).SetPhases), arg0)
 }
 
+// SetRemoteMaxCurrent mocks base method.
+func (m *MockAPI) SetRemoteMaxCurrent(arg0 string, arg1 float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRemoteMaxCurrent", arg0, arg1)
+}
+
+// SetRemoteMaxCurrent indicates an expected call of SetRemoteMaxCurrent.
+func (mr *MockAPIMockRecorder) SetRemoteMaxCurrent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRemoteMaxCurrent", reflect.TypeOf((*MockAPI)(nil).SetRemoteMaxCurrent), arg0, arg1)
+}
+
 // SetTargetEnergy mocks base method.
 func (m *MockAPI) SetTargetEnergy(arg0 float64) {
 	m.ctrl.T.Helper()
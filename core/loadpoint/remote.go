@@ -12,6 +12,9 @@ const (
 	RemoteSoftDisable RemoteDemand = "soft"
 )
 
+// RemoteNoLimit removes an external current limit
+const RemoteNoLimit = -1.0
+
 // RemoteDemandString converts string to RemoteDemand
 func RemoteDemandString(demand string) (RemoteDemand, error) {
 	switch strings.ToLower(demand) {
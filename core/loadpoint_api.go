@@ -9,6 +9,7 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/wrapper"
+	"golang.org/x/exp/maps"
 )
 
 var _ loadpoint.API = (*Loadpoint)(nil)
@@ -253,24 +254,81 @@ func (lp *Loadpoint) SetDisableThreshold(threshold float64) {
 	}
 }
 
-// RemoteControl sets remote status demand
+// RemoteControl sets remote status demand on behalf of an external source, RemoteEnable removes the source's demand
 func (lp *Loadpoint) RemoteControl(source string, demand loadpoint.RemoteDemand) {
 	lp.Lock()
 	defer lp.Unlock()
 
-	lp.log.DEBUG.Println("remote demand:", demand)
+	lp.log.DEBUG.Printf("remote demand (%s): %s", source, demand)
 
-	// apply immediately
-	if lp.remoteDemand != demand {
-		lp.remoteDemand = demand
+	if demand == loadpoint.RemoteEnable {
+		delete(lp.remoteDemands, source)
+	} else {
+		if lp.remoteDemands == nil {
+			lp.remoteDemands = make(map[string]loadpoint.RemoteDemand)
+		}
+		lp.remoteDemands[source] = demand
+	}
+
+	rank := func(d loadpoint.RemoteDemand) int {
+		switch d {
+		case loadpoint.RemoteHardDisable:
+			return 2
+		case loadpoint.RemoteSoftDisable:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	// most restrictive demand applies
+	res, resSource := loadpoint.RemoteEnable, ""
+	for src, d := range lp.remoteDemands {
+		if r := rank(d); r > rank(res) || r == rank(res) && src < resSource {
+			res, resSource = d, src
+		}
+	}
 
-		lp.publish("remoteDisabled", demand)
-		lp.publish("remoteDisabledSource", source)
+	lp.publish("remoteDisabled", res)
+	lp.publish("remoteDisabledSource", resSource)
 
+	// apply immediately
+	if lp.remoteDemand != res {
+		lp.remoteDemand = res
 		lp.requestUpdate()
 	}
 }
 
+// SetRemoteMaxCurrent limits the charge current on behalf of an external source
+func (lp *Loadpoint) SetRemoteMaxCurrent(source string, current float64) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	prev, ok := lp.remoteLimits[source]
+
+	if current < 0 {
+		if !ok {
+			return
+		}
+
+		lp.log.DEBUG.Printf("remote current limit (%s): removed", source)
+		delete(lp.remoteLimits, source)
+	} else {
+		if ok && prev == current {
+			return
+		}
+
+		lp.log.DEBUG.Printf("remote current limit (%s): %.3gA", source, current)
+		if lp.remoteLimits == nil {
+			lp.remoteLimits = make(map[string]float64)
+		}
+		lp.remoteLimits[source] = current
+	}
+
+	lp.publish("remoteLimits", maps.Clone(lp.remoteLimits))
+	lp.requestUpdate()
+}
+
 // HasChargeMeter determines if a physical charge meter is attached
 func (lp *Loadpoint) HasChargeMeter() bool {
 	_, isWrapped := lp.chargeMeter.(*wrapper.ChargeMeter)
@@ -394,6 +452,13 @@ func (lp *Loadpoint) GetRemainingEnergy() float64 {
 	return lp.chargeRemainingEnergy
 }
 
+// GetVehicleSoc returns the vehicle soc
+func (lp *Loadpoint) GetVehicleSoc() float64 {
+	lp.Lock()
+	defer lp.Unlock()
+	return lp.vehicleSoc
+}
+
 // GetVehicle gets the active vehicle
 func (lp *Loadpoint) GetVehicle() api.Vehicle {
 	lp.Lock()
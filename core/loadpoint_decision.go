@@ -0,0 +1,56 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+// maxDecisions is the number of charging decisions kept in history
+const maxDecisions = 30
+
+// timerState is the last published state of a loadpoint timer
+type timerState struct {
+	action    string
+	remaining time.Duration
+}
+
+// publishDecision completes the decision with the resulting charger state, publishes it and adds it to the history
+func (lp *Loadpoint) publishDecision(d loadpoint.Decision, err error) {
+	d.Status = lp.GetStatus()
+	d.Enabled = lp.enabled
+	if lp.enabled {
+		d.ChargeCurrent = lp.chargeCurrent
+	}
+	d.Phases = lp.activePhases()
+
+	d.PVAction, d.PVRemaining = lp.pvTimerState.action, int64(lp.pvTimerState.remaining.Seconds())
+	d.PhaseAction, d.PhaseRemaining = lp.phaseTimerState.action, int64(lp.phaseTimerState.remaining.Seconds())
+	d.PlanActive = lp.planActive
+	d.PlanSlotEnd = lp.planSlotEnd
+
+	if err != nil {
+		d.Error = err.Error()
+	}
+
+	lp.Lock()
+	lp.decisions = append(lp.decisions, d)
+	if len(lp.decisions) > maxDecisions {
+		lp.decisions = lp.decisions[len(lp.decisions)-maxDecisions:]
+	}
+	lp.Unlock()
+
+	lp.log.DEBUG.Printf("decision: %v", d)
+	lp.publish(decision, d)
+}
+
+// GetDecisions returns the recent charging decisions, latest last
+func (lp *Loadpoint) GetDecisions() []loadpoint.Decision {
+	lp.Lock()
+	defer lp.Unlock()
+
+	res := make([]loadpoint.Decision, len(lp.decisions))
+	copy(res, lp.decisions)
+
+	return res
+}
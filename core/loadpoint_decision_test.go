@@ -0,0 +1,109 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecision(t *testing.T) {
+	tc := []struct {
+		status  api.ChargeStatus
+		mode    api.ChargeMode
+		reason  loadpoint.Reason
+		enabled bool
+		expect  func(h *mock.MockCharger)
+	}{
+		{api.StatusA, api.ModeNow, loadpoint.ReasonDisconnected, false, func(h *mock.MockCharger) {
+			h.EXPECT().Enable(false)
+		}},
+		{api.StatusB, api.ModeOff, loadpoint.ReasonModeOff, false, func(h *mock.MockCharger) {
+			h.EXPECT().Enable(false)
+		}},
+		{api.StatusC, api.ModeNow, loadpoint.ReasonModeNow, true, func(h *mock.MockCharger) {
+			h.EXPECT().MaxCurrent(int64(maxA))
+		}},
+		{api.StatusC, api.ModePV, loadpoint.ReasonPV, true, func(h *mock.MockCharger) {
+			h.EXPECT().MaxCurrent(int64(8)) // 6A + 500W @ 1p
+		}},
+	}
+
+	for _, tc := range tc {
+		t.Log(tc)
+
+		ctrl := gomock.NewController(t)
+		charger := mock.NewMockCharger(ctrl)
+
+		lp := &Loadpoint{
+			log:           util.NewLogger("foo"),
+			bus:           evbus.New(),
+			clock:         clock.NewMock(),
+			charger:       charger,
+			chargeMeter:   &Null{}, // silence nil panics
+			chargeRater:   &Null{}, // silence nil panics
+			chargeTimer:   &Null{}, // silence nil panics
+			wakeUpTimer:   NewTimer(),
+			sessionEnergy: NewEnergyMetrics(),
+			MinCurrent:    minA,
+			MaxCurrent:    maxA,
+			phases:        1,
+			status:        tc.status, // no status change
+		}
+
+		attachListeners(t, lp)
+
+		charger.EXPECT().Status().Return(tc.status, nil)
+		charger.EXPECT().Enabled().Return(true, nil)
+
+		if tc.expect != nil {
+			tc.expect(charger)
+		}
+
+		lp.Mode = tc.mode
+		lp.Update(-500, false, false, false, 0, nil, nil)
+
+		res := lp.GetDecisions()
+		assert.Len(t, res, 1)
+
+		d := res[0]
+		assert.Equal(t, tc.reason, d.Reason)
+		assert.Equal(t, tc.mode, d.Mode)
+		assert.Equal(t, -500.0, d.SitePower)
+		assert.Equal(t, tc.enabled, d.Enabled)
+
+		ctrl.Finish()
+	}
+}
+
+func TestDecisionHistory(t *testing.T) {
+	lp := NewLoadpoint(util.NewLogger("foo"))
+
+	for i := 0; i < maxDecisions+5; i++ {
+		lp.publishDecision(loadpoint.Decision{SitePower: float64(i)}, nil)
+	}
+
+	res := lp.GetDecisions()
+	assert.Len(t, res, maxDecisions)
+	assert.Equal(t, float64(5), res[0].SitePower)
+	assert.Equal(t, float64(maxDecisions+4), res[len(res)-1].SitePower)
+}
+
+func TestDecisionRemainingSeconds(t *testing.T) {
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	lp.pvTimerState = timerState{action: pvEnable, remaining: 90 * time.Second}
+
+	lp.publishDecision(loadpoint.Decision{}, nil)
+
+	b, err := json.Marshal(lp.GetDecisions()[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"pvRemaining":90,`)
+}
@@ -47,7 +47,12 @@ func (lp *Loadpoint) planRequiredDuration(maxPower float64) time.Duration {
 // - required total charging duration
 // - actual charging plan as rate table
 func (lp *Loadpoint) GetPlan(targetTime time.Time, maxPower float64) (time.Duration, api.Rates, error) {
-	if lp.planner == nil || targetTime.IsZero() {
+	// planner is replaced on configuration reload
+	lp.Lock()
+	p := lp.planner
+	lp.Unlock()
+
+	if p == nil || targetTime.IsZero() {
 		return 0, nil, nil
 	}
 
@@ -57,7 +62,7 @@ func (lp *Loadpoint) GetPlan(targetTime time.Time, maxPower float64) (time.Durat
 	}
 
 	requiredDuration := lp.planRequiredDuration(maxPower)
-	plan, err := lp.planner.Plan(requiredDuration, targetTime)
+	plan, err := p.Plan(requiredDuration, targetTime)
 
 	// sort plan by time
 	slices.SortStableFunc(plan, planner.SortByTime)
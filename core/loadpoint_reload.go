@@ -0,0 +1,77 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/coordinator"
+	"github.com/evcc-io/evcc/core/planner"
+	"golang.org/x/exp/slices"
+)
+
+// sameIdentity validates that the next loadpoint configuration refers to the same devices
+func (lp *Loadpoint) sameIdentity(next *Loadpoint) error {
+	if next.charger != lp.charger {
+		return errors.New("charger changed")
+	}
+
+	if next.MeterRef != lp.MeterRef || next.MeterRef != "" && next.chargeMeter != lp.chargeMeter {
+		return errors.New("charge meter changed")
+	}
+
+	return nil
+}
+
+// reload applies the next loadpoint configuration while keeping runtime state
+func (lp *Loadpoint) reload(next *Loadpoint, c *coordinator.Coordinator, tariff api.Tariff) {
+	lp.Lock()
+	lp.Title_ = next.Title_
+	lp.Priority_ = next.Priority_
+	lp.ConfiguredPhases = next.ConfiguredPhases
+	lp.VehicleRef = next.VehicleRef
+	lp.Soc.Poll = next.Soc.Poll
+	lp.Soc.Estimate = next.Soc.Estimate
+	lp.Enable = next.Enable
+	lp.Disable = next.Disable
+	lp.ResetOnDisconnect = next.ResetOnDisconnect
+	lp.onDisconnect = next.onDisconnect
+	lp.GuardDuration = next.GuardDuration
+	lp.defaultVehicle = next.defaultVehicle
+	lp.coordinator = coordinator.NewAdapter(lp, c)
+	lp.planner = planner.New(lp.log, tariff)
+	lp.Unlock()
+
+	lp.SetMinCurrent(next.MinCurrent)
+	lp.SetMaxCurrent(next.MaxCurrent)
+
+	// keep active vehicle if still configured, otherwise fall back to vehicle of same title or default vehicle.
+	// changed default vehicles take effect on next disconnect.
+	if vehicle := lp.GetVehicle(); vehicle != nil {
+		vehicles := c.GetVehicles()
+
+		if slices.Contains(vehicles, vehicle) {
+			lp.coordinator.Acquire(vehicle)
+		} else {
+			lp.Lock()
+			lp.vehicle = nil // vehicle instance has been replaced
+			lp.Unlock()
+
+			next := lp.defaultVehicle
+			if idx := slices.IndexFunc(vehicles, func(v api.Vehicle) bool {
+				return v.Title() == vehicle.Title()
+			}); idx >= 0 {
+				next = vehicles[idx]
+			}
+
+			lp.setActiveVehicle(next)
+		}
+	}
+
+	// fixed phase chargers follow the configuration
+	if _, ok := lp.charger.(api.PhaseSwitcher); !ok {
+		lp.setPhases(lp.ConfiguredPhases)
+	}
+
+	lp.publish(title, lp.Title())
+	lp.setConfiguredPhases(lp.ConfiguredPhases)
+}
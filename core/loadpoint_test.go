@@ -7,6 +7,7 @@ import (
 	evbus "github.com/asaskevich/EventBus"
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/soc"
 	"github.com/evcc-io/evcc/mock"
 	"github.com/evcc-io/evcc/push"
@@ -784,3 +785,65 @@ func TestMinSoc(t *testing.T) {
 		assert.Equal(t, tc.res, lp.minSocNotReached(), tc)
 	}
 }
+
+func TestRemoteMaxCurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := mock.NewMockCharger(ctrl)
+
+	lp := &Loadpoint{
+		log:         util.NewLogger("foo"),
+		bus:         evbus.New(),
+		clock:       clock.NewMock(),
+		charger:     charger,
+		wakeUpTimer: NewTimer(),
+		MinCurrent:  minA,
+		MaxCurrent:  maxA,
+		enabled:     true,
+	}
+
+	// lowest limit applies
+	lp.SetRemoteMaxCurrent("foo", 10)
+	lp.SetRemoteMaxCurrent("bar", 8)
+
+	charger.EXPECT().MaxCurrent(int64(8)).Return(nil)
+	assert.NoError(t, lp.setLimit(maxA, true))
+
+	// limit below min current disables charger
+	lp.SetRemoteMaxCurrent("bar", 0)
+
+	charger.EXPECT().Enable(false).Return(nil)
+	assert.NoError(t, lp.setLimit(maxA, true))
+	assert.False(t, lp.enabled)
+
+	// removing limits restores max current
+	lp.SetRemoteMaxCurrent("foo", loadpoint.RemoteNoLimit)
+	lp.SetRemoteMaxCurrent("bar", loadpoint.RemoteNoLimit)
+
+	charger.EXPECT().MaxCurrent(int64(maxA)).Return(nil)
+	charger.EXPECT().Enable(true).Return(nil)
+	assert.NoError(t, lp.setLimit(maxA, true))
+	assert.True(t, lp.enabled)
+}
+
+func TestRemoteControl(t *testing.T) {
+	lp := &Loadpoint{
+		log:   util.NewLogger("foo"),
+		bus:   evbus.New(),
+		clock: clock.NewMock(),
+	}
+
+	// most restrictive demand applies
+	lp.RemoteControl("foo", loadpoint.RemoteSoftDisable)
+	lp.RemoteControl("bar", loadpoint.RemoteHardDisable)
+	assert.True(t, lp.remoteControlled(loadpoint.RemoteHardDisable))
+
+	// enabling only removes the source's own demand
+	lp.RemoteControl("bar", loadpoint.RemoteEnable)
+	assert.True(t, lp.remoteControlled(loadpoint.RemoteSoftDisable))
+
+	lp.RemoteControl("bar", loadpoint.RemoteEnable)
+	assert.True(t, lp.remoteControlled(loadpoint.RemoteSoftDisable))
+
+	lp.RemoteControl("foo", loadpoint.RemoteEnable)
+	assert.True(t, lp.remoteControlled(loadpoint.RemoteEnable))
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
 	"github.com/jinzhu/copier"
 	"golang.org/x/exp/slices"
@@ -105,6 +106,12 @@ func (t *Planner) Plan(requiredDuration time.Duration, targetTime time.Time) (ap
 
 	rates, err := t.tariff.Rates()
 
+	// outdated rates remain usable until they expire
+	if tariff.Outdated(rates, err) {
+		t.log.DEBUG.Println("planner: using outdated rates")
+		err = nil
+	}
+
 	// treat like normal target charging if we don't have rates
 	if len(rates) == 0 || err != nil {
 		return simplePlan, err
@@ -235,3 +235,29 @@ func TestChargeAfterTargetTime(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, !SlotAt(clock.Now(), plan).IsEmpty(), "should not start past target time")
 }
+
+func TestOutdatedRates(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	trf := mock.NewMockTariff(ctrl)
+	trf.EXPECT().Rates().Return(rates([]float64{80, 10, 80, 80}, clock.Now(), time.Hour), api.ErrOutdated)
+
+	p := &Planner{
+		log:    util.NewLogger("foo"),
+		clock:  clock,
+		tariff: trf,
+	}
+
+	// outdated rates are used for planning
+	plan, err := p.Plan(time.Hour, clock.Now().Add(4*time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, SlotAt(clock.Now(), plan).IsEmpty(), "should not start at expensive slot")
+	assert.False(t, SlotAt(clock.Now().Add(time.Hour), plan).IsEmpty(), "should start at cheapest slot")
+
+	// no rates at all
+	trf.EXPECT().Rates().Return(nil, api.ErrOutdated)
+
+	_, err = p.Plan(time.Hour, clock.Now().Add(4*time.Hour))
+	assert.ErrorIs(t, err, api.ErrOutdated)
+}
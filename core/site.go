@@ -21,6 +21,7 @@ import (
 	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/telemetry"
+	"github.com/evcc-io/evcc/vehicle/wrapper"
 )
 
 const standbyPower = 10 // consider less than 10W as charger in standby
@@ -47,6 +48,8 @@ type batteryMeasurement struct {
 type Site struct {
 	uiChan       chan<- util.Param // client push messages
 	lpUpdateChan chan *Loadpoint
+	reloadChan   chan func()   // configuration reload requests
+	runC         chan struct{} // closed when the control loop stops, nil before it runs
 
 	*Health
 
@@ -54,15 +57,16 @@ type Site struct {
 	log *util.Logger
 
 	// configuration
-	Title                             string       `mapstructure:"title"`         // UI title
-	Voltage                           float64      `mapstructure:"voltage"`       // Operating voltage. 230V for Germany.
-	ResidualPower                     float64      `mapstructure:"residualPower"` // PV meter only: household usage. Grid meter: household safety margin
-	Meters                            MetersConfig // Meter references
-	PrioritySoc                       float64      `mapstructure:"prioritySoc"`                       // prefer battery up to this Soc
-	BufferSoc                         float64      `mapstructure:"bufferSoc"`                         // continue charging on battery above this Soc
-	BufferStartSoc                    float64      `mapstructure:"bufferStartSoc"`                    // start charging on battery above this Soc
-	MaxGridSupplyWhileBatteryCharging float64      `mapstructure:"maxGridSupplyWhileBatteryCharging"` // ignore battery charging if AC consumption is above this value
-	SmartCostLimit                    float64      `mapstructure:"smartCostLimit"`                    // always charge if cost is below this value
+	Title                             string             `mapstructure:"title"`         // UI title
+	Voltage                           float64            `mapstructure:"voltage"`       // Operating voltage. 230V for Germany.
+	ResidualPower                     float64            `mapstructure:"residualPower"` // PV meter only: household usage. Grid meter: household safety margin
+	Meters                            MetersConfig       // Meter references
+	PrioritySoc                       float64            `mapstructure:"prioritySoc"`                       // prefer battery up to this Soc
+	BufferSoc                         float64            `mapstructure:"bufferSoc"`                         // continue charging on battery above this Soc
+	BufferStartSoc                    float64            `mapstructure:"bufferStartSoc"`                    // start charging on battery above this Soc
+	MaxGridSupplyWhileBatteryCharging float64            `mapstructure:"maxGridSupplyWhileBatteryCharging"` // ignore battery charging if AC consumption is above this value
+	SmartCostLimit                    float64            `mapstructure:"smartCostLimit"`                    // always charge if cost is below this value
+	DeviceHealth                      DeviceHealthConfig `mapstructure:"deviceHealth"`                      // device error notification thresholds
 
 	// meters
 	gridMeter     api.Meter   // Grid usage meter
@@ -75,6 +79,7 @@ type Site struct {
 	coordinator *coordinator.Coordinator // Vehicles
 	prioritizer *prioritizer.Prioritizer // Power budgets
 	savings     *Savings                 // Savings
+	health      *deviceHealth            // Device failure notifications
 
 	// cached state
 	gridPower    float64 // Grid power
@@ -82,6 +87,8 @@ type Site struct {
 	batteryPower float64 // Battery charge power
 	batterySoc   float64 // Battery soc
 
+	tariffOutdated bool // outdated planner rates have been reported
+
 	publishCache map[string]any // store last published values to avoid unnecessary republishing
 }
 
@@ -143,11 +150,28 @@ func NewSiteFromConfig(
 		}
 	}
 
+	if err := site.configureMeters(cp); err != nil {
+		return nil, err
+	}
+
+	if site.BufferStartSoc != 0 && site.BufferStartSoc <= site.BufferSoc {
+		site.log.WARN.Println("bufferStartSoc must be larger than bufferSoc")
+	}
+
+	if site.BufferSoc != 0 && site.BufferSoc <= site.PrioritySoc {
+		site.log.WARN.Println("bufferSoc must be larger than prioritySoc")
+	}
+
+	return site, nil
+}
+
+// configureMeters resolves the site's meter references
+func (site *Site) configureMeters(cp configProvider) error {
 	// grid meter
 	if site.Meters.GridMeterRef != "" {
 		var err error
 		if site.gridMeter, err = cp.Meter(site.Meters.GridMeterRef); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
@@ -155,7 +179,7 @@ func NewSiteFromConfig(
 	for _, ref := range append(site.Meters.PVMetersRef, site.Meters.PVMetersRef_...) {
 		pv, err := cp.Meter(ref)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		site.pvMeters = append(site.pvMeters, pv)
 	}
@@ -169,7 +193,7 @@ func NewSiteFromConfig(
 	for _, ref := range append(site.Meters.BatteryMetersRef, site.Meters.BatteryMetersRef_...) {
 		battery, err := cp.Meter(ref)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		site.batteryMeters = append(site.batteryMeters, battery)
 	}
@@ -187,25 +211,17 @@ func NewSiteFromConfig(
 	for _, ref := range site.Meters.AuxMetersRef {
 		meter, err := cp.Meter(ref)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		site.auxMeters = append(site.auxMeters, meter)
 	}
 
 	// configure meter from references
 	if site.gridMeter == nil && len(site.pvMeters) == 0 {
-		return nil, errors.New("missing either grid or pv meter")
-	}
-
-	if site.BufferStartSoc != 0 && site.BufferStartSoc <= site.BufferSoc {
-		site.log.WARN.Println("bufferStartSoc must be larger than bufferSoc")
-	}
-
-	if site.BufferSoc != 0 && site.BufferSoc <= site.PrioritySoc {
-		site.log.WARN.Println("bufferSoc must be larger than prioritySoc")
+		return errors.New("missing either grid or pv meter")
 	}
 
-	return site, nil
+	return nil
 }
 
 // NewSite creates a Site with sane defaults
@@ -213,7 +229,11 @@ func NewSite() *Site {
 	lp := &Site{
 		log:          util.NewLogger("site"),
 		publishCache: make(map[string]any),
+		reloadChan:   make(chan func()),
 		Voltage:      230, // V
+		DeviceHealth: DeviceHealthConfig{
+			Errors: 3,
+		},
 	}
 
 	return lp
@@ -381,6 +401,7 @@ func (site *Site) updateMeters() error {
 		}
 
 		err := retry.Do(site.updateMeter(meter, power), retryOptions...)
+		site.health.Update(name+" meter", err)
 
 		if err == nil {
 			site.log.DEBUG.Printf("%s power: %.0fW", name, *power)
@@ -400,6 +421,7 @@ func (site *Site) updateMeters() error {
 		for i, meter := range site.pvMeters {
 			var power float64
 			err := retry.Do(site.updateMeter(meter, &power), retryOptions...)
+			site.health.Update(fmt.Sprintf("pv %d meter", i+1), err)
 
 			mm[i] = meterMeasurement{Power: power}
 
@@ -433,6 +455,7 @@ func (site *Site) updateMeters() error {
 
 			// NOTE battery errors are logged but ignored as we don't consider them relevant
 			err := retry.Do(site.updateMeter(meter, &power), retryOptions...)
+			site.health.Update(fmt.Sprintf("battery %d meter", i+1), err)
 
 			if err == nil {
 				site.batteryPower += power
@@ -668,6 +691,20 @@ func (s *Site) publishTariffs() {
 	if co2 := s.effectiveCo2(greenShare); co2 != nil {
 		s.publish("tariffEffectiveCo2", co2)
 	}
+
+	// last successful rates update for detecting stale tariffs
+	for key, tariff := range map[string]api.Tariff{
+		"tariffGridUpdated":    s.tariffs.Grid,
+		"tariffFeedInUpdated":  s.tariffs.FeedIn,
+		"tariffCo2Updated":     s.tariffs.Co2,
+		"tariffPlannerUpdated": s.tariffs.Planner,
+	} {
+		if tu, ok := tariff.(api.TariffUpdated); ok {
+			if ts := tu.Updated(); !ts.IsZero() {
+				s.publishDelta(key, ts)
+			}
+		}
+	}
 }
 
 func (site *Site) update(lp Updater) {
@@ -688,22 +725,7 @@ func (site *Site) update(lp Updater) {
 		flexiblePower = site.prioritizer.GetChargePowerFlexibility(lp)
 	}
 
-	var autoCharge bool
-	if tariff := site.GetTariff(PlannerTariff); tariff != nil {
-		rates, err := tariff.Rates()
-
-		var rate api.Rate
-		if err == nil {
-			rate, err = rates.Current(time.Now())
-		}
-
-		if err == nil {
-			limit := site.GetSmartCostLimit()
-			autoCharge = limit != 0 && rate.Price <= limit
-		} else {
-			site.log.ERROR.Println("tariff:", err)
-		}
-	}
+	autoCharge := site.smartCostActive()
 
 	if sitePower, batteryBuffered, batteryStart, err := site.sitePower(totalChargePower, flexiblePower); err == nil {
 		greenShare := site.greenShare()
@@ -727,6 +749,41 @@ func (site *Site) update(lp Updater) {
 	}
 }
 
+// smartCostActive returns true if the current planner rate is below the smart cost limit and records the tariff's health
+func (site *Site) smartCostActive() bool {
+	tf := site.GetTariff(PlannerTariff)
+	if tf == nil {
+		return false
+	}
+
+	var res bool
+	rates, err := tf.Rates()
+
+	// outdated rates are reported but remain usable for the decision
+	outdated := tariff.Outdated(rates, err)
+	if outdated && !site.tariffOutdated {
+		site.log.WARN.Println("tariff: using outdated rates")
+	}
+	site.tariffOutdated = outdated
+
+	if err == nil || outdated {
+		if rate, rerr := rates.Current(time.Now()); rerr == nil {
+			limit := site.GetSmartCostLimit()
+			res = limit != 0 && rate.Price <= limit
+		} else {
+			err = rerr
+		}
+	}
+	site.health.Update("tariff", err)
+
+	// usable outdated rates have been warned about
+	if err != nil && !(outdated && errors.Is(err, api.ErrOutdated)) {
+		site.log.ERROR.Println("tariff:", err)
+	}
+
+	return res
+}
+
 // prepare publishes initial values
 func (site *Site) prepare() {
 	site.publish("siteTitle", site.Title)
@@ -756,6 +813,8 @@ func (site *Site) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Event)
 
 	site.prepare()
 
+	site.health = newDeviceHealth(site.DeviceHealth, pushChan)
+
 	for id, lp := range site.loadpoints {
 		lpUIChan := make(chan util.Param)
 		lpPushChan := make(chan push.Event)
@@ -775,6 +834,24 @@ func (site *Site) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Event)
 		}(id)
 
 		lp.Prepare(lpUIChan, lpPushChan, site.lpUpdateChan)
+		lp.health = newDeviceHealth(site.DeviceHealth, lpPushChan)
+	}
+
+	// offline vehicles are reported once
+	go site.reportOffline(site.GetVehicles())
+}
+
+// reportOffline reports vehicles that failed to initialize. Vehicles configured
+// with the offline feature, e.g. the offline template, are not failing.
+func (site *Site) reportOffline(vehicles []api.Vehicle) {
+	for _, v := range vehicles {
+		if _, ok := v.(*wrapper.Wrapper); !ok {
+			continue
+		}
+
+		if _, err := v.Soc(); err != nil {
+			site.health.Offline("vehicle "+v.Title(), err)
+		}
 	}
 }
 
@@ -792,6 +869,13 @@ func (site *Site) loopLoadpoints(next chan<- Updater) {
 func (site *Site) Run(stopC chan struct{}, interval time.Duration) {
 	site.Health = NewHealth(time.Minute + interval)
 
+	runC := make(chan struct{})
+	defer close(runC)
+
+	site.Lock()
+	site.runC = runC
+	site.Unlock()
+
 	loadpointChan := make(chan Updater)
 	go site.loopLoadpoints(loadpointChan)
 
@@ -804,6 +888,8 @@ func (site *Site) Run(stopC chan struct{}, interval time.Duration) {
 			site.update(<-loadpointChan)
 		case lp := <-site.lpUpdateChan:
 			site.update(lp)
+		case fun := <-site.reloadChan:
+			fun()
 		case <-stopC:
 			return
 		}
@@ -5,6 +5,8 @@ import (
 	"github.com/evcc-io/evcc/core/loadpoint"
 )
 
+//go:generate mockgen -package site -destination mock.go -mock_names API=MockAPI github.com/evcc-io/evcc/core/site API
+
 // API is the external site API
 type API interface {
 	Healthy() bool
@@ -0,0 +1,232 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/evcc-io/evcc/core/site (interfaces: API)
+
+// Package site is a generated GoMock package.
+package site
+
+import (
+	reflect "reflect"
+
+	api "github.com/evcc-io/evcc/api"
+	loadpoint "github.com/evcc-io/evcc/core/loadpoint"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAPI is a mock of API interface.
+type MockAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIMockRecorder
+}
+
+// MockAPIMockRecorder is the mock recorder for MockAPI.
+type MockAPIMockRecorder struct {
+	mock *MockAPI
+}
+
+// NewMockAPI creates a new mock instance.
+func NewMockAPI(ctrl *gomock.Controller) *MockAPI {
+	mock := &MockAPI{ctrl: ctrl}
+	mock.recorder = &MockAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
+	return m.recorder
+}
+
+// GetBufferSoc mocks base method.
+func (m *MockAPI) GetBufferSoc() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBufferSoc")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetBufferSoc indicates an expected call of GetBufferSoc.
+func (mr *MockAPIMockRecorder) GetBufferSoc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBufferSoc", reflect.TypeOf((*MockAPI)(nil).GetBufferSoc))
+}
+
+// GetBufferStartSoc mocks base method.
+func (m *MockAPI) GetBufferStartSoc() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBufferStartSoc")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetBufferStartSoc indicates an expected call of GetBufferStartSoc.
+func (mr *MockAPIMockRecorder) GetBufferStartSoc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBufferStartSoc", reflect.TypeOf((*MockAPI)(nil).GetBufferStartSoc))
+}
+
+// GetPrioritySoc mocks base method.
+func (m *MockAPI) GetPrioritySoc() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrioritySoc")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetPrioritySoc indicates an expected call of GetPrioritySoc.
+func (mr *MockAPIMockRecorder) GetPrioritySoc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrioritySoc", reflect.TypeOf((*MockAPI)(nil).GetPrioritySoc))
+}
+
+// GetResidualPower mocks base method.
+func (m *MockAPI) GetResidualPower() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResidualPower")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetResidualPower indicates an expected call of GetResidualPower.
+func (mr *MockAPIMockRecorder) GetResidualPower() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResidualPower", reflect.TypeOf((*MockAPI)(nil).GetResidualPower))
+}
+
+// GetSmartCostLimit mocks base method.
+func (m *MockAPI) GetSmartCostLimit() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSmartCostLimit")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetSmartCostLimit indicates an expected call of GetSmartCostLimit.
+func (mr *MockAPIMockRecorder) GetSmartCostLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartCostLimit", reflect.TypeOf((*MockAPI)(nil).GetSmartCostLimit))
+}
+
+// GetTariff mocks base method.
+func (m *MockAPI) GetTariff(arg0 string) api.Tariff {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTariff", arg0)
+	ret0, _ := ret[0].(api.Tariff)
+	return ret0
+}
+
+// GetTariff indicates an expected call of GetTariff.
+func (mr *MockAPIMockRecorder) GetTariff(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTariff", reflect.TypeOf((*MockAPI)(nil).GetTariff), arg0)
+}
+
+// GetVehicles mocks base method.
+func (m *MockAPI) GetVehicles() []api.Vehicle {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVehicles")
+	ret0, _ := ret[0].([]api.Vehicle)
+	return ret0
+}
+
+// GetVehicles indicates an expected call of GetVehicles.
+func (mr *MockAPIMockRecorder) GetVehicles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicles", reflect.TypeOf((*MockAPI)(nil).GetVehicles))
+}
+
+// Healthy mocks base method.
+func (m *MockAPI) Healthy() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Healthy")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Healthy indicates an expected call of Healthy.
+func (mr *MockAPIMockRecorder) Healthy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Healthy", reflect.TypeOf((*MockAPI)(nil).Healthy))
+}
+
+// Loadpoints mocks base method.
+func (m *MockAPI) Loadpoints() []loadpoint.API {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Loadpoints")
+	ret0, _ := ret[0].([]loadpoint.API)
+	return ret0
+}
+
+// Loadpoints indicates an expected call of Loadpoints.
+func (mr *MockAPIMockRecorder) Loadpoints() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Loadpoints", reflect.TypeOf((*MockAPI)(nil).Loadpoints))
+}
+
+// SetBufferSoc mocks base method.
+func (m *MockAPI) SetBufferSoc(arg0 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBufferSoc", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBufferSoc indicates an expected call of SetBufferSoc.
+func (mr *MockAPIMockRecorder) SetBufferSoc(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBufferSoc", reflect.TypeOf((*MockAPI)(nil).SetBufferSoc), arg0)
+}
+
+// SetBufferStartSoc mocks base method.
+func (m *MockAPI) SetBufferStartSoc(arg0 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBufferStartSoc", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBufferStartSoc indicates an expected call of SetBufferStartSoc.
+func (mr *MockAPIMockRecorder) SetBufferStartSoc(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBufferStartSoc", reflect.TypeOf((*MockAPI)(nil).SetBufferStartSoc), arg0)
+}
+
+// SetPrioritySoc mocks base method.
+func (m *MockAPI) SetPrioritySoc(arg0 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrioritySoc", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrioritySoc indicates an expected call of SetPrioritySoc.
+func (mr *MockAPIMockRecorder) SetPrioritySoc(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrioritySoc", reflect.TypeOf((*MockAPI)(nil).SetPrioritySoc), arg0)
+}
+
+// SetResidualPower mocks base method.
+func (m *MockAPI) SetResidualPower(arg0 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetResidualPower", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetResidualPower indicates an expected call of SetResidualPower.
+func (mr *MockAPIMockRecorder) SetResidualPower(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetResidualPower", reflect.TypeOf((*MockAPI)(nil).SetResidualPower), arg0)
+}
+
+// SetSmartCostLimit mocks base method.
+func (m *MockAPI) SetSmartCostLimit(arg0 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSmartCostLimit", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSmartCostLimit indicates an expected call of SetSmartCostLimit.
+func (mr *MockAPIMockRecorder) SetSmartCostLimit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSmartCostLimit", reflect.TypeOf((*MockAPI)(nil).SetSmartCostLimit), arg0)
+}
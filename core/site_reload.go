@@ -0,0 +1,115 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/coordinator"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/evcc-io/evcc/util"
+)
+
+var errSiteNotRunning = errors.New("site not running")
+
+// Reload applies a changed configuration to the running site.
+// Loadpoints are matched by position and keep their state including open sessions.
+// A loadpoint's charger and charge meter must be unchanged, otherwise a restart is required.
+// Nil tariffs keep the current tariffs.
+func (site *Site) Reload(
+	cp configProvider,
+	other map[string]interface{},
+	loadpoints []map[string]interface{},
+	vehicles []api.Vehicle,
+	tariffs *tariff.Tariffs,
+) error {
+	next := NewSite()
+	if err := util.DecodeOther(other, next); err != nil {
+		return err
+	}
+
+	if err := next.configureMeters(cp); err != nil {
+		return err
+	}
+
+	if len(loadpoints) != len(site.loadpoints) {
+		return fmt.Errorf("number of loadpoints changed from %d to %d, restart required", len(site.loadpoints), len(loadpoints))
+	}
+
+	// validate all loadpoints before applying anything
+	nextLps := make([]*Loadpoint, 0, len(loadpoints))
+	for id, lp := range site.loadpoints {
+		nextLp, err := NewLoadpointFromConfig(lp.log, cp, loadpoints[id])
+		if err != nil {
+			return fmt.Errorf("loadpoint %d: %w", id+1, err)
+		}
+
+		if err := lp.sameIdentity(nextLp); err != nil {
+			return fmt.Errorf("loadpoint %d: %w, restart required", id+1, err)
+		}
+
+		nextLps = append(nextLps, nextLp)
+	}
+
+	site.Lock()
+	runC := site.runC
+	site.Unlock()
+
+	if runC == nil {
+		return errSiteNotRunning
+	}
+
+	// apply inside the control loop to avoid concurrent loadpoint updates
+	doneC := make(chan struct{})
+	select {
+	case site.reloadChan <- func() {
+		site.reload(next, nextLps, vehicles, tariffs)
+		close(doneC)
+	}:
+	case <-runC:
+		return errSiteNotRunning
+	}
+	<-doneC
+
+	return nil
+}
+
+// reload replaces site configuration and meters and reconfigures all loadpoints
+func (site *Site) reload(next *Site, loadpoints []*Loadpoint, vehicles []api.Vehicle, tariffs *tariff.Tariffs) {
+	site.log.INFO.Println("reloading configuration")
+
+	site.Lock()
+	site.Title = next.Title
+	site.Voltage = next.Voltage
+	Voltage = next.Voltage
+	site.ResidualPower = next.ResidualPower
+	site.Meters = next.Meters
+	site.PrioritySoc = next.PrioritySoc
+	site.BufferSoc = next.BufferSoc
+	site.BufferStartSoc = next.BufferStartSoc
+	site.MaxGridSupplyWhileBatteryCharging = next.MaxGridSupplyWhileBatteryCharging
+	site.SmartCostLimit = next.SmartCostLimit
+
+	site.gridMeter = next.gridMeter
+	site.pvMeters = next.pvMeters
+	site.batteryMeters = next.batteryMeters
+	site.auxMeters = next.auxMeters
+
+	if tariffs != nil {
+		site.tariffs = *tariffs
+		site.savings.tariffs = *tariffs
+	}
+	site.coordinator = coordinator.New(site.log, vehicles)
+	site.Unlock()
+
+	// values changed via api take precedence
+	site.restoreSettings()
+
+	tariff := site.GetTariff(PlannerTariff)
+	for id, lp := range site.loadpoints {
+		lp.reload(loadpoints[id], site.coordinator, tariff)
+	}
+
+	site.publishCache = make(map[string]any)
+	site.prepare()
+}
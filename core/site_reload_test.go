@@ -0,0 +1,155 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfigProvider struct {
+	meters   map[string]api.Meter
+	chargers map[string]api.Charger
+}
+
+func (cp testConfigProvider) Meter(name string) (api.Meter, error) {
+	if m, ok := cp.meters[name]; ok {
+		return m, nil
+	}
+	return nil, fmt.Errorf("meter does not exist: %s", name)
+}
+
+func (cp testConfigProvider) Charger(name string) (api.Charger, error) {
+	if c, ok := cp.chargers[name]; ok {
+		return c, nil
+	}
+	return nil, fmt.Errorf("charger does not exist: %s", name)
+}
+
+func (cp testConfigProvider) Vehicle(name string) (api.Vehicle, error) {
+	return nil, fmt.Errorf("vehicle does not exist: %s", name)
+}
+
+func TestSiteReload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	log := util.NewLogger("foo")
+
+	grid := mock.NewMockMeter(ctrl)
+	cp := testConfigProvider{
+		meters:   map[string]api.Meter{"grid": grid},
+		chargers: map[string]api.Charger{"charger": mock.NewMockCharger(ctrl)},
+	}
+
+	siteConf := map[string]interface{}{
+		"title":  "home",
+		"meters": map[string]interface{}{"grid": "grid"},
+	}
+	lpConf := map[string]interface{}{
+		"title":   "garage",
+		"charger": "charger",
+		"phases":  3,
+	}
+
+	lp, err := NewLoadpointFromConfig(log, cp, lpConf)
+	require.NoError(t, err)
+
+	site, err := NewSiteFromConfig(log, cp, siteConf, []*Loadpoint{lp}, nil, tariff.Tariffs{})
+	require.NoError(t, err)
+
+	// reload requires the control loop
+	assert.ErrorIs(t, site.Reload(cp, siteConf, []map[string]interface{}{lpConf}, nil, nil), errSiteNotRunning)
+
+	// control loop
+	stopC := make(chan struct{})
+	defer close(stopC)
+	site.runC = stopC
+
+	go func() {
+		for {
+			select {
+			case fun := <-site.reloadChan:
+				fun()
+			case <-stopC:
+				return
+			}
+		}
+	}()
+
+	// concurrent api access
+	doneC := make(chan struct{})
+	go func() {
+		defer close(doneC)
+		for i := 0; i < 100; i++ {
+			_, _, _ = lp.GetPlan(time.Now().Add(time.Hour), 11e3)
+		}
+	}()
+
+	planner := lp.planner
+
+	// replaced grid meter and changed loadpoint settings
+	nextGrid := mock.NewMockMeter(ctrl)
+	cp.meters["grid"] = nextGrid
+
+	siteConf["title"] = "new home"
+	lpConf["title"] = "carport"
+	lpConf["maxcurrent"] = 32
+
+	require.NoError(t, site.Reload(cp, siteConf, []map[string]interface{}{lpConf}, nil, nil))
+	<-doneC
+
+	assert.Equal(t, "new home", site.Title)
+	assert.Equal(t, nextGrid, site.gridMeter)
+	assert.Equal(t, "carport", lp.Title())
+	assert.Equal(t, 32.0, lp.GetMaxCurrent())
+
+	lp.Lock()
+	assert.NotSame(t, planner, lp.planner)
+	lp.Unlock()
+
+	// loadpoint devices cannot be replaced
+	cp.chargers["charger"] = mock.NewMockCharger(ctrl)
+	assert.Error(t, site.Reload(cp, siteConf, []map[string]interface{}{lpConf}, nil, nil))
+	cp.chargers["charger"] = lp.charger
+
+	// number of loadpoints cannot change
+	assert.Error(t, site.Reload(cp, siteConf, nil, nil, nil))
+}
+
+func TestSiteReloadStopped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	log := util.NewLogger("foo")
+
+	cp := testConfigProvider{
+		meters: map[string]api.Meter{"grid": mock.NewMockMeter(ctrl)},
+	}
+
+	siteConf := map[string]interface{}{
+		"meters": map[string]interface{}{"grid": "grid"},
+	}
+
+	site, err := NewSiteFromConfig(log, cp, siteConf, nil, nil, tariff.Tariffs{})
+	require.NoError(t, err)
+
+	// control loop has stopped
+	site.runC = make(chan struct{})
+	close(site.runC)
+
+	errC := make(chan error)
+	go func() {
+		errC <- site.Reload(cp, siteConf, nil, nil, nil)
+	}()
+
+	select {
+	case err := <-errC:
+		assert.ErrorIs(t, err, errSiteNotRunning)
+	case <-time.After(time.Second):
+		t.Fatal("reload blocked")
+	}
+}
@@ -2,8 +2,15 @@ package core
 
 import (
 	"testing"
+	"time"
 
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestSitePower(t *testing.T) {
@@ -75,3 +82,57 @@ func TestGreenShare(t *testing.T) {
 		}
 	}
 }
+
+func TestSmartCostOutdatedRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tf := mock.NewMockTariff(ctrl)
+
+	pushChan := make(chan push.Event, 1)
+
+	site := &Site{
+		log:            util.NewLogger("foo"),
+		health:         newDeviceHealth(DeviceHealthConfig{}, pushChan),
+		tariffs:        tariff.Tariffs{Planner: tf},
+		SmartCostLimit: 0.2,
+	}
+
+	now := time.Now()
+	rates := api.Rates{{Start: now.Add(-time.Hour), End: now.Add(time.Hour), Price: 0.1}}
+
+	// outdated rates remain usable for the decision but are reported
+	tf.EXPECT().Rates().Return(rates, api.ErrOutdated).Times(2)
+
+	assert.True(t, site.smartCostActive())
+	assert.True(t, site.tariffOutdated)
+
+	ev := <-pushChan
+	assert.Equal(t, evDeviceError, ev.Event)
+	assert.Equal(t, "outdated", ev.Attributes["reason"])
+
+	assert.True(t, site.smartCostActive())
+
+	select {
+	case ev := <-pushChan:
+		t.Fatalf("unexpected event: %v", ev)
+	default:
+	}
+
+	// current rates recover the tariff
+	tf.EXPECT().Rates().Return(rates, nil)
+
+	assert.True(t, site.smartCostActive())
+	assert.False(t, site.tariffOutdated)
+
+	ev = <-pushChan
+	assert.Equal(t, evDeviceRecovered, ev.Event)
+
+	// outdated without rates fails
+	tf.EXPECT().Rates().Return(nil, api.ErrOutdated)
+
+	assert.False(t, site.smartCostActive())
+	assert.False(t, site.tariffOutdated)
+
+	ev = <-pushChan
+	assert.Equal(t, evDeviceError, ev.Event)
+	assert.Equal(t, "outdated", ev.Attributes["reason"])
+}
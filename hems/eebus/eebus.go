@@ -0,0 +1,428 @@
+package eebus
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/enbility/eebus-go/features"
+	"github.com/enbility/eebus-go/spine"
+	"github.com/enbility/eebus-go/spine/model"
+	eebusutil "github.com/enbility/eebus-go/util"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/charger/eebus"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+)
+
+// limitation of power consumption data model, not yet defined by eebus-go
+const (
+	keyFailsafeConsumptionActivePowerLimit model.DeviceConfigurationKeyNameType = "failsafeConsumptionActivePowerLimit"
+	keyFailsafeDurationMinimum             model.DeviceConfigurationKeyNameType = "failsafeDurationMinimum"
+
+	limitTypeSignDependentAbsValue model.LoadControlLimitTypeType = "signDependentAbsValue"
+	scopeTypeActivePowerLimit      model.ScopeTypeType            = "activePowerLimit"
+)
+
+// local data ids
+const (
+	limitId          model.LoadControlLimitIdType       = 0
+	measurementId    model.MeasurementIdType            = 0
+	failsafeLimitKey model.DeviceConfigurationKeyIdType = 0
+	failsafeDurKey   model.DeviceConfigurationKeyIdType = 1
+)
+
+// voltage is used for converting power limits to current limits
+const voltage = 230
+
+// source identifies the eebus limit at the loadpoints
+const source = "eebus"
+
+// EEBus implements the controllable system of the limitation of power consumption (LPC, §14a EnWG) use case
+type EEBus struct {
+	mu   sync.Mutex
+	log  *util.Logger
+	site site.API
+	ski  string
+	lpc  *lpc
+
+	loadControl  *writeFeature
+	deviceConfig *writeFeature
+	measurement  spine.FeatureLocal
+
+	interval time.Duration
+	state    lpcState
+}
+
+// New creates an EEBus HEMS from generic config
+func New(other map[string]interface{}, site site.API) (*EEBus, error) {
+	cc := struct {
+		Ski              string
+		Ip               string
+		FailsafeLimit    float64
+		FailsafeDuration time.Duration
+		HeartbeatTimeout time.Duration
+		Interval         time.Duration
+	}{
+		FailsafeLimit:    4200,
+		FailsafeDuration: 2 * time.Hour,
+		HeartbeatTimeout: 120 * time.Second,
+		Interval:         10 * time.Second,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if eebus.Instance == nil {
+		return nil, errors.New("eebus not configured")
+	}
+
+	if cc.Ski == "" {
+		return nil, errors.New("missing ski")
+	}
+
+	c := &EEBus{
+		log:      util.NewLogger("eebus"),
+		site:     site,
+		ski:      eebusutil.NormalizeSKI(cc.Ski),
+		lpc:      newLPC(clock.New(), cc.HeartbeatTimeout, cc.FailsafeLimit, cc.FailsafeDuration),
+		interval: cc.Interval,
+	}
+
+	c.addFeatures()
+	c.addUseCases()
+
+	spine.Events.Subscribe(c)
+
+	eebus.Instance.RegisterDevice(cc.Ski, cc.Ip, c.onConnect, c.onDisconnect)
+
+	return c, nil
+}
+
+func (c *EEBus) onConnect(ski string) {
+	c.log.DEBUG.Println("energy guard connected:", ski)
+}
+
+func (c *EEBus) onDisconnect(ski string) {
+	c.log.DEBUG.Println("energy guard disconnected:", ski)
+}
+
+// addFeatures adds the controllable system's server features to the local entity
+func (c *EEBus) addFeatures() {
+	service := eebus.Instance.Service()
+	entity := service.LocalEntity()
+
+	// consumption limit
+	c.loadControl = newWriteFeature(entity, model.FeatureTypeTypeLoadControl, c.writeLoadControl)
+	c.loadControl.AddFunctionType(model.FunctionTypeLoadControlLimitDescriptionListData, true, false)
+	c.loadControl.AddFunctionType(model.FunctionTypeLoadControlLimitListData, true, true)
+
+	c.loadControl.SetData(model.FunctionTypeLoadControlLimitDescriptionListData, &model.LoadControlLimitDescriptionListDataType{
+		LoadControlLimitDescriptionData: []model.LoadControlLimitDescriptionDataType{{
+			LimitId:        eebusutil.Ptr(limitId),
+			LimitType:      eebusutil.Ptr(limitTypeSignDependentAbsValue),
+			LimitCategory:  eebusutil.Ptr(model.LoadControlCategoryTypeObligation),
+			LimitDirection: eebusutil.Ptr(model.EnergyDirectionTypeConsume),
+			MeasurementId:  eebusutil.Ptr(measurementId),
+			Unit:           eebusutil.Ptr(model.UnitOfMeasurementTypeW),
+			ScopeType:      eebusutil.Ptr(scopeTypeActivePowerLimit),
+		}},
+	})
+	c.loadControl.SetData(model.FunctionTypeLoadControlLimitListData, c.limitData(false, 0, 0))
+
+	// failsafe values
+	c.deviceConfig = newWriteFeature(entity, model.FeatureTypeTypeDeviceConfiguration, c.writeDeviceConfiguration)
+	c.deviceConfig.AddFunctionType(model.FunctionTypeDeviceConfigurationKeyValueDescriptionListData, true, false)
+	c.deviceConfig.AddFunctionType(model.FunctionTypeDeviceConfigurationKeyValueListData, true, true)
+
+	c.deviceConfig.SetData(model.FunctionTypeDeviceConfigurationKeyValueDescriptionListData, &model.DeviceConfigurationKeyValueDescriptionListDataType{
+		DeviceConfigurationKeyValueDescriptionData: []model.DeviceConfigurationKeyValueDescriptionDataType{
+			{
+				KeyId:     eebusutil.Ptr(failsafeLimitKey),
+				KeyName:   eebusutil.Ptr(keyFailsafeConsumptionActivePowerLimit),
+				ValueType: eebusutil.Ptr(model.DeviceConfigurationKeyValueTypeTypeScaledNumber),
+				Unit:      eebusutil.Ptr(model.UnitOfMeasurementTypeW),
+			},
+			{
+				KeyId:     eebusutil.Ptr(failsafeDurKey),
+				KeyName:   eebusutil.Ptr(keyFailsafeDurationMinimum),
+				ValueType: eebusutil.Ptr(model.DeviceConfigurationKeyValueTypeTypeDuration),
+			},
+		},
+	})
+	c.publishFailsafe()
+
+	// consumption measurement
+	c.measurement = entity.GetOrAddFeature(model.FeatureTypeTypeMeasurement, model.RoleTypeServer)
+	c.measurement.AddFunctionType(model.FunctionTypeMeasurementDescriptionListData, true, false)
+	c.measurement.AddFunctionType(model.FunctionTypeMeasurementListData, true, false)
+
+	c.measurement.SetData(model.FunctionTypeMeasurementDescriptionListData, &model.MeasurementDescriptionListDataType{
+		MeasurementDescriptionData: []model.MeasurementDescriptionDataType{{
+			MeasurementId:   eebusutil.Ptr(measurementId),
+			MeasurementType: eebusutil.Ptr(model.MeasurementTypeTypePower),
+			CommodityType:   eebusutil.Ptr(model.CommodityTypeTypeElectricity),
+			Unit:            eebusutil.Ptr(model.UnitOfMeasurementTypeW),
+			ScopeType:       eebusutil.Ptr(model.ScopeTypeTypeACPowerTotal),
+		}},
+	})
+
+	// energy guard heartbeat
+	entity.GetOrAddFeature(model.FeatureTypeTypeDeviceDiagnosis, model.RoleTypeClient)
+}
+
+// addUseCases announces the supported use cases. The use cases are added to the use case
+// manager directly since spine does not know the controllable system actor yet.
+func (c *EEBus) addUseCases() {
+	ucManager := eebus.Instance.Service().LocalDevice().UseCaseManager()
+
+	ucManager.Add(model.UseCaseActorTypeControllableSystem, model.UseCaseNameTypeLimitationOfPowerConsumption,
+		model.SpecificationVersionType("1.0.0"), []model.UseCaseScenarioSupportType{1, 2, 3, 4})
+	ucManager.Add(model.UseCaseActorTypeMonitoredUnit, model.UseCaseNameTypeMonitoringOfPowerConsumption,
+		model.SpecificationVersionType("1.0.0"), []model.UseCaseScenarioSupportType{1})
+}
+
+func (c *EEBus) limitData(active bool, limit float64, duration time.Duration) *model.LoadControlLimitListDataType {
+	data := model.LoadControlLimitDataType{
+		LimitId:           eebusutil.Ptr(limitId),
+		IsLimitChangeable: eebusutil.Ptr(true),
+		IsLimitActive:     eebusutil.Ptr(active),
+		Value:             model.NewScaledNumberType(limit),
+	}
+
+	if duration > 0 {
+		data.TimePeriod = &model.TimePeriodType{
+			EndTime: model.NewAbsoluteOrRelativeTimeTypeFromDuration(duration),
+		}
+	}
+
+	return &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{data},
+	}
+}
+
+func (c *EEBus) publishFailsafe() {
+	limit, duration := c.lpc.Failsafe()
+
+	c.deviceConfig.SetData(model.FunctionTypeDeviceConfigurationKeyValueListData, &model.DeviceConfigurationKeyValueListDataType{
+		DeviceConfigurationKeyValueData: []model.DeviceConfigurationKeyValueDataType{
+			{
+				KeyId:             eebusutil.Ptr(failsafeLimitKey),
+				Value:             &model.DeviceConfigurationKeyValueValueType{ScaledNumber: model.NewScaledNumberType(limit)},
+				IsValueChangeable: eebusutil.Ptr(true),
+			},
+			{
+				KeyId:             eebusutil.Ptr(failsafeDurKey),
+				Value:             &model.DeviceConfigurationKeyValueValueType{Duration: model.NewDurationType(duration)},
+				IsValueChangeable: eebusutil.Ptr(true),
+			},
+		},
+	})
+}
+
+// writeLoadControl handles consumption limit writes by the energy guard
+func (c *EEBus) writeLoadControl(function model.FunctionType, data any) *spine.ErrorType {
+	list, ok := data.(*model.LoadControlLimitListDataType)
+	if !ok || function != model.FunctionTypeLoadControlLimitListData {
+		return spine.NewErrorTypeFromNumber(model.ErrorNumberTypeCommandRejected)
+	}
+
+	for _, item := range list.LoadControlLimitData {
+		if item.LimitId != nil && *item.LimitId != limitId {
+			continue
+		}
+
+		if item.Value == nil || item.Value.GetValue() < 0 {
+			return spine.NewErrorType(model.ErrorNumberTypeCommandRejected, "invalid limit")
+		}
+
+		active := item.IsLimitActive != nil && *item.IsLimitActive
+		limit := item.Value.GetValue()
+
+		var duration time.Duration
+		if item.TimePeriod != nil && item.TimePeriod.EndTime != nil {
+			d, err := item.TimePeriod.EndTime.GetTimeDuration()
+			if err != nil {
+				return spine.NewErrorType(model.ErrorNumberTypeCommandRejected, "invalid duration")
+			}
+			duration = d
+		}
+
+		c.log.INFO.Printf("consumption limit: %.0fW active: %t duration: %v", limit, active, duration)
+
+		c.lpc.SetLimit(active, limit, duration)
+		c.loadControl.SetData(model.FunctionTypeLoadControlLimitListData, c.limitData(active, limit, duration))
+	}
+
+	go c.update()
+
+	return nil
+}
+
+// writeDeviceConfiguration handles failsafe value writes by the energy guard
+func (c *EEBus) writeDeviceConfiguration(function model.FunctionType, data any) *spine.ErrorType {
+	list, ok := data.(*model.DeviceConfigurationKeyValueListDataType)
+	if !ok || function != model.FunctionTypeDeviceConfigurationKeyValueListData {
+		return spine.NewErrorTypeFromNumber(model.ErrorNumberTypeCommandRejected)
+	}
+
+	limit, duration := c.lpc.Failsafe()
+
+	for _, item := range list.DeviceConfigurationKeyValueData {
+		if item.KeyId == nil || item.Value == nil {
+			continue
+		}
+
+		switch *item.KeyId {
+		case failsafeLimitKey:
+			if item.Value.ScaledNumber == nil || item.Value.ScaledNumber.GetValue() < 0 {
+				return spine.NewErrorType(model.ErrorNumberTypeCommandRejected, "invalid failsafe limit")
+			}
+			limit = item.Value.ScaledNumber.GetValue()
+
+		case failsafeDurKey:
+			if item.Value.Duration == nil {
+				return spine.NewErrorType(model.ErrorNumberTypeCommandRejected, "invalid failsafe duration")
+			}
+			d, err := item.Value.Duration.GetTimeDuration()
+			if err != nil {
+				return spine.NewErrorType(model.ErrorNumberTypeCommandRejected, "invalid failsafe duration")
+			}
+			duration = d
+		}
+	}
+
+	c.log.INFO.Printf("failsafe limit: %.0fW duration: %v", limit, duration)
+
+	c.lpc.SetFailsafe(limit, duration)
+	c.publishFailsafe()
+
+	return nil
+}
+
+// HandleEvent subscribes to the energy guard's heartbeat and records received heartbeats
+func (c *EEBus) HandleEvent(payload spine.EventPayload) {
+	if eebusutil.NormalizeSKI(payload.Ski) != c.ski {
+		return
+	}
+
+	switch payload.EventType {
+	case spine.EventTypeEntityChange:
+		if payload.ChangeType != spine.ElementChangeAdd || payload.Entity == nil {
+			return
+		}
+
+		dd, err := features.NewDeviceDiagnosis(model.RoleTypeClient, model.RoleTypeServer, eebus.Instance.Service().LocalDevice(), payload.Entity)
+		if err != nil {
+			return
+		}
+
+		if err := dd.SubscribeForEntity(); err != nil {
+			c.log.ERROR.Println("heartbeat subscription:", err)
+		}
+
+	case spine.EventTypeDataChange:
+		if _, ok := payload.Data.(*model.DeviceDiagnosisHeartbeatDataType); ok {
+			c.lpc.Heartbeat()
+		}
+	}
+}
+
+// distribute shares the consumption limit between loadpoints with connected vehicles or all loadpoints.
+// Loadpoints are served in order of priority as long as the limit allows charging at their minimum current,
+// the remaining power is shared evenly. Loadpoints that cannot be served are limited to 0A.
+func distribute(limit float64, loadpoints []loadpoint.API) map[int]float64 {
+	var active []int
+	for id, lp := range loadpoints {
+		if status := lp.GetStatus(); status == api.StatusB || status == api.StatusC {
+			active = append(active, id)
+		}
+	}
+
+	if len(active) == 0 {
+		for id := range loadpoints {
+			active = append(active, id)
+		}
+	}
+
+	sort.SliceStable(active, func(i, j int) bool {
+		return loadpoints[active[i]].Priority() > loadpoints[active[j]].Priority()
+	})
+
+	res := make(map[int]float64, len(loadpoints))
+	for id := range loadpoints {
+		res[id] = 0
+	}
+
+	phases := func(id int) float64 {
+		if phases := loadpoints[id].GetPhases(); phases > 0 {
+			return float64(phases)
+		}
+		return 3
+	}
+
+	// serve loadpoints at minimum current
+	var served []int
+	remaining := limit
+	for _, id := range active {
+		if minPower := loadpoints[id].GetMinCurrent() * voltage * phases(id); minPower <= remaining {
+			remaining -= minPower
+			served = append(served, id)
+		}
+	}
+
+	for _, id := range served {
+		extra := remaining / float64(len(served)) / (voltage * phases(id))
+		res[id] = math.Floor(10*(loadpoints[id].GetMinCurrent()+extra)) / 10
+	}
+
+	return res
+}
+
+// update applies the effective limit to the loadpoints and reports the consumption
+func (c *EEBus) update() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state, limit, limited := c.lpc.Update()
+	if state != c.state {
+		c.log.WARN.Printf("state: %s", state)
+		c.state = state
+	}
+
+	loadpoints := c.site.Loadpoints()
+
+	currents := distribute(limit, loadpoints)
+
+	var power float64
+	for id, lp := range loadpoints {
+		power += lp.GetChargePower()
+
+		current := loadpoint.RemoteNoLimit
+		if limited {
+			current = currents[id]
+		}
+		lp.SetRemoteMaxCurrent(source, current)
+	}
+
+	c.measurement.SetData(model.FunctionTypeMeasurementListData, &model.MeasurementListDataType{
+		MeasurementData: []model.MeasurementDataType{{
+			MeasurementId: eebusutil.Ptr(measurementId),
+			ValueType:     eebusutil.Ptr(model.MeasurementValueTypeTypeValue),
+			Timestamp:     model.NewAbsoluteOrRelativeTimeTypeFromTime(time.Now()),
+			Value:         model.NewScaledNumberType(power),
+		}},
+	})
+}
+
+// Run executes the EEBus HEMS
+func (c *EEBus) Run() {
+	for range time.Tick(c.interval) {
+		c.update()
+	}
+}
@@ -0,0 +1,43 @@
+package eebus
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistribute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	newLoadpoint := func(status api.ChargeStatus, priority int) loadpoint.API {
+		lp := loadpoint.NewMockAPI(ctrl)
+		lp.EXPECT().GetStatus().Return(status).AnyTimes()
+		lp.EXPECT().Priority().Return(priority).AnyTimes()
+		lp.EXPECT().GetPhases().Return(3).AnyTimes()
+		lp.EXPECT().GetMinCurrent().Return(6.0).AnyTimes()
+		return lp
+	}
+
+	for _, tc := range []struct {
+		limit float64
+		res   map[int]float64
+	}{
+		// below n x min, higher priority loadpoint is served
+		{4200, map[int]float64{0: 0, 1: 6, 2: 0}},
+		// remaining power is shared
+		{9660, map[int]float64{0: 7, 1: 7, 2: 0}},
+		// below min
+		{4000, map[int]float64{0: 0, 1: 0, 2: 0}},
+	} {
+		loadpoints := []loadpoint.API{
+			newLoadpoint(api.StatusC, 0),
+			newLoadpoint(api.StatusB, 1),
+			newLoadpoint(api.StatusA, 2),
+		}
+
+		assert.Equal(t, tc.res, distribute(tc.limit, loadpoints), tc.limit)
+	}
+}
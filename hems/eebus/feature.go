@@ -0,0 +1,43 @@
+package eebus
+
+import (
+	"github.com/enbility/eebus-go/spine"
+	"github.com/enbility/eebus-go/spine/model"
+)
+
+// writeFeature is a local server feature accepting write commands
+type writeFeature struct {
+	*spine.FeatureLocalImpl
+	write func(function model.FunctionType, data any) *spine.ErrorType
+}
+
+// newWriteFeature adds a server feature with write callback to the entity
+func newWriteFeature(entity *spine.EntityLocalImpl, featureType model.FeatureTypeType, write func(model.FunctionType, any) *spine.ErrorType) *writeFeature {
+	f := &writeFeature{
+		FeatureLocalImpl: spine.NewFeatureLocalImpl(entity.NextFeatureId(), entity, featureType, model.RoleTypeServer),
+		write:            write,
+	}
+
+	f.SetDescriptionString(string(featureType) + " Server")
+	entity.AddFeature(f)
+
+	return f
+}
+
+// HandleMessage handles write commands and passes all other messages to the spine implementation
+func (f *writeFeature) HandleMessage(message *spine.Message) *spine.ErrorType {
+	if message.CmdClassifier != model.CmdClassifierTypeWrite {
+		return f.FeatureLocalImpl.HandleMessage(message)
+	}
+
+	data, err := message.Cmd.Data()
+	if err != nil || data.Function == nil {
+		return spine.NewErrorType(model.ErrorNumberTypeCommandNotSupported, "no function found for cmd data")
+	}
+
+	if op, ok := f.Operations()[*data.Function]; !ok || !op.Write {
+		return spine.NewErrorTypeFromNumber(model.ErrorNumberTypeCommandRejected)
+	}
+
+	return f.write(*data.Function, data.Value)
+}
@@ -0,0 +1,141 @@
+package eebus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+// lpcState is the state of the limitation of power consumption use case
+type lpcState int
+
+const (
+	stateControlled lpcState = iota // limits are set by the energy guard
+	stateFailsafe                   // heartbeat lost, failsafe limit applies
+	stateAutonomous                 // failsafe duration elapsed without energy guard contact
+)
+
+func (s lpcState) String() string {
+	switch s {
+	case stateFailsafe:
+		return "failsafe"
+	case stateAutonomous:
+		return "autonomous"
+	default:
+		return "controlled"
+	}
+}
+
+// lpc implements the controllable system's consumption limit state machine
+type lpc struct {
+	mu    sync.Mutex
+	clock clock.Clock
+
+	state            lpcState
+	heartbeatTimeout time.Duration
+	heartbeat        time.Time // last heartbeat received
+	failsafeSince    time.Time
+
+	limitActive  bool
+	limit        float64 // W
+	limitEnd     time.Time
+	limitUpdated time.Time
+
+	failsafeLimit    float64 // W
+	failsafeDuration time.Duration
+}
+
+func newLPC(clock clock.Clock, heartbeatTimeout time.Duration, failsafeLimit float64, failsafeDuration time.Duration) *lpc {
+	return &lpc{
+		clock:            clock,
+		heartbeatTimeout: heartbeatTimeout,
+		heartbeat:        clock.Now(), // failsafe if energy guard doesn't connect
+		failsafeLimit:    failsafeLimit,
+		failsafeDuration: failsafeDuration,
+	}
+}
+
+// Heartbeat records an energy guard heartbeat
+func (l *lpc) Heartbeat() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.heartbeat = l.clock.Now()
+}
+
+// SetLimit updates the consumption limit, a zero duration limit does not expire
+func (l *lpc) SetLimit(active bool, limit float64, duration time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+
+	l.limitActive = active
+	l.limit = limit
+	l.limitUpdated = now
+
+	l.limitEnd = time.Time{}
+	if duration > 0 {
+		l.limitEnd = now.Add(duration)
+	}
+}
+
+// SetFailsafe updates the failsafe limit and minimum duration
+func (l *lpc) SetFailsafe(limit float64, duration time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.failsafeLimit = limit
+	l.failsafeDuration = duration
+}
+
+// Failsafe returns the failsafe limit and minimum duration
+func (l *lpc) Failsafe() (float64, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.failsafeLimit, l.failsafeDuration
+}
+
+// Update advances the state machine and returns the state and the effective consumption limit
+func (l *lpc) Update() (lpcState, float64, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	lost := now.Sub(l.heartbeat) > l.heartbeatTimeout
+
+	switch l.state {
+	case stateControlled:
+		if lost {
+			l.state = stateFailsafe
+			l.failsafeSince = now
+		}
+
+	case stateFailsafe:
+		switch {
+		case !lost && l.limitUpdated.After(l.failsafeSince):
+			l.state = stateControlled
+		case now.Sub(l.failsafeSince) >= l.failsafeDuration:
+			l.state = stateAutonomous
+		}
+
+	case stateAutonomous:
+		if !lost {
+			l.state = stateControlled
+		}
+	}
+
+	switch l.state {
+	case stateFailsafe:
+		return l.state, l.failsafeLimit, true
+
+	case stateControlled:
+		if l.limitActive && (l.limitEnd.IsZero() || now.Before(l.limitEnd)) {
+			return l.state, l.limit, true
+		}
+	}
+
+	return l.state, 0, false
+}
@@ -0,0 +1,90 @@
+package eebus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLPCFailsafe(t *testing.T) {
+	clock := clock.NewMock()
+	l := newLPC(clock, time.Minute, 4200, time.Hour)
+
+	l.SetLimit(true, 8000, 0)
+
+	state, limit, ok := l.Update()
+	assert.Equal(t, stateControlled, state)
+	assert.True(t, ok)
+	assert.Equal(t, 8000.0, limit)
+
+	// heartbeat lost
+	clock.Add(2 * time.Minute)
+
+	state, limit, ok = l.Update()
+	assert.Equal(t, stateFailsafe, state)
+	assert.True(t, ok)
+	assert.Equal(t, 4200.0, limit)
+
+	// heartbeat alone does not leave failsafe
+	l.Heartbeat()
+
+	state, _, _ = l.Update()
+	assert.Equal(t, stateFailsafe, state)
+
+	// new limit does
+	clock.Add(time.Second)
+	l.SetLimit(true, 6000, 0)
+
+	state, limit, ok = l.Update()
+	assert.Equal(t, stateControlled, state)
+	assert.True(t, ok)
+	assert.Equal(t, 6000.0, limit)
+}
+
+func TestLPCAutonomous(t *testing.T) {
+	clock := clock.NewMock()
+	l := newLPC(clock, time.Minute, 4200, time.Hour)
+
+	clock.Add(2 * time.Minute)
+
+	state, _, _ := l.Update()
+	assert.Equal(t, stateFailsafe, state)
+
+	// failsafe duration elapsed
+	clock.Add(time.Hour)
+
+	state, _, ok := l.Update()
+	assert.Equal(t, stateAutonomous, state)
+	assert.False(t, ok)
+
+	// energy guard back
+	l.Heartbeat()
+
+	state, _, ok = l.Update()
+	assert.Equal(t, stateControlled, state)
+	assert.False(t, ok)
+}
+
+func TestLPCLimitExpiry(t *testing.T) {
+	clock := clock.NewMock()
+	l := newLPC(clock, time.Hour, 4200, time.Hour)
+
+	l.SetLimit(true, 5000, 10*time.Minute)
+
+	_, limit, ok := l.Update()
+	assert.True(t, ok)
+	assert.Equal(t, 5000.0, limit)
+
+	clock.Add(10 * time.Minute)
+
+	_, _, ok = l.Update()
+	assert.False(t, ok)
+
+	// inactive limit
+	l.SetLimit(false, 5000, 0)
+
+	_, _, ok = l.Update()
+	assert.False(t, ok)
+}
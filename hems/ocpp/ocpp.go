@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/hems/ocpp/profile"
 	"github.com/evcc-io/evcc/util"
@@ -22,6 +23,7 @@ type OCPP struct {
 	log  *util.Logger
 	site site.API
 	cp   ocpp16.ChargePoint
+	sc   *profile.SmartCharging
 }
 
 const retryTimeout = 5 * time.Second
@@ -58,10 +60,13 @@ func New(conf map[string]interface{}, site site.API) (*OCPP, error) {
 		cp:   cp,
 	}
 
+	connectors := len(site.Loadpoints())
+	s.sc = profile.NewSmartCharging(log, connectors, s.maxCurrent)
+
 	err := cp.Start(cc.URI)
 	if err == nil {
-		cp.SetCoreHandler(profile.NewCore(log, profile.GetDefaultConfig()))
-		cp.SetSmartChargingHandler(profile.NewSmartCharging(log))
+		cp.SetCoreHandler(profile.NewCore(log, profile.GetDefaultConfig(connectors)))
+		cp.SetSmartChargingHandler(s.sc)
 
 		go s.errorHandler(ws.Errors())
 		go s.errorHandler(cp.Errors())
@@ -77,17 +82,39 @@ func (s *OCPP) errorHandler(errC <-chan error) {
 	}
 }
 
+// maxCurrent returns the loadpoint's max current, connector 0 returns the sum of all loadpoints
+func (s *OCPP) maxCurrent(connector int) float64 {
+	var res float64
+	for id, lp := range s.site.Loadpoints() {
+		if connector == 0 || connector == id+1 {
+			res += lp.GetMaxCurrent()
+		}
+	}
+	return res
+}
+
 // Run executes the OCPP chargepoint client
 func (s *OCPP) Run() {
 	for {
 		for id, lp := range s.site.Loadpoints() {
 			connector := id + 1
 
+			lpStatus := lp.GetStatus()
+
 			status := ocppcore.ChargePointStatusAvailable
-			if lp.GetStatus() == api.StatusC {
+			if lpStatus == api.StatusC {
 				status = ocppcore.ChargePointStatusCharging
 			}
 
+			// apply smart charging limit
+			s.sc.SetConnected(connector, lpStatus == api.StatusB || lpStatus == api.StatusC)
+
+			limit := loadpoint.RemoteNoLimit
+			if current, ok := s.sc.Limit(connector); ok {
+				limit = current
+			}
+			lp.SetRemoteMaxCurrent("ocpp", limit)
+
 			s.log.DEBUG.Printf("send: lp-%d status: %+v", connector, status)
 			if _, err := s.cp.StatusNotification(connector, ocppcore.NoError, status); err != nil {
 				s.log.ERROR.Printf("lp-%d: %v", connector, err)
@@ -4,6 +4,7 @@ import (
 	"strconv"
 
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 )
 
@@ -93,22 +94,22 @@ func (c ConfigMap) set(key string, readonly bool, value string) {
 	}
 }
 
-func GetDefaultConfig() ConfigMap {
+func GetDefaultConfig(connectors int) ConfigMap {
 	intBase := 10
 
 	var cfg ConfigMap = make(map[string]core.ConfigurationKey)
 
 	// readonly
-	cfg.set(SupportedFeatureProfiles, true, core.ProfileName)
+	cfg.set(SupportedFeatureProfiles, true, core.ProfileName+","+smartcharging.ProfileName)
 	cfg.set(AuthorizeRemoteTxRequests, true, strconv.FormatBool(false))
 	cfg.set(GetConfigurationMaxKeys, true, strconv.FormatInt(50, intBase))
-	cfg.set(NumberOfConnectors, true, strconv.FormatInt(1, intBase))
+	cfg.set(NumberOfConnectors, true, strconv.FormatInt(int64(connectors), intBase))
 	cfg.set(LocalAuthListMaxLength, true, strconv.FormatInt(100, intBase))
 	cfg.set(SendLocalListMaxLength, true, strconv.FormatInt(20, intBase))
-	cfg.set(ChargeProfileMaxStackLevel, true, strconv.FormatInt(10, intBase))
-	cfg.set(ChargingScheduleAllowedChargingRateUnit, true, "Power")
-	cfg.set(ChargingScheduleMaxPeriods, true, strconv.FormatInt(5, intBase))
-	cfg.set(MaxChargingProfilesInstalled, true, strconv.FormatInt(10, intBase))
+	cfg.set(ChargeProfileMaxStackLevel, true, strconv.FormatInt(MaxStackLevel, intBase))
+	cfg.set(ChargingScheduleAllowedChargingRateUnit, true, "Current,Power")
+	cfg.set(ChargingScheduleMaxPeriods, true, strconv.FormatInt(MaxPeriods, intBase))
+	cfg.set(MaxChargingProfilesInstalled, true, strconv.FormatInt(MaxProfiles, intBase))
 
 	// read/write
 	cfg.set(ClockAlignedDataInterval, false, strconv.FormatInt(0, intBase))
@@ -1,34 +1,408 @@
 package profile
 
 import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/util"
 	sc "github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"golang.org/x/exp/slices"
+)
+
+// smart charging limits
+const (
+	MaxStackLevel = 10
+	MaxPeriods    = 48
+	MaxProfiles   = 10
 )
 
+// voltage is used for converting power limits to current limits
+const voltage = 230
+
+type chargingProfile struct {
+	connector int
+	*types.ChargingProfile
+}
+
+// deleteProfiles removes the profiles matching del
+func deleteProfiles(profiles []chargingProfile, del func(chargingProfile) bool) []chargingProfile {
+	res := profiles[:0]
+	for _, p := range profiles {
+		if !del(p) {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
 type SmartCharging struct {
-	log *util.Logger
+	mu         sync.Mutex
+	log        *util.Logger
+	clock      clock.Clock
+	connectors int
+	maxCurrent func(connector int) float64 // local limit, connector 0 is the charge point
+	sessions   map[int]time.Time           // connected vehicles' session start
+	profiles   []chargingProfile
 }
 
-func NewSmartCharging(log *util.Logger) *SmartCharging {
+func NewSmartCharging(log *util.Logger, connectors int, maxCurrent func(connector int) float64) *SmartCharging {
 	return &SmartCharging{
-		log: log,
+		log:        log,
+		clock:      clock.New(),
+		connectors: connectors,
+		maxCurrent: maxCurrent,
+		sessions:   make(map[int]time.Time),
+	}
+}
+
+// SetConnected updates the connector's vehicle connection. TxProfiles are removed when the vehicle disconnects.
+func (s *SmartCharging) SetConnected(connector int, connected bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sessions[connector]; ok == connected {
+		return
+	}
+
+	if connected {
+		s.sessions[connector] = s.clock.Now()
+		return
+	}
+
+	delete(s.sessions, connector)
+
+	s.profiles = deleteProfiles(s.profiles, func(p chargingProfile) bool {
+		return p.connector == connector && p.ChargingProfilePurpose == types.ChargingProfilePurposeTxProfile
+	})
+}
+
+// Limit returns the connector's effective current limit
+func (s *SmartCharging) Limit(connector int) (float64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.limit(connector, s.clock.Now())
+}
+
+// validate checks if the profile can be installed
+func (s *SmartCharging) validate(connector int, profile *types.ChargingProfile) bool {
+	if connector > s.connectors || profile.StackLevel > MaxStackLevel || profile.ChargingSchedule == nil ||
+		len(profile.ChargingSchedule.ChargingSchedulePeriod) > MaxPeriods {
+		return false
+	}
+
+	if profile.ChargingProfileKind == types.ChargingProfileKindRecurring && profile.ChargingSchedule.StartSchedule == nil {
+		return false
+	}
+
+	switch profile.ChargingProfilePurpose {
+	case types.ChargingProfilePurposeChargePointMaxProfile:
+		return connector == 0
+	case types.ChargingProfilePurposeTxProfile:
+		_, connected := s.sessions[connector]
+		return connector > 0 && connected
+	default:
+		return true
 	}
 }
 
 // OnSetChargingProfile handles the CS message
 func (s *SmartCharging) OnSetChargingProfile(request *sc.SetChargingProfileRequest) (confirmation *sc.SetChargingProfileConfirmation, err error) {
 	s.log.TRACE.Printf("recv: %s %+v", request.GetFeatureName(), request)
-	return sc.NewSetChargingProfileConfirmation(sc.ChargingProfileStatusRejected), nil
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile := request.ChargingProfile
+	if profile == nil || !s.validate(request.ConnectorId, profile) {
+		return sc.NewSetChargingProfileConfirmation(sc.ChargingProfileStatusRejected), nil
+	}
+
+	// replace profiles with same id or same purpose and stack level
+	profiles := deleteProfiles(slices.Clone(s.profiles), func(p chargingProfile) bool {
+		return p.ChargingProfileId == profile.ChargingProfileId ||
+			p.connector == request.ConnectorId && p.ChargingProfilePurpose == profile.ChargingProfilePurpose && p.StackLevel == profile.StackLevel
+	})
+
+	if len(profiles) >= MaxProfiles {
+		return sc.NewSetChargingProfileConfirmation(sc.ChargingProfileStatusRejected), nil
+	}
+
+	s.profiles = append(profiles, chargingProfile{
+		connector:       request.ConnectorId,
+		ChargingProfile: profile,
+	})
+
+	s.log.DEBUG.Printf("connector %d: installed %s %d at stack level %d", request.ConnectorId, profile.ChargingProfilePurpose, profile.ChargingProfileId, profile.StackLevel)
+
+	return sc.NewSetChargingProfileConfirmation(sc.ChargingProfileStatusAccepted), nil
 }
 
 // OnClearChargingProfile handles the CS message
 func (s *SmartCharging) OnClearChargingProfile(request *sc.ClearChargingProfileRequest) (confirmation *sc.ClearChargingProfileConfirmation, err error) {
 	s.log.TRACE.Printf("recv: %s %+v", request.GetFeatureName(), request)
-	return sc.NewClearChargingProfileConfirmation(sc.ClearChargingProfileStatusUnknown), nil
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := len(s.profiles)
+
+	s.profiles = deleteProfiles(s.profiles, func(p chargingProfile) bool {
+		if request.Id != nil {
+			return p.ChargingProfileId == *request.Id
+		}
+
+		return (request.ConnectorId == nil || p.connector == *request.ConnectorId) &&
+			(request.ChargingProfilePurpose == "" || p.ChargingProfilePurpose == request.ChargingProfilePurpose) &&
+			(request.StackLevel == nil || p.StackLevel == *request.StackLevel)
+	})
+
+	if len(s.profiles) == count {
+		return sc.NewClearChargingProfileConfirmation(sc.ClearChargingProfileStatusUnknown), nil
+	}
+
+	return sc.NewClearChargingProfileConfirmation(sc.ClearChargingProfileStatusAccepted), nil
 }
 
 // OnGetCompositeSchedule handles the CS message
 func (s *SmartCharging) OnGetCompositeSchedule(request *sc.GetCompositeScheduleRequest) (confirmation *sc.GetCompositeScheduleConfirmation, err error) {
 	s.log.TRACE.Printf("recv: %s %+v", request.GetFeatureName(), request)
-	return sc.NewGetCompositeScheduleConfirmation(sc.GetCompositeScheduleStatusRejected), nil
+
+	if request.ConnectorId > s.connectors {
+		return sc.NewGetCompositeScheduleConfirmation(sc.GetCompositeScheduleStatusRejected), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now().Truncate(time.Second)
+	end := now.Add(time.Duration(request.Duration) * time.Second)
+
+	unit := request.ChargingRateUnit
+	if unit == "" {
+		unit = types.ChargingRateUnitAmperes
+	}
+
+	schedule := types.NewChargingSchedule(unit)
+	schedule.StartSchedule = types.NewDateTime(now)
+	schedule.Duration = &request.Duration
+
+	maxCurrent := s.maxCurrent(request.ConnectorId)
+
+	prev := math.NaN()
+	for _, ts := range s.breakpoints(request.ConnectorId, now, end) {
+		limit := maxCurrent
+		if l, ok := s.limit(request.ConnectorId, ts); ok && l < limit {
+			limit = l
+		}
+
+		if limit == prev {
+			continue
+		}
+		prev = limit
+
+		if unit == types.ChargingRateUnitWatts {
+			limit *= voltage * 3
+		}
+
+		schedule.ChargingSchedulePeriod = append(schedule.ChargingSchedulePeriod,
+			types.NewChargingSchedulePeriod(int(ts.Sub(now).Seconds()), limit))
+	}
+
+	res := sc.NewGetCompositeScheduleConfirmation(sc.GetCompositeScheduleStatusAccepted)
+	res.ScheduleStart = schedule.StartSchedule
+
+	if request.ConnectorId > 0 {
+		res.ConnectorId = &request.ConnectorId
+	}
+
+	res.ChargingSchedule = schedule
+
+	return res, nil
+}
+
+// limit returns the connector's effective current limit at the given time (no mutex).
+// The charge point's maximum is shared evenly between the connectors with connected vehicles.
+func (s *SmartCharging) limit(connector int, ts time.Time) (float64, bool) {
+	res, ok := s.purposeLimit(types.ChargingProfilePurposeChargePointMaxProfile, 0, ts)
+	if connector == 0 {
+		return res, ok
+	}
+
+	// connector is counted as active even if the vehicle is not connected yet
+	active := len(s.sessions)
+	if _, connected := s.sessions[connector]; !connected {
+		active++
+	}
+
+	if ok && active > 1 {
+		res /= float64(active)
+	}
+
+	tx, txOk := s.purposeLimit(types.ChargingProfilePurposeTxProfile, connector, ts)
+	if !txOk {
+		tx, txOk = s.purposeLimit(types.ChargingProfilePurposeTxDefaultProfile, connector, ts)
+	}
+
+	switch {
+	case ok && txOk:
+		return math.Min(res, tx), true
+	case txOk:
+		return tx, true
+	default:
+		return res, ok
+	}
+}
+
+// purposeLimit returns the limit of the active profile with highest precedence for the given purpose
+func (s *SmartCharging) purposeLimit(purpose types.ChargingProfilePurposeType, connector int, ts time.Time) (float64, bool) {
+	var profiles []chargingProfile
+	for _, p := range s.profiles {
+		if p.ChargingProfilePurpose == purpose && (p.connector == connector || p.connector == 0) {
+			profiles = append(profiles, p)
+		}
+	}
+
+	// connector-specific profiles take precedence over station-wide profiles, then higher stack levels
+	sort.SliceStable(profiles, func(i, j int) bool {
+		if profiles[i].connector != profiles[j].connector {
+			return profiles[i].connector > profiles[j].connector
+		}
+		return profiles[i].StackLevel > profiles[j].StackLevel
+	})
+
+	for _, p := range profiles {
+		if limit, ok := s.profileLimit(p, connector, ts); ok {
+			return limit, true
+		}
+	}
+
+	return 0, false
+}
+
+// recurrence returns the recurring profile's period
+func recurrence(p chargingProfile) time.Duration {
+	if p.RecurrencyKind == types.RecurrencyKindWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// scheduleStart returns the start of the profile's schedule instance active at the given time
+func (s *SmartCharging) scheduleStart(p chargingProfile, connector int, ts time.Time) (time.Time, bool) {
+	schedule := p.ChargingSchedule
+
+	switch {
+	case p.ChargingProfileKind == types.ChargingProfileKindRecurring:
+		start := schedule.StartSchedule.Time
+		if ts.Before(start) {
+			return time.Time{}, false
+		}
+
+		period := recurrence(p)
+		return start.Add(ts.Sub(start) / period * period), true
+
+	case p.ChargingProfileKind == types.ChargingProfileKindAbsolute && schedule.StartSchedule != nil:
+		return schedule.StartSchedule.Time, true
+
+	default:
+		// relative to session start
+		start, ok := s.sessions[connector]
+		return start, ok
+	}
+}
+
+// profileLimit returns the profile's current limit at the given time
+func (s *SmartCharging) profileLimit(p chargingProfile, connector int, ts time.Time) (float64, bool) {
+	if p.ValidFrom != nil && ts.Before(p.ValidFrom.Time) || p.ValidTo != nil && !ts.Before(p.ValidTo.Time) {
+		return 0, false
+	}
+
+	start, ok := s.scheduleStart(p, connector, ts)
+	if !ok || ts.Before(start) {
+		return 0, false
+	}
+
+	schedule := p.ChargingSchedule
+	offset := ts.Sub(start)
+
+	if schedule.Duration != nil && offset >= time.Duration(*schedule.Duration)*time.Second {
+		return 0, false
+	}
+
+	var period *types.ChargingSchedulePeriod
+	for i, sp := range schedule.ChargingSchedulePeriod {
+		if time.Duration(sp.StartPeriod)*time.Second <= offset && (period == nil || sp.StartPeriod > period.StartPeriod) {
+			period = &schedule.ChargingSchedulePeriod[i]
+		}
+	}
+
+	if period == nil {
+		return 0, false
+	}
+
+	limit := period.Limit
+	if schedule.ChargingRateUnit == types.ChargingRateUnitWatts {
+		phases := 3
+		if period.NumberPhases != nil && *period.NumberPhases > 0 {
+			phases = *period.NumberPhases
+		}
+
+		limit /= float64(voltage * phases)
+	}
+
+	return limit, true
+}
+
+// breakpoints returns the sorted times within [from, to) at which the connector's limit may change
+func (s *SmartCharging) breakpoints(connector int, from, to time.Time) []time.Time {
+	res := []time.Time{from}
+
+	add := func(ts time.Time) {
+		if ts.After(from) && ts.Before(to) {
+			res = append(res, ts)
+		}
+	}
+
+	for _, p := range s.profiles {
+		if p.connector != connector && p.connector != 0 {
+			continue
+		}
+
+		if p.ValidFrom != nil {
+			add(p.ValidFrom.Time)
+		}
+		if p.ValidTo != nil {
+			add(p.ValidTo.Time)
+		}
+
+		// schedule instances within the window
+		start, ok := s.scheduleStart(p, connector, from)
+		if !ok && p.ChargingProfileKind == types.ChargingProfileKindRecurring {
+			start, ok = p.ChargingSchedule.StartSchedule.Time, true
+		}
+
+		for ok && start.Before(to) {
+			for _, sp := range p.ChargingSchedule.ChargingSchedulePeriod {
+				add(start.Add(time.Duration(sp.StartPeriod) * time.Second))
+			}
+			if d := p.ChargingSchedule.Duration; d != nil {
+				add(start.Add(time.Duration(*d) * time.Second))
+			}
+
+			if p.ChargingProfileKind != types.ChargingProfileKindRecurring {
+				break
+			}
+
+			start = start.Add(recurrence(p))
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Before(res[j]) })
+
+	return slices.CompactFunc(res, func(a, b time.Time) bool { return a.Equal(b) })
 }
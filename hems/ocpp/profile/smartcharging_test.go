@@ -0,0 +1,195 @@
+package profile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+	sc "github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSmartCharging(connectors int) (*SmartCharging, *clock.Mock) {
+	clock := clock.NewMock()
+	clock.Set(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	s := NewSmartCharging(util.NewLogger("foo"), connectors, func(connector int) float64 {
+		if connector == 0 {
+			return 16 * float64(connectors)
+		}
+		return 16
+	})
+	s.clock = clock
+
+	return s, clock
+}
+
+func setProfile(t *testing.T, s *SmartCharging, connector int, profile *types.ChargingProfile) sc.ChargingProfileStatus {
+	res, err := s.OnSetChargingProfile(sc.NewSetChargingProfileRequest(connector, profile))
+	require.NoError(t, err)
+	return res.Status
+}
+
+func absoluteProfile(id, stackLevel int, purpose types.ChargingProfilePurposeType, start time.Time, periods ...types.ChargingSchedulePeriod) *types.ChargingProfile {
+	schedule := types.NewChargingSchedule(types.ChargingRateUnitAmperes, periods...)
+	schedule.StartSchedule = types.NewDateTime(start)
+	return types.NewChargingProfile(id, stackLevel, purpose, types.ChargingProfileKindAbsolute, schedule)
+}
+
+func TestSmartChargingValidation(t *testing.T) {
+	s, clock := newTestSmartCharging(1)
+	now := clock.Now()
+
+	// charge point max profile on connector
+	assert.Equal(t, sc.ChargingProfileStatusRejected, setProfile(t, s, 1,
+		absoluteProfile(1, 0, types.ChargingProfilePurposeChargePointMaxProfile, now, types.NewChargingSchedulePeriod(0, 10))))
+
+	// unknown connector
+	assert.Equal(t, sc.ChargingProfileStatusRejected, setProfile(t, s, 2,
+		absoluteProfile(1, 0, types.ChargingProfilePurposeTxDefaultProfile, now, types.NewChargingSchedulePeriod(0, 10))))
+
+	// tx profile without vehicle
+	assert.Equal(t, sc.ChargingProfileStatusRejected, setProfile(t, s, 1,
+		absoluteProfile(1, 0, types.ChargingProfilePurposeTxProfile, now, types.NewChargingSchedulePeriod(0, 10))))
+
+	s.SetConnected(1, true)
+	assert.Equal(t, sc.ChargingProfileStatusAccepted, setProfile(t, s, 1,
+		absoluteProfile(1, 0, types.ChargingProfilePurposeTxProfile, now, types.NewChargingSchedulePeriod(0, 10))))
+
+	limit, ok := s.Limit(1)
+	assert.True(t, ok)
+	assert.Equal(t, 10.0, limit)
+
+	// tx profile removed on disconnect
+	s.SetConnected(1, false)
+	_, ok = s.Limit(1)
+	assert.False(t, ok)
+}
+
+func TestSmartChargingStacking(t *testing.T) {
+	s, clock := newTestSmartCharging(1)
+	now := clock.Now()
+
+	// default profile with lower limit during first hour
+	require.Equal(t, sc.ChargingProfileStatusAccepted, setProfile(t, s, 0,
+		absoluteProfile(1, 0, types.ChargingProfilePurposeTxDefaultProfile, now,
+			types.NewChargingSchedulePeriod(0, 8),
+			types.NewChargingSchedulePeriod(3600, 16))))
+
+	// higher stack level valid for 30 minutes
+	profile := absoluteProfile(2, 1, types.ChargingProfilePurposeTxDefaultProfile, now, types.NewChargingSchedulePeriod(0, 6))
+	profile.ValidTo = types.NewDateTime(now.Add(30 * time.Minute))
+	require.Equal(t, sc.ChargingProfileStatusAccepted, setProfile(t, s, 1, profile))
+
+	// charge point max caps tx default
+	require.Equal(t, sc.ChargingProfileStatusAccepted, setProfile(t, s, 0,
+		absoluteProfile(3, 0, types.ChargingProfilePurposeChargePointMaxProfile, now, types.NewChargingSchedulePeriod(0, 12))))
+
+	for _, tc := range []struct {
+		offset time.Duration
+		limit  float64
+	}{
+		{0, 6},
+		{30 * time.Minute, 8},
+		{time.Hour, 12},
+	} {
+		clock.Set(now.Add(tc.offset))
+
+		limit, ok := s.Limit(1)
+		assert.True(t, ok)
+		assert.Equal(t, tc.limit, limit, tc.offset)
+	}
+
+	// composite schedule
+	clock.Set(now)
+
+	res, err := s.OnGetCompositeSchedule(sc.NewGetCompositeScheduleRequest(1, 7200))
+	require.NoError(t, err)
+	require.Equal(t, sc.GetCompositeScheduleStatusAccepted, res.Status)
+
+	assert.Equal(t, []types.ChargingSchedulePeriod{
+		types.NewChargingSchedulePeriod(0, 6),
+		types.NewChargingSchedulePeriod(1800, 8),
+		types.NewChargingSchedulePeriod(3600, 12),
+	}, res.ChargingSchedule.ChargingSchedulePeriod)
+
+	// clear charge point max
+	id := 3
+	clear, err := s.OnClearChargingProfile(&sc.ClearChargingProfileRequest{Id: &id})
+	require.NoError(t, err)
+	assert.Equal(t, sc.ClearChargingProfileStatusAccepted, clear.Status)
+
+	clock.Set(now.Add(time.Hour))
+
+	limit, ok := s.Limit(1)
+	assert.True(t, ok)
+	assert.Equal(t, 16.0, limit)
+}
+
+func TestSmartChargingRecurring(t *testing.T) {
+	s, clock := newTestSmartCharging(2)
+	now := clock.Now()
+
+	// daily 11kW limit from 18:00 to 22:00 shared by both connectors
+	schedule := types.NewChargingSchedule(types.ChargingRateUnitWatts, types.NewChargingSchedulePeriod(0, 11040))
+	schedule.StartSchedule = types.NewDateTime(now.Add(18 * time.Hour))
+	duration := 4 * 3600
+	schedule.Duration = &duration
+
+	profile := types.NewChargingProfile(1, 0, types.ChargingProfilePurposeChargePointMaxProfile, types.ChargingProfileKindRecurring, schedule)
+	profile.RecurrencyKind = types.RecurrencyKindDaily
+	require.Equal(t, sc.ChargingProfileStatusAccepted, setProfile(t, s, 0, profile))
+
+	s.SetConnected(1, true)
+	s.SetConnected(2, true)
+
+	for _, tc := range []struct {
+		offset time.Duration
+		limit  float64
+		ok     bool
+	}{
+		{12 * time.Hour, 0, false},
+		{19 * time.Hour, 8, true},
+		{23 * time.Hour, 0, false},
+		{24*time.Hour + 20*time.Hour, 8, true},
+	} {
+		clock.Set(now.Add(tc.offset))
+
+		limit, ok := s.Limit(1)
+		assert.Equal(t, tc.ok, ok, tc.offset)
+		assert.Equal(t, tc.limit, limit, tc.offset)
+	}
+}
+
+func TestSmartChargingSharedLimit(t *testing.T) {
+	s, clock := newTestSmartCharging(2)
+	now := clock.Now()
+
+	require.Equal(t, sc.ChargingProfileStatusAccepted, setProfile(t, s, 0,
+		absoluteProfile(1, 0, types.ChargingProfilePurposeChargePointMaxProfile, now, types.NewChargingSchedulePeriod(0, 20))))
+
+	// single active connector receives the full limit
+	s.SetConnected(1, true)
+
+	limit, ok := s.Limit(1)
+	assert.True(t, ok)
+	assert.Equal(t, 20.0, limit)
+
+	// limit is shared once the second vehicle connects
+	s.SetConnected(2, true)
+
+	for _, connector := range []int{1, 2} {
+		limit, ok := s.Limit(connector)
+		assert.True(t, ok)
+		assert.Equal(t, 10.0, limit, connector)
+	}
+
+	s.SetConnected(1, false)
+
+	limit, ok = s.Limit(2)
+	assert.True(t, ok)
+	assert.Equal(t, 20.0, limit)
+}
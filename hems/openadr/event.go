@@ -0,0 +1,168 @@
+package openadr
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dylanmei/iso8601"
+)
+
+// signal names
+const (
+	signalSimple = "SIMPLE"
+	signalPrice  = "ELECTRICITY_PRICE"
+)
+
+// action is the loadpoint behaviour applied during an event
+type action int
+
+const (
+	actionNone   action = iota // no change
+	actionReduce               // reduce the current cap
+	actionPV                   // switch to PV-only charging
+	actionPause                // pause charging
+)
+
+func (a action) String() string {
+	switch a {
+	case actionReduce:
+		return "reduce"
+	case actionPV:
+		return "pv"
+	case actionPause:
+		return "pause"
+	default:
+		return "none"
+	}
+}
+
+// actionString converts string to action
+func actionString(s string) (action, error) {
+	for a := actionNone; a <= actionPause; a++ {
+		if strings.EqualFold(s, a.String()) {
+			return a, nil
+		}
+	}
+	return actionNone, fmt.Errorf("invalid action: %s", s)
+}
+
+// interval is a signal interval with absolute start
+type interval struct {
+	start, end time.Time // zero end is unbounded
+	value      float64
+}
+
+// signal is a parsed event signal
+type signal struct {
+	name      string
+	intervals []interval
+}
+
+// event is a parsed OpenADR event
+type event struct {
+	id               string
+	modification     int
+	marketContext    string
+	status           string
+	test             bool
+	responseRequired bool
+	start, end       time.Time // zero end is unbounded
+	signals          []signal
+}
+
+// parseDuration parses iso8601 durations with empty durations being zero
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return iso8601.ParseDuration(s)
+}
+
+// newEvent parses the event's active period and signal intervals
+func newEvent(e OadrEvent) (*event, error) {
+	desc := e.EiEvent.EventDescriptor
+
+	res := &event{
+		id:               desc.EventID,
+		modification:     desc.ModificationNumber,
+		marketContext:    desc.MarketContext,
+		status:           strings.ToLower(desc.EventStatus),
+		test:             strings.EqualFold(desc.TestEvent, "true"),
+		responseRequired: !strings.EqualFold(e.ResponseRequired, "never"),
+	}
+
+	start, err := time.Parse(time.RFC3339, e.EiEvent.ActivePeriod.Start)
+	if err != nil {
+		return nil, fmt.Errorf("event %s: start: %w", res.id, err)
+	}
+	res.start = start
+
+	duration, err := parseDuration(e.EiEvent.ActivePeriod.Duration)
+	if err != nil {
+		return nil, fmt.Errorf("event %s: duration: %w", res.id, err)
+	}
+	if duration > 0 {
+		res.end = start.Add(duration)
+	}
+
+	for _, s := range e.EiEvent.Signals {
+		sig := signal{name: strings.ToUpper(s.SignalName)}
+
+		ts := start
+		for _, i := range s.Intervals {
+			d, err := parseDuration(i.Duration)
+			if err != nil {
+				return nil, fmt.Errorf("event %s: signal %s: %w", res.id, s.SignalID, err)
+			}
+
+			iv := interval{start: ts, end: res.end, value: i.Payload.Value}
+			if d > 0 {
+				iv.end = ts.Add(d)
+			}
+
+			sig.intervals = append(sig.intervals, iv)
+			ts = iv.end
+		}
+
+		res.signals = append(res.signals, sig)
+	}
+
+	return res, nil
+}
+
+// key identifies the event's modification
+func (e *event) key() string {
+	return fmt.Sprintf("%s/%d", e.id, e.modification)
+}
+
+// Active returns true if the event is active at the given time
+func (e *event) Active(now time.Time) bool {
+	return e.status != eventStatusCancelled && !now.Before(e.start) && (e.end.IsZero() || now.Before(e.end))
+}
+
+// Completed returns true if the event has ended or was cancelled
+func (e *event) Completed(now time.Time) bool {
+	return e.status == eventStatusCancelled || !e.end.IsZero() && !now.Before(e.end)
+}
+
+// Value returns the named signal's value at the given time
+func (e *event) Value(name string, now time.Time) (float64, bool) {
+	if !e.Active(now) {
+		return 0, false
+	}
+
+	for _, s := range e.signals {
+		if s.name != name {
+			continue
+		}
+
+		for _, i := range s.intervals {
+			if !now.Before(i.start) && (i.end.IsZero() || now.Before(i.end)) {
+				return i.value, true
+			}
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,219 @@
+package openadr
+
+import "encoding/xml"
+
+// Elements are tagged with their full OpenADR 2.0b namespace so that messages can be encoded and
+// decoded independent of the prefixes chosen by the VTN. Nested paths span multiple namespaces
+// and are matched by local name only.
+
+const (
+	profileName   = "2.0b"
+	transportName = "simpleHttp"
+)
+
+// response codes
+const (
+	responseOK = "200"
+)
+
+// opt types
+const (
+	optIn  = "optIn"
+	optOut = "optOut"
+)
+
+// event status
+const (
+	eventStatusCancelled = "cancelled"
+)
+
+// Payload is the oadrPayload envelope
+type Payload struct {
+	XMLName      xml.Name     `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrPayload"`
+	SignedObject SignedObject `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrSignedObject"`
+}
+
+// SignedObject contains exactly one message
+type SignedObject struct {
+	QueryRegistration         *QueryRegistration         `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrQueryRegistration,omitempty"`
+	CreatePartyRegistration   *CreatePartyRegistration   `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrCreatePartyRegistration,omitempty"`
+	CreatedPartyRegistration  *CreatedPartyRegistration  `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrCreatedPartyRegistration,omitempty"`
+	Poll                      *Poll                      `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrPoll,omitempty"`
+	Response                  *Response                  `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrResponse,omitempty"`
+	RequestEvent              *RequestEvent              `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrRequestEvent,omitempty"`
+	DistributeEvent           *DistributeEvent           `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrDistributeEvent,omitempty"`
+	CreatedEvent              *CreatedEvent              `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrCreatedEvent,omitempty"`
+	RequestReregistration     *RequestReregistration     `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrRequestReregistration,omitempty"`
+	CancelPartyRegistration   *CancelPartyRegistration   `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrCancelPartyRegistration,omitempty"`
+	CanceledPartyRegistration *CanceledPartyRegistration `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrCanceledPartyRegistration,omitempty"`
+}
+
+// EiResponse is the generic response
+type EiResponse struct {
+	ResponseCode        string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 responseCode"`
+	ResponseDescription string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 responseDescription,omitempty"`
+	RequestID           string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110/payloads requestID"`
+}
+
+// QueryRegistration message definition
+type QueryRegistration struct {
+	RequestID string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110/payloads requestID"`
+}
+
+// CreatePartyRegistration message definition
+type CreatePartyRegistration struct {
+	RequestID      string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110/payloads requestID"`
+	RegistrationID string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 registrationID,omitempty"`
+	VenID          string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 venID,omitempty"`
+	ProfileName    string `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrProfileName"`
+	TransportName  string `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrTransportName"`
+	ReportOnly     bool   `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrReportOnly"`
+	XmlSignature   bool   `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrXmlSignature"`
+	VenName        string `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrVenName,omitempty"`
+	HttpPullModel  bool   `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrHttpPullModel"`
+}
+
+// CreatedPartyRegistration message definition
+type CreatedPartyRegistration struct {
+	EiResponse     EiResponse `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 eiResponse"`
+	RegistrationID string     `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 registrationID,omitempty"`
+	VenID          string     `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 venID,omitempty"`
+	VtnID          string     `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 vtnID"`
+	PollFreq       *PollFreq  `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrRequestedOadrPollFreq,omitempty"`
+}
+
+// PollFreq is the VTN's requested poll frequency
+type PollFreq struct {
+	Duration string `xml:"urn:ietf:params:xml:ns:icalendar-2.0 duration"`
+}
+
+// CancelPartyRegistration message definition
+type CancelPartyRegistration struct {
+	RequestID      string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110/payloads requestID"`
+	RegistrationID string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 registrationID"`
+	VenID          string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 venID,omitempty"`
+}
+
+// CanceledPartyRegistration message definition
+type CanceledPartyRegistration struct {
+	EiResponse     EiResponse `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 eiResponse"`
+	RegistrationID string     `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 registrationID,omitempty"`
+	VenID          string     `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 venID,omitempty"`
+}
+
+// RequestReregistration message definition
+type RequestReregistration struct {
+	VenID string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 venID"`
+}
+
+// Poll message definition
+type Poll struct {
+	VenID string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 venID"`
+}
+
+// Response message definition
+type Response struct {
+	EiResponse EiResponse `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 eiResponse"`
+	VenID      string     `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 venID,omitempty"`
+}
+
+// RequestEvent message definition
+type RequestEvent struct {
+	EiRequestEvent EiRequestEvent `xml:"http://docs.oasis-open.org/ns/energyinterop/201110/payloads eiRequestEvent"`
+}
+
+// EiRequestEvent message definition
+type EiRequestEvent struct {
+	RequestID string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110/payloads requestID"`
+	VenID     string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 venID"`
+}
+
+// DistributeEvent message definition
+type DistributeEvent struct {
+	EiResponse *EiResponse `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 eiResponse,omitempty"`
+	RequestID  string      `xml:"http://docs.oasis-open.org/ns/energyinterop/201110/payloads requestID"`
+	VtnID      string      `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 vtnID"`
+	Events     []OadrEvent `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrEvent"`
+}
+
+// OadrEvent wraps an event together with its response requirement
+type OadrEvent struct {
+	EiEvent          EiEvent `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 eiEvent"`
+	ResponseRequired string  `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrResponseRequired"`
+}
+
+// EiEvent message definition
+type EiEvent struct {
+	EventDescriptor EventDescriptor `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 eventDescriptor"`
+	ActivePeriod    ActivePeriod    `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 eiActivePeriod"`
+	Signals         []EventSignal   `xml:"eiEventSignals>eiEventSignal"`
+}
+
+// EventDescriptor message definition
+type EventDescriptor struct {
+	EventID            string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 eventID"`
+	ModificationNumber int    `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 modificationNumber"`
+	Priority           int    `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 priority,omitempty"`
+	MarketContext      string `xml:"eiMarketContext>marketContext"`
+	CreatedDateTime    string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 createdDateTime,omitempty"`
+	EventStatus        string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 eventStatus"`
+	TestEvent          string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 testEvent,omitempty"`
+}
+
+// ActivePeriod message definition
+type ActivePeriod struct {
+	Start    string `xml:"properties>dtstart>date-time"`
+	Duration string `xml:"properties>duration>duration"`
+}
+
+// EventSignal message definition
+type EventSignal struct {
+	Intervals    []Interval     `xml:"intervals>interval"`
+	SignalName   string         `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 signalName"`
+	SignalType   string         `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 signalType"`
+	SignalID     string         `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 signalID"`
+	CurrentValue *SignalPayload `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 currentValue,omitempty"`
+}
+
+// Interval message definition
+type Interval struct {
+	Duration string        `xml:"duration>duration"`
+	UID      string        `xml:"uid>text"`
+	Payload  SignalPayload `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 signalPayload"`
+}
+
+// SignalPayload message definition
+type SignalPayload struct {
+	Value float64 `xml:"payloadFloat>value"`
+}
+
+// CreatedEvent message definition
+type CreatedEvent struct {
+	EiCreatedEvent EiCreatedEvent `xml:"http://docs.oasis-open.org/ns/energyinterop/201110/payloads eiCreatedEvent"`
+}
+
+// EiCreatedEvent message definition
+type EiCreatedEvent struct {
+	EiResponse     EiResponse     `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 eiResponse"`
+	EventResponses EventResponses `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 eventResponses"`
+	VenID          string         `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 venID"`
+}
+
+// EventResponses message definition
+type EventResponses struct {
+	EventResponse []EventResponse `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 eventResponse"`
+}
+
+// EventResponse message definition
+type EventResponse struct {
+	ResponseCode     string           `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 responseCode"`
+	RequestID        string           `xml:"http://docs.oasis-open.org/ns/energyinterop/201110/payloads requestID"`
+	QualifiedEventID QualifiedEventID `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 qualifiedEventID"`
+	OptType          string           `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 optType"`
+}
+
+// QualifiedEventID message definition
+type QualifiedEventID struct {
+	EventID            string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 eventID"`
+	ModificationNumber int    `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 modificationNumber"`
+}
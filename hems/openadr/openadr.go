@@ -0,0 +1,342 @@
+package openadr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"golang.org/x/exp/slices"
+)
+
+// source identifies the OpenADR demand at the loadpoints
+const source = "openadr"
+
+// price is an action applied above the price threshold
+type price struct {
+	Above  float64
+	Action string
+}
+
+// OpenADR is an OpenADR 2.0b VEN responding to demand response events
+type OpenADR struct {
+	mu    sync.Mutex
+	log   *util.Logger
+	site  site.API
+	clock clock.Clock
+	ven   *ven
+
+	interval      time.Duration
+	levels        map[int]action
+	prices        []price
+	reduceCurrent float64
+
+	optTest          bool
+	optMarketContext []string
+	optMaxDuration   time.Duration
+
+	events map[string]*event // by event id
+	opts   map[string]string // by event modification
+	action action
+	modes  map[int]api.ChargeMode // loadpoint modes before switching to pv
+}
+
+// New creates an OpenADR VEN from generic config
+func New(other map[string]interface{}, site site.API) (*OpenADR, error) {
+	cc := struct {
+		URI           string
+		VenName       string
+		VenID         string
+		Interval      time.Duration
+		Levels        map[int]string
+		Prices        []price
+		ReduceCurrent float64
+		OptIn         struct {
+			Test          bool
+			MarketContext []string
+			MaxDuration   time.Duration
+		}
+	}{
+		VenName:  "evcc",
+		Interval: time.Minute,
+		Levels: map[int]string{
+			1: actionReduce.String(),
+			2: actionPV.String(),
+			3: actionPause.String(),
+		},
+		ReduceCurrent: 6,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.URI == "" {
+		return nil, errors.New("missing uri")
+	}
+
+	log := util.NewLogger("openadr")
+
+	c := &OpenADR{
+		log:              log,
+		site:             site,
+		clock:            clock.New(),
+		ven:              newVEN(log, cc.URI, cc.VenName, cc.VenID),
+		interval:         cc.Interval,
+		levels:           make(map[int]action),
+		reduceCurrent:    cc.ReduceCurrent,
+		optTest:          cc.OptIn.Test,
+		optMarketContext: cc.OptIn.MarketContext,
+		optMaxDuration:   cc.OptIn.MaxDuration,
+		events:           make(map[string]*event),
+		opts:             make(map[string]string),
+		modes:            make(map[int]api.ChargeMode),
+	}
+
+	for level, s := range cc.Levels {
+		a, err := actionString(s)
+		if err != nil {
+			return nil, fmt.Errorf("level %d: %w", level, err)
+		}
+		c.levels[level] = a
+	}
+
+	for _, p := range cc.Prices {
+		if _, err := actionString(p.Action); err != nil {
+			return nil, fmt.Errorf("price %.3f: %w", p.Above, err)
+		}
+	}
+
+	// highest threshold first
+	c.prices = slices.Clone(cc.Prices)
+	sort.SliceStable(c.prices, func(i, j int) bool {
+		return c.prices[i].Above > c.prices[j].Above
+	})
+
+	return c, nil
+}
+
+// opt decides whether to participate in the event
+func (c *OpenADR) opt(ev *event) string {
+	switch {
+	case ev.status == eventStatusCancelled:
+		return optIn
+	case ev.test && !c.optTest:
+		return optOut
+	case len(c.optMarketContext) > 0 && !slices.Contains(c.optMarketContext, ev.marketContext):
+		return optOut
+	case c.optMaxDuration > 0 && (ev.end.IsZero() || ev.end.Sub(ev.start) > c.optMaxDuration):
+		return optOut
+	default:
+		return optIn
+	}
+}
+
+// distribute updates the events and reports the opt status of new events to the VTN
+func (c *OpenADR) distribute(d *DistributeEvent) error {
+	events := make(map[string]*event)
+	keys := make(map[string]bool)
+	var responses []EventResponse
+
+	for _, e := range d.Events {
+		ev, err := newEvent(e)
+		if err != nil {
+			c.log.ERROR.Println(err)
+			continue
+		}
+
+		events[ev.id] = ev
+		keys[ev.key()] = true
+
+		if _, ok := c.opts[ev.key()]; ok {
+			continue
+		}
+
+		opt := c.opt(ev)
+		c.opts[ev.key()] = opt
+
+		c.log.INFO.Printf("event %s (%s, %s - %s): %s", ev.key(), ev.status, ev.start.Local().Format(time.RFC822), ev.end.Local().Format(time.RFC822), opt)
+
+		if ev.responseRequired {
+			responses = append(responses, EventResponse{
+				ResponseCode: responseOK,
+				RequestID:    d.RequestID,
+				QualifiedEventID: QualifiedEventID{
+					EventID:            ev.id,
+					ModificationNumber: ev.modification,
+				},
+				OptType: opt,
+			})
+		}
+	}
+
+	// the VTN always distributes the complete list of events
+	c.events = events
+
+	for key := range c.opts {
+		if !keys[key] {
+			delete(c.opts, key)
+		}
+	}
+
+	if len(responses) == 0 {
+		return nil
+	}
+
+	return c.ven.CreatedEvent(d.RequestID, responses)
+}
+
+// signalAction maps a signal value to its configured action
+func (c *OpenADR) signalAction(name string, value float64) action {
+	switch name {
+	case signalSimple:
+		return c.levels[int(value)]
+
+	case signalPrice:
+		for _, p := range c.prices {
+			if value > p.Above {
+				a, _ := actionString(p.Action)
+				return a
+			}
+		}
+	}
+
+	return actionNone
+}
+
+// currentAction returns the most restrictive action of all active opted-in events
+func (c *OpenADR) currentAction(now time.Time) action {
+	res := actionNone
+
+	for _, ev := range c.events {
+		if c.opts[ev.key()] != optIn {
+			continue
+		}
+
+		for _, name := range []string{signalSimple, signalPrice} {
+			if value, ok := ev.Value(name, now); ok {
+				if a := c.signalAction(name, value); a > res {
+					res = a
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+// apply changes the loadpoint behaviour when the action changes
+func (c *OpenADR) apply(a action) {
+	if a == c.action {
+		return
+	}
+
+	c.log.INFO.Printf("action: %s", a)
+
+	for id, lp := range c.site.Loadpoints() {
+		// revert previous action
+		switch c.action {
+		case actionReduce:
+			lp.SetRemoteMaxCurrent(source, loadpoint.RemoteNoLimit)
+		case actionPV:
+			// restore unless changed during the event
+			if mode, ok := c.modes[id]; ok && lp.GetMode() == api.ModePV {
+				lp.SetMode(mode)
+			}
+		case actionPause:
+			// removes only the openadr demand, other sources' demands remain
+			lp.RemoteControl(source, loadpoint.RemoteEnable)
+		}
+
+		switch a {
+		case actionReduce:
+			lp.SetRemoteMaxCurrent(source, c.reduceCurrent)
+		case actionPV:
+			if mode := lp.GetMode(); mode == api.ModeNow || mode == api.ModeMinPV {
+				c.modes[id] = mode
+				lp.SetMode(api.ModePV)
+			}
+		case actionPause:
+			lp.RemoteControl(source, loadpoint.RemoteHardDisable)
+		}
+	}
+
+	if c.action == actionPV {
+		c.modes = make(map[int]api.ChargeMode)
+	}
+
+	c.action = a
+}
+
+// update registers the VEN if required, polls the VTN and applies the current action
+func (c *OpenADR) update() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	defer func() {
+		c.apply(c.currentAction(c.clock.Now()))
+	}()
+
+	if !c.ven.Registered() {
+		if err := c.ven.Register(); err != nil {
+			return fmt.Errorf("register: %w", err)
+		}
+
+		c.log.INFO.Printf("registered as ven %s with vtn %s", c.ven.venID, c.ven.vtnID)
+
+		if c.ven.pollFreq > 0 {
+			c.interval = c.ven.pollFreq
+		}
+
+		d, err := c.ven.RequestEvents()
+		if err != nil {
+			return fmt.Errorf("request events: %w", err)
+		}
+
+		if err := c.distribute(d); err != nil {
+			return fmt.Errorf("created event: %w", err)
+		}
+	}
+
+	res, err := c.ven.Poll()
+	if err != nil {
+		return fmt.Errorf("poll: %w", err)
+	}
+
+	switch {
+	case res.DistributeEvent != nil:
+		if err := c.distribute(res.DistributeEvent); err != nil {
+			return fmt.Errorf("created event: %w", err)
+		}
+
+	case res.RequestReregistration != nil:
+		c.log.INFO.Println("reregistration requested")
+		c.ven.registrationID = ""
+
+	case res.CancelPartyRegistration != nil:
+		c.log.WARN.Println("registration cancelled by vtn")
+		if err := c.ven.Deregister(res.CancelPartyRegistration.RequestID); err != nil {
+			return fmt.Errorf("cancel registration: %w", err)
+		}
+		c.events = make(map[string]*event)
+	}
+
+	return nil
+}
+
+// Run executes the OpenADR VEN
+func (c *OpenADR) Run() {
+	for {
+		if err := c.update(); err != nil {
+			c.log.ERROR.Println(err)
+		}
+
+		c.clock.Sleep(c.interval)
+	}
+}
@@ -0,0 +1,189 @@
+package openadr
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vtn is a minimal OpenADR 2.0b VTN stub
+type vtn struct {
+	mu        sync.Mutex
+	events    []OadrEvent
+	responses []EventResponse
+	polled    bool
+}
+
+func (v *vtn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	var req Payload
+	if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var res SignedObject
+	msg := req.SignedObject
+
+	switch {
+	case strings.HasSuffix(r.URL.Path, serviceRegisterParty) && msg.QueryRegistration != nil:
+		res.CreatedPartyRegistration = &CreatedPartyRegistration{
+			EiResponse: EiResponse{ResponseCode: responseOK, RequestID: msg.QueryRegistration.RequestID},
+			VtnID:      "vtn",
+		}
+
+	case strings.HasSuffix(r.URL.Path, serviceRegisterParty) && msg.CreatePartyRegistration != nil:
+		res.CreatedPartyRegistration = &CreatedPartyRegistration{
+			EiResponse:     EiResponse{ResponseCode: responseOK, RequestID: msg.CreatePartyRegistration.RequestID},
+			RegistrationID: "reg",
+			VenID:          "ven",
+			VtnID:          "vtn",
+			PollFreq:       &PollFreq{Duration: "PT10S"},
+		}
+
+	case strings.HasSuffix(r.URL.Path, serviceEvent) && msg.RequestEvent != nil:
+		res.DistributeEvent = &DistributeEvent{
+			RequestID: "req",
+			VtnID:     "vtn",
+			Events:    v.events,
+		}
+
+	case strings.HasSuffix(r.URL.Path, serviceEvent) && msg.CreatedEvent != nil:
+		v.responses = append(v.responses, msg.CreatedEvent.EiCreatedEvent.EventResponses.EventResponse...)
+		res.Response = &Response{EiResponse: EiResponse{ResponseCode: responseOK}}
+
+	case strings.HasSuffix(r.URL.Path, servicePoll) && msg.Poll != nil:
+		v.polled = true
+		res.Response = &Response{EiResponse: EiResponse{ResponseCode: responseOK}}
+
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	_ = xml.NewEncoder(w).Encode(Payload{SignedObject: res})
+}
+
+func simpleEvent(id string, start time.Time, test bool, levels ...float64) OadrEvent {
+	e := OadrEvent{
+		EiEvent: EiEvent{
+			EventDescriptor: EventDescriptor{
+				EventID:       id,
+				MarketContext: "http://market",
+				EventStatus:   "far",
+			},
+			ActivePeriod: ActivePeriod{
+				Start:    start.Format(time.RFC3339),
+				Duration: "PT2H",
+			},
+		},
+		ResponseRequired: "always",
+	}
+
+	if test {
+		e.EiEvent.EventDescriptor.TestEvent = "true"
+	}
+
+	sig := EventSignal{SignalName: signalSimple, SignalType: "level", SignalID: "1"}
+	for _, level := range levels {
+		sig.Intervals = append(sig.Intervals, Interval{Duration: "PT1H", Payload: SignalPayload{Value: level}})
+	}
+	e.EiEvent.Signals = []EventSignal{sig}
+
+	return e
+}
+
+func TestOpenADR(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lp := loadpoint.NewMockAPI(ctrl)
+
+	st := site.NewMockAPI(ctrl)
+	st.EXPECT().Loadpoints().Return([]loadpoint.API{lp}).AnyTimes()
+
+	clock := clock.NewMock()
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock.Set(now)
+
+	stub := &vtn{
+		events: []OadrEvent{
+			simpleEvent("ev1", now, false, 1, 3),
+			simpleEvent("test", now, true, 3),
+		},
+	}
+
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	c, err := New(map[string]interface{}{"uri": srv.URL}, st)
+	require.NoError(t, err)
+	c.clock = clock
+
+	// first interval reduces the current cap
+	lp.EXPECT().SetRemoteMaxCurrent(source, 6.0)
+	require.NoError(t, c.update())
+
+	assert.True(t, stub.polled)
+	assert.Equal(t, 10*time.Second, c.interval)
+	assert.Equal(t, []EventResponse{
+		{ResponseCode: responseOK, RequestID: "req", QualifiedEventID: QualifiedEventID{EventID: "ev1"}, OptType: optIn},
+		{ResponseCode: responseOK, RequestID: "req", QualifiedEventID: QualifiedEventID{EventID: "test"}, OptType: optOut},
+	}, stub.responses)
+
+	// second interval pauses charging
+	clock.Add(time.Hour)
+	lp.EXPECT().SetRemoteMaxCurrent(source, loadpoint.RemoteNoLimit)
+	lp.EXPECT().RemoteControl(source, loadpoint.RemoteHardDisable)
+	require.NoError(t, c.update())
+
+	// event ended
+	clock.Add(time.Hour)
+	lp.EXPECT().RemoteControl(source, loadpoint.RemoteEnable)
+	require.NoError(t, c.update())
+
+	// opt status is only reported once
+	assert.Len(t, stub.responses, 2)
+}
+
+func TestOpenADRPriceSignal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lp := loadpoint.NewMockAPI(ctrl)
+
+	st := site.NewMockAPI(ctrl)
+	st.EXPECT().Loadpoints().Return([]loadpoint.API{lp}).AnyTimes()
+
+	c, err := New(map[string]interface{}{
+		"uri": "http://vtn",
+		"prices": []map[string]interface{}{
+			{"above": 0.3, "action": "pv"},
+			{"above": 0.5, "action": "pause"},
+		},
+	}, st)
+	require.NoError(t, err)
+
+	assert.Equal(t, actionNone, c.signalAction(signalPrice, 0.2))
+	assert.Equal(t, actionPV, c.signalAction(signalPrice, 0.4))
+	assert.Equal(t, actionPause, c.signalAction(signalPrice, 0.6))
+
+	// pv mode is restored after the event
+	lp.EXPECT().GetMode().Return(api.ModeNow)
+	lp.EXPECT().SetMode(api.ModePV)
+	c.apply(actionPV)
+
+	lp.EXPECT().GetMode().Return(api.ModePV)
+	lp.EXPECT().SetMode(api.ModeNow)
+	c.apply(actionNone)
+}
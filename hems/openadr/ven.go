@@ -0,0 +1,189 @@
+package openadr
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dylanmei/iso8601"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/google/uuid"
+)
+
+// OpenADR 2.0b simple HTTP services
+const (
+	serviceRegisterParty = "EiRegisterParty"
+	serviceEvent         = "EiEvent"
+	servicePoll          = "OadrPoll"
+)
+
+// ven is an OpenADR 2.0b VEN using the simple HTTP pull model
+type ven struct {
+	*request.Helper
+	uri  string
+	name string
+
+	venID          string
+	registrationID string
+	vtnID          string
+	pollFreq       time.Duration
+}
+
+func newVEN(log *util.Logger, uri, name, venID string) *ven {
+	return &ven{
+		Helper: request.NewHelper(log),
+		uri:    strings.TrimSuffix(uri, "/") + "/OpenADR2/Simple/2.0b/",
+		name:   name,
+		venID:  venID,
+	}
+}
+
+// send posts the message to the VTN service and returns the VTN's response
+func (v *ven) send(service string, msg SignedObject) (SignedObject, error) {
+	var res Payload
+
+	body, err := xml.Marshal(Payload{SignedObject: msg})
+	if err != nil {
+		return res.SignedObject, err
+	}
+
+	req, err := request.New(http.MethodPost, v.uri+service, bytes.NewReader(append([]byte(xml.Header), body...)), map[string]string{
+		"Content-Type": "application/xml",
+	})
+	if err != nil {
+		return res.SignedObject, err
+	}
+
+	b, err := v.DoBody(req)
+	if err == nil && len(b) > 0 {
+		err = xml.Unmarshal(b, &res)
+	}
+
+	return res.SignedObject, err
+}
+
+func responseError(res EiResponse) error {
+	if res.ResponseCode != responseOK {
+		return fmt.Errorf("vtn response %s: %s", res.ResponseCode, res.ResponseDescription)
+	}
+	return nil
+}
+
+// Registered returns true if the VEN is registered with the VTN
+func (v *ven) Registered() bool {
+	return v.registrationID != ""
+}
+
+// Register queries the VTN registration and registers the VEN
+func (v *ven) Register() error {
+	res, err := v.send(serviceRegisterParty, SignedObject{
+		QueryRegistration: &QueryRegistration{RequestID: uuid.NewString()},
+	})
+	if err != nil {
+		return err
+	}
+
+	if res.CreatedPartyRegistration == nil {
+		return fmt.Errorf("query registration: unexpected response")
+	}
+
+	res, err = v.send(serviceRegisterParty, SignedObject{
+		CreatePartyRegistration: &CreatePartyRegistration{
+			RequestID:     uuid.NewString(),
+			VenID:         v.venID,
+			ProfileName:   profileName,
+			TransportName: transportName,
+			VenName:       v.name,
+			HttpPullModel: true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	reg := res.CreatedPartyRegistration
+	if reg == nil {
+		return fmt.Errorf("create registration: unexpected response")
+	}
+
+	if err := responseError(reg.EiResponse); err != nil {
+		return err
+	}
+
+	if reg.RegistrationID == "" || reg.VenID == "" {
+		return fmt.Errorf("create registration: missing registration or ven id")
+	}
+
+	v.registrationID = reg.RegistrationID
+	v.venID = reg.VenID
+	v.vtnID = reg.VtnID
+
+	if reg.PollFreq != nil {
+		if d, err := iso8601.ParseDuration(reg.PollFreq.Duration); err == nil {
+			v.pollFreq = d
+		}
+	}
+
+	return nil
+}
+
+// Deregister acknowledges a cancelled registration
+func (v *ven) Deregister(requestID string) error {
+	v.registrationID = ""
+
+	_, err := v.send(serviceRegisterParty, SignedObject{
+		CanceledPartyRegistration: &CanceledPartyRegistration{
+			EiResponse: EiResponse{ResponseCode: responseOK, RequestID: requestID},
+			VenID:      v.venID,
+		},
+	})
+
+	return err
+}
+
+// RequestEvents requests all pending events from the VTN
+func (v *ven) RequestEvents() (*DistributeEvent, error) {
+	res, err := v.send(serviceEvent, SignedObject{
+		RequestEvent: &RequestEvent{EiRequestEvent{
+			RequestID: uuid.NewString(),
+			VenID:     v.venID,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.DistributeEvent == nil {
+		return nil, fmt.Errorf("request event: unexpected response")
+	}
+
+	return res.DistributeEvent, nil
+}
+
+// Poll polls the VTN for new messages
+func (v *ven) Poll() (SignedObject, error) {
+	return v.send(servicePoll, SignedObject{
+		Poll: &Poll{VenID: v.venID},
+	})
+}
+
+// CreatedEvent reports the VEN's opt status for the given events
+func (v *ven) CreatedEvent(requestID string, responses []EventResponse) error {
+	res, err := v.send(serviceEvent, SignedObject{
+		CreatedEvent: &CreatedEvent{EiCreatedEvent{
+			EiResponse:     EiResponse{ResponseCode: responseOK, RequestID: requestID},
+			EventResponses: EventResponses{responses},
+			VenID:          v.venID,
+		}},
+	})
+
+	if err == nil && res.Response != nil {
+		err = responseError(res.Response.EiResponse)
+	}
+
+	return err
+}
@@ -0,0 +1,142 @@
+package semp
+
+import (
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/soc"
+)
+
+// maxLatestEnd is the planning horizon for charging without deadline
+const maxLatestEnd = 24 * time.Hour
+
+// timeframe creates a timeframe relative to now
+func timeframe(start, end time.Duration, minEnergy, maxEnergy float64, minPower, maxPower int) Timeframe {
+	minE, maxE := int(minEnergy), int(maxEnergy)
+
+	return Timeframe{
+		EarliestStart:       int(start / time.Second),
+		LatestEnd:           int(math.Ceil(end.Seconds())),
+		MinEnergy:           &minE,
+		MaxEnergy:           &maxE,
+		MinPowerConsumption: &minPower,
+		MaxPowerConsumption: &maxPower,
+	}
+}
+
+// chargeDuration is the duration for charging energy (Wh) at power (W)
+func chargeDuration(energy, power float64) time.Duration {
+	return time.Duration(float64(time.Hour) * energy / power)
+}
+
+// minSocEnergy returns the energy (Wh) required for reaching the vehicle's min soc
+func minSocEnergy(lp loadpoint.API) float64 {
+	v := lp.GetVehicle()
+	minSoc := float64(lp.GetMinSoc())
+
+	if v == nil || minSoc == 0 {
+		return 0
+	}
+
+	vehicleSoc := lp.GetVehicleSoc()
+	if vehicleSoc >= minSoc {
+		return 0
+	}
+
+	return 1e3 * v.Capacity() * (minSoc - vehicleSoc) / 100 / soc.ChargeEfficiency
+}
+
+// timeframes creates the loadpoint's timeframes. Energy required for the min soc is requested
+// immediately. Target charging is requested according to the loadpoint's plan and
+// any other remaining energy is requested depending on the charge mode.
+func (s *SEMP) timeframes(lp loadpoint.API, now time.Time) []Timeframe {
+	mode := lp.GetMode()
+	status := lp.GetStatus()
+	charging := status == api.StatusC
+
+	if mode == api.ModeOff || !charging && status != api.StatusB {
+		return nil
+	}
+
+	maxPower := lp.GetMaxPower()
+	if maxPower <= 0 {
+		return nil
+	}
+
+	maxPowerConsumption := int(maxPower)
+	minPowerConsumption := int(lp.GetMinPower())
+	if mode == api.ModeNow {
+		minPowerConsumption = maxPowerConsumption
+	}
+
+	// remaining max energy demand in Wh
+	remaining := lp.GetRemainingEnergy()
+
+	// add 1kWh in case we're charging but battery claims full
+	if charging && remaining == 0 {
+		remaining = 1e3 // 1kWh
+	}
+
+	var res []Timeframe
+
+	// timeframes after offset are free
+	var offset time.Duration
+
+	// min soc
+	if energy := minSocEnergy(lp); energy > 0 {
+		offset = chargeDuration(energy, maxPower)
+		res = append(res, timeframe(0, offset, energy, energy, maxPowerConsumption, maxPowerConsumption))
+		remaining = math.Max(0, remaining-energy)
+	}
+
+	if remaining <= 0 {
+		return res
+	}
+
+	// target charging
+	if targetTime := lp.GetTargetTime(); targetTime.After(now.Add(offset)) {
+		_, plan, err := lp.GetPlan(targetTime, maxPower)
+		if err != nil {
+			s.log.ERROR.Println("plan:", err)
+		}
+
+		var planned bool
+		for _, slot := range plan {
+			start := slot.Start
+			if earliest := now.Add(offset); start.Before(earliest) {
+				start = earliest
+			}
+
+			if !slot.End.After(start) || remaining <= 0 {
+				continue
+			}
+
+			energy := math.Min(remaining, maxPower*slot.End.Sub(start).Hours())
+			res = append(res, timeframe(start.Sub(now), slot.End.Sub(now), energy, energy, minPowerConsumption, maxPowerConsumption))
+			remaining -= energy
+			planned = true
+		}
+
+		// no plan, charge until target time
+		if !planned {
+			res = append(res, timeframe(offset, targetTime.Sub(now), remaining, remaining, minPowerConsumption, maxPowerConsumption))
+		}
+
+		return res
+	}
+
+	// remaining demand
+	latestEnd := lp.GetRemainingDuration()
+	if mode == api.ModeMinPV || mode == api.ModePV || latestEnd <= 0 {
+		latestEnd = maxLatestEnd
+	}
+
+	minEnergy := remaining
+	if mode == api.ModePV {
+		minEnergy = 0
+	}
+
+	return append(res, timeframe(offset, offset+latestEnd, minEnergy, remaining, minPowerConsumption, maxPowerConsumption))
+}
@@ -0,0 +1,96 @@
+package semp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func energy(tf Timeframe) (int, int) {
+	return *tf.MinEnergy, *tf.MaxEnergy
+}
+
+func TestTimeframes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2023, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	s := &SEMP{log: util.NewLogger("foo")}
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetStatus().Return(api.StatusB).AnyTimes()
+	lp.EXPECT().GetMaxPower().Return(10e3).AnyTimes()
+	lp.EXPECT().GetMinPower().Return(1.4e3).AnyTimes()
+	lp.EXPECT().GetRemainingEnergy().Return(25e3).AnyTimes()
+
+	// pv mode without plan
+	lp.EXPECT().GetMode().Return(api.ModePV)
+	lp.EXPECT().GetVehicle().Return(nil)
+	lp.EXPECT().GetMinSoc().Return(0)
+	lp.EXPECT().GetTargetTime().Return(time.Time{})
+	lp.EXPECT().GetRemainingDuration().Return(time.Duration(0))
+
+	res := s.timeframes(lp, now)
+	assert.Len(t, res, 1)
+	assert.Equal(t, 0, res[0].EarliestStart)
+	assert.Equal(t, 24*3600, res[0].LatestEnd)
+	minE, maxE := energy(res[0])
+	assert.Equal(t, 0, minE)
+	assert.Equal(t, 25000, maxE)
+
+	// min soc and target plan
+	vehicle := mock.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Capacity().Return(45.0)
+
+	target := now.Add(13 * time.Hour)
+	lp.EXPECT().GetMode().Return(api.ModePV)
+	lp.EXPECT().GetVehicle().Return(vehicle)
+	lp.EXPECT().GetMinSoc().Return(20)
+	lp.EXPECT().GetVehicleSoc().Return(10.0)
+	lp.EXPECT().GetTargetTime().Return(target)
+	lp.EXPECT().GetPlan(target, 10e3).Return(2*time.Hour, api.Rates{
+		{Start: now.Add(8 * time.Hour), End: now.Add(9 * time.Hour)},
+		{Start: now.Add(10 * time.Hour), End: now.Add(11 * time.Hour)},
+	}, nil)
+
+	res = s.timeframes(lp, now)
+	assert.Len(t, res, 3)
+
+	// 4.5kWh / 90% = 5kWh min soc energy at 10kW
+	assert.Equal(t, 0, res[0].EarliestStart)
+	assert.Equal(t, 1800, res[0].LatestEnd)
+	minE, maxE = energy(res[0])
+	assert.Equal(t, 5000, minE)
+	assert.Equal(t, 5000, maxE)
+
+	// remaining 20kWh according to plan
+	assert.Equal(t, 8*3600, res[1].EarliestStart)
+	assert.Equal(t, 9*3600, res[1].LatestEnd)
+	minE, maxE = energy(res[1])
+	assert.Equal(t, 10000, minE)
+	assert.Equal(t, 10000, maxE)
+
+	assert.Equal(t, 10*3600, res[2].EarliestStart)
+	assert.Equal(t, 11*3600, res[2].LatestEnd)
+	minE, maxE = energy(res[2])
+	assert.Equal(t, 10000, minE)
+	assert.Equal(t, 10000, maxE)
+
+	// target without plan
+	lp.EXPECT().GetMode().Return(api.ModeNow)
+	lp.EXPECT().GetVehicle().Return(nil)
+	lp.EXPECT().GetMinSoc().Return(0)
+	lp.EXPECT().GetTargetTime().Return(target)
+	lp.EXPECT().GetPlan(target, 10e3).Return(time.Duration(0), nil, nil)
+
+	res = s.timeframes(lp, now)
+	assert.Len(t, res, 1)
+	assert.Equal(t, 0, res[0].EarliestStart)
+	assert.Equal(t, 13*3600, res[0].LatestEnd)
+	assert.Equal(t, 10000, *res[0].MinPowerConsumption)
+}
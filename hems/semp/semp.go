@@ -440,49 +440,10 @@ func (s *SEMP) allDeviceStatus() (res []DeviceStatus) {
 }
 
 func (s *SEMP) planningRequest(id int, lp loadpoint.API) (res PlanningRequest) {
-	mode := lp.GetMode()
-	charging := lp.GetStatus() == api.StatusC
-	connected := charging || lp.GetStatus() == api.StatusB
-
-	// remaining max demand duration in seconds
-	chargeRemainingDuration := lp.GetRemainingDuration()
-	latestEnd := int(chargeRemainingDuration / time.Second)
-	if mode == api.ModeMinPV || mode == api.ModePV || latestEnd <= 0 {
-		latestEnd = 24 * 3600
-	}
-
-	// remaining max energy demand in Wh
-	chargeRemainingEnergy := lp.GetRemainingEnergy()
-	maxEnergy := int(chargeRemainingEnergy)
-
-	// add 1kWh in case we're charging but battery claims full
-	if charging && maxEnergy == 0 {
-		maxEnergy = 1e3 // 1kWh
-	}
-
-	minEnergy := maxEnergy
-	if mode == api.ModePV {
-		minEnergy = 0
-	}
-
-	maxPowerConsumption := int(lp.GetMaxPower())
-	minPowerConsumption := int(lp.GetMinPower())
-	if mode == api.ModeNow {
-		minPowerConsumption = maxPowerConsumption
-	}
-
-	if mode != api.ModeOff && connected && maxEnergy > 0 {
-		res = PlanningRequest{
-			Timeframe: []Timeframe{{
-				DeviceID:            s.deviceID(id),
-				EarliestStart:       0,
-				LatestEnd:           latestEnd,
-				MinEnergy:           &minEnergy,
-				MaxEnergy:           &maxEnergy,
-				MaxPowerConsumption: &maxPowerConsumption,
-				MinPowerConsumption: &minPowerConsumption,
-			}},
-		}
+	// timeframes are derived from the current plan on each request and therefore follow plan changes
+	for _, tf := range s.timeframes(lp, time.Now()) {
+		tf.DeviceID = s.deviceID(id)
+		res.Timeframe = append(res.Timeframe, tf)
 	}
 
 	return res
@@ -17,10 +17,15 @@ var (
 
 	//go:embed i18n/*.toml
 	i18n embed.FS
+
+	//go:embed schema.json
+	schema []byte
 )
 
-// init loads embedded assets unless live assets are already loaded
+// init provides the config schema and loads embedded assets unless live assets are already loaded
 func init() {
+	cmd.ConfigSchema = schema
+
 	if !assets.Live() {
 		var err error
 
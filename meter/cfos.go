@@ -32,6 +32,12 @@ func NewCfosPowerBrainFromConfig(other map[string]interface{}) (api.Meter, error
 		ID: 1,
 	}
 
+	// modbus priority and frame delay are applied by the connection
+	other, err := modbus.ExtractSettings(other)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := util.DecodeOther(other, &cc); err != nil {
 		return nil, err
 	}
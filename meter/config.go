@@ -26,6 +26,15 @@ func (r meterRegistry) Get(name string) (func(map[string]interface{}) (api.Meter
 
 var registry meterRegistry = make(map[string]func(map[string]interface{}) (api.Meter, error))
 
+// Types returns the list of meter types
+func Types() []string {
+	var res []string
+	for typ := range registry {
+		res = append(res, typ)
+	}
+	return res
+}
+
 // NewFromConfig creates meter from configuration
 func NewFromConfig(typ string, other map[string]interface{}) (v api.Meter, err error) {
 	factory, err := registry.Get(strings.ToLower(typ))
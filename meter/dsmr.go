@@ -60,6 +60,8 @@ type Dsmr struct {
 	timeout time.Duration
 	frame   dsmr.Frame
 	updated time.Time
+	conn    net.Conn
+	closed  chan struct{}
 }
 
 var (
@@ -96,6 +98,7 @@ func NewDsmr(uri, energy string, timeout time.Duration) (api.Meter, error) {
 		addr:    uri,
 		energy:  energy,
 		timeout: timeout,
+		closed:  make(chan struct{}),
 	}
 
 	done := make(chan struct{}, 1)
@@ -156,6 +159,12 @@ func (m *Dsmr) run(conn net.Conn, done chan struct{}) {
 	reader := bufio.NewReader(conn)
 
 	for {
+		select {
+		case <-m.closed:
+			return
+		default:
+		}
+
 		if conn == nil {
 			var err error
 			conn, err = m.connect()
@@ -163,7 +172,10 @@ func (m *Dsmr) run(conn net.Conn, done chan struct{}) {
 				handle("connect", err)
 				sleep := backoff.NextBackOff().Truncate(time.Second)
 				log.DEBUG.Printf("next attempt after: %v", sleep)
-				time.Sleep(sleep)
+				select {
+				case <-m.closed:
+				case <-time.After(sleep):
+				}
 				continue
 			}
 
@@ -230,9 +242,36 @@ func (m *Dsmr) connect() (net.Conn, error) {
 		return nil, err
 	}
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	select {
+	case <-m.closed:
+		conn.Close()
+		return nil, net.ErrClosed
+	default:
+	}
+
+	m.conn = conn
+
 	return conn, nil
 }
 
+var _ io.Closer = (*Dsmr)(nil)
+
+// Close implements the io.Closer interface
+func (m *Dsmr) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	close(m.closed)
+	if m.conn != nil {
+		_ = m.conn.Close() // stops pending reads
+	}
+
+	return nil
+}
+
 func (m *Dsmr) get(id string) (float64, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -67,6 +67,13 @@ func NewModbusFromConfig(other map[string]interface{}) (api.Meter, error) {
 		conn.Delay(cc.Delay)
 	}
 
+	// set non-default inter-frame delay of the shared bus
+	if cc.FrameDelay > 0 {
+		conn.FrameDelay(cc.FrameDelay)
+	}
+
+	conn.Priority(cc.Priority)
+
 	// set non-default timeout
 	if cc.Timeout > 0 {
 		conn.Timeout(cc.Timeout)
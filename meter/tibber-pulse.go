@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"sync"
 	"time"
@@ -27,6 +28,7 @@ type Tibber struct {
 	log     *util.Logger
 	updated time.Time
 	live    tibber.LiveMeasurement
+	client  *graphql.SubscriptionClient
 }
 
 func NewTibberFromConfig(other map[string]interface{}) (api.Meter, error) {
@@ -90,6 +92,8 @@ func NewTibberFromConfig(other map[string]interface{}) (api.Meter, error) {
 		WithRetryTimeout(0).
 		WithLog(t.log.TRACE.Println)
 
+	t.client = client
+
 	// run the client
 	done := make(chan error)
 	go t.subscribe(client, cc.HomeID, done)
@@ -145,6 +149,13 @@ func (t *Tibber) subscribe(client *graphql.SubscriptionClient, homeID string, do
 	}()
 }
 
+var _ io.Closer = (*Tibber)(nil)
+
+// Close implements the io.Closer interface
+func (t *Tibber) Close() error {
+	return t.client.Close()
+}
+
 // CurrentPower implements the api.Meter interface
 func (t *Tibber) CurrentPower() (float64, error) {
 	t.mu.Lock()
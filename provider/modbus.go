@@ -70,6 +70,13 @@ func NewModbusFromConfig(other map[string]interface{}) (Provider, error) {
 		conn.Delay(cc.Delay)
 	}
 
+	// set non-default inter-frame delay of the shared bus
+	if cc.FrameDelay > 0 {
+		conn.FrameDelay(cc.FrameDelay)
+	}
+
+	conn.Priority(cc.Priority)
+
 	// set non-default connect delay
 	if cc.ConnectDelay > 0 {
 		conn.ConnectDelay(cc.ConnectDelay)
@@ -3,6 +3,9 @@ package push
 import (
 	"fmt"
 	"strings"
+
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
 )
 
 // Messenger implements message sending
@@ -10,6 +13,26 @@ type Messenger interface {
 	Send(title, msg string)
 }
 
+// Message is a rendered message including the event context
+type Message struct {
+	Event          string                 `json:"event"`
+	Loadpoint      int                    `json:"loadpoint,omitempty"` // loadpoint id starting at 1
+	LoadpointTitle string                 `json:"loadpointTitle,omitempty"`
+	Title          string                 `json:"title"`
+	Msg            string                 `json:"message"`
+	Values         map[string]interface{} `json:"values"` // template values
+}
+
+// Controller is implemented by messengers accepting commands for controlling the site
+type Controller interface {
+	Control(site site.API, cache *util.Cache)
+}
+
+// EventMessenger implements structured message sending. It is preferred over Messenger if implemented.
+type EventMessenger interface {
+	SendEvent(m Message)
+}
+
 type senderRegistry map[string]func(map[string]interface{}) (Messenger, error)
 
 func (r senderRegistry) Add(name string, factory func(map[string]interface{}) (Messenger, error)) {
@@ -4,15 +4,22 @@ import (
 	"fmt"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/sprig/v3"
+	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/util"
+	"golang.org/x/exp/slices"
 )
 
+// RuleInterval is the evaluation interval of push rules
+const RuleInterval = 10 * time.Second
+
 // Event is a notification event
 type Event struct {
-	Loadpoint *int // optional loadpoint id
-	Event     string
+	Loadpoint  *int // optional loadpoint id
+	Event      string
+	Attributes map[string]any // optional event attributes
 }
 
 // EventTemplateConfig is the push message configuration for an event
@@ -20,40 +27,69 @@ type EventTemplateConfig struct {
 	Title, Msg string
 }
 
+// namedMessenger is a messenger identified by name
+type namedMessenger struct {
+	name string
+	Messenger
+}
+
 // Hub subscribes to event notifications and sends them to client devices
 type Hub struct {
+	log         *util.Logger
+	clock       clock.Clock
 	definitions map[string]EventTemplateConfig
-	sender      []Messenger
+	rules       []*rule
+	sender      []namedMessenger
 	cache       *util.Cache
 }
 
-// NewHub creates push hub with definitions and receiver
-func NewHub(cc map[string]EventTemplateConfig, cache *util.Cache) (*Hub, error) {
+func validateTemplate(tmpl string) error {
+	_, err := template.New("out").Funcs(sprig.TxtFuncMap()).Parse(tmpl)
+	return err
+}
+
+// NewHub creates push hub with event and rule definitions and receiver
+func NewHub(cc map[string]EventTemplateConfig, rules map[string]RuleConfig, cache *util.Cache) (*Hub, error) {
 	// instantiate all event templates
 	for k, v := range cc {
-		if _, err := template.New("out").Funcs(sprig.TxtFuncMap()).Parse(v.Title); err != nil {
+		if err := validateTemplate(v.Title); err != nil {
 			return nil, fmt.Errorf("invalid event title: %s (%w)", k, err)
 		}
-		if _, err := template.New("out").Funcs(sprig.TxtFuncMap()).Parse(v.Msg); err != nil {
+		if err := validateTemplate(v.Msg); err != nil {
 			return nil, fmt.Errorf("invalid event message: %s (%w)", k, err)
 		}
 	}
 
 	h := &Hub{
+		log:         util.NewLogger("push"),
+		clock:       clock.New(),
 		definitions: cc,
 		cache:       cache,
 	}
 
+	for k, v := range rules {
+		r, err := newRule(k, v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule: %s (%w)", k, err)
+		}
+		h.rules = append(h.rules, r)
+	}
+
+	// evaluate in stable order
+	slices.SortFunc(h.rules, func(a, b *rule) bool {
+		return a.name < b.name
+	})
+
 	return h, nil
 }
 
-// Add adds a sender to the list of senders
-func (h *Hub) Add(sender Messenger) {
-	h.sender = append(h.sender, sender)
+// Add adds a named sender to the list of senders
+func (h *Hub) Add(name string, sender Messenger) {
+	h.sender = append(h.sender, namedMessenger{name: name, Messenger: sender})
 }
 
-// apply applies the event template to the content to produce the actual message
-func (h *Hub) apply(ev Event, tmpl string) (string, error) {
+// attributes returns the cached values of the event's loadpoint and the event attributes
+func (h *Hub) attributes(ev Event) map[string]interface{} {
 	attr := make(map[string]interface{})
 
 	// loadpoint id
@@ -68,12 +104,126 @@ func (h *Hub) apply(ev Event, tmpl string) (string, error) {
 		}
 	}
 
-	return util.ReplaceFormatted(tmpl, attr)
+	// event attributes
+	for k, v := range ev.Attributes {
+		attr[k] = v
+	}
+
+	return attr
+}
+
+// ruleAttributes returns the cached values of the rule's loadpoint and the current value
+func (h *Hub) ruleAttributes(r *rule, val any) map[string]interface{} {
+	attr := make(map[string]interface{})
+
+	if r.Loadpoint > 0 {
+		attr["loadpoint"] = r.Loadpoint
+	}
+
+	for _, p := range h.cache.All() {
+		if p.Loadpoint == nil || r.Loadpoint > 0 && *p.Loadpoint == r.Loadpoint-1 {
+			attr[p.Key] = p.Val
+		}
+	}
+
+	attr["rule"] = r.name
+	attr["value"] = val
+
+	return attr
+}
+
+// message applies the title and message templates to the attributes to produce the actual message
+func message(event string, loadpoint *int, attr map[string]interface{}, title, msg string) (Message, error) {
+	res := Message{
+		Event:  event,
+		Values: attr,
+	}
+
+	if loadpoint != nil {
+		res.Loadpoint = *loadpoint + 1
+		res.LoadpointTitle, _ = attr["title"].(string)
+	}
+
+	var err error
+	if res.Title, err = util.ReplaceFormatted(title, attr); err != nil {
+		return res, fmt.Errorf("invalid title template: %w", err)
+	}
+
+	if res.Msg, err = util.ReplaceFormatted(msg, attr); err != nil {
+		return res, fmt.Errorf("invalid message template: %w", err)
+	}
+
+	return res, nil
+}
+
+// send sends the message to all or the selected senders
+func (h *Hub) send(m Message, names []string) {
+	for _, sender := range h.sender {
+		if len(names) > 0 && !slices.Contains(names, sender.name) {
+			continue
+		}
+
+		if em, ok := sender.Messenger.(EventMessenger); ok {
+			go em.SendEvent(m)
+		} else {
+			go sender.Send(m.Title, m.Msg)
+		}
+	}
+}
+
+// evaluate evaluates all rules against the cached values
+func (h *Hub) evaluate() {
+	now := h.clock.Now()
+
+	for _, r := range h.rules {
+		p := h.cache.Get(r.param().UniqueID())
+
+		if !r.evaluate(p.Val, p.Key != "", now) {
+			continue
+		}
+
+		var lp *int
+		if r.Loadpoint > 0 {
+			lp = r.param().Loadpoint
+		}
+
+		m, err := message(r.name, lp, h.ruleAttributes(r, p.Val), r.Title, r.Msg)
+		if err != nil {
+			h.log.ERROR.Printf("rule %s: %v", r.name, err)
+			continue
+		}
+
+		if strings.TrimSpace(m.Msg) == "" {
+			h.log.DEBUG.Printf("did not send empty message template for rule %s", r.name)
+			continue
+		}
+
+		h.send(m, r.Messengers)
+	}
+}
+
+// RunRules periodically evaluates the rules
+func (h *Hub) RunRules() {
+	for _, r := range h.rules {
+		for _, name := range r.Messengers {
+			if !slices.ContainsFunc(h.sender, func(s namedMessenger) bool { return s.name == name }) {
+				h.log.WARN.Printf("rule %s: unknown messenger: %s", r.name, name)
+			}
+		}
+	}
+
+	for range h.clock.Tick(RuleInterval) {
+		h.evaluate()
+	}
 }
 
 // Run is the Hub's main publishing loop
 func (h *Hub) Run(events <-chan Event, valueChan chan util.Param) {
-	log := util.NewLogger("push")
+	log := h.log
+
+	if len(h.rules) > 0 && len(h.sender) > 0 {
+		go h.RunRules()
+	}
 
 	for ev := range events {
 		if len(h.sender) == 0 {
@@ -90,24 +240,16 @@ func (h *Hub) Run(events <-chan Event, valueChan chan util.Param) {
 		valueChan <- util.Param{Val: flushC}
 		<-flushC
 
-		title, err := h.apply(ev, definition.Title)
+		m, err := message(ev.Event, ev.Loadpoint, h.attributes(ev), definition.Title, definition.Msg)
 		if err != nil {
-			log.ERROR.Printf("invalid title template for %s: %v", ev.Event, err)
+			log.ERROR.Printf("%s: %v", ev.Event, err)
 			continue
 		}
 
-		msg, err := h.apply(ev, definition.Msg)
-		if err != nil {
-			log.ERROR.Printf("invalid message template for %s: %v", ev.Event, err)
-			continue
-		}
-
-		for _, sender := range h.sender {
-			if strings.TrimSpace(msg) != "" {
-				go sender.Send(title, msg)
-			} else {
-				log.DEBUG.Printf("did not send empty message template for %s: %v", ev.Event, err)
-			}
+		if strings.TrimSpace(m.Msg) != "" {
+			h.send(m, nil)
+		} else {
+			log.DEBUG.Printf("did not send empty message template for %s: %v", ev.Event, err)
 		}
 	}
 }
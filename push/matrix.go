@@ -0,0 +1,107 @@
+package push
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+func init() {
+	registry.Add("matrix", NewMatrixFromConfig)
+}
+
+// Matrix implements the Matrix client-server api messenger
+type Matrix struct {
+	*request.Helper
+	log   *util.Logger
+	uri   string
+	room  string
+	token string
+	txn   atomic.Uint64
+}
+
+// NewMatrixFromConfig creates new Matrix messenger
+func NewMatrixFromConfig(other map[string]interface{}) (Messenger, error) {
+	var cc struct {
+		URI   string // homeserver
+		Token string
+		Room  string // room id
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.URI == "" || cc.Token == "" {
+		return nil, errors.New("missing uri or token")
+	}
+
+	if cc.Room == "" {
+		return nil, errors.New("missing room")
+	}
+
+	log := util.NewLogger("matrix").Redact(cc.Token)
+
+	m := &Matrix{
+		Helper: request.NewHelper(log),
+		log:    log,
+		uri:    strings.TrimSuffix(util.DefaultScheme(cc.URI, "https"), "/"),
+		room:   cc.Room,
+		token:  cc.Token,
+	}
+
+	return m, nil
+}
+
+// send sends a message event to the room
+func (m *Matrix) send(title, msg string) error {
+	text := msg
+	formatted := htmlMessage(msg)
+	if title != "" {
+		text = title + "\n" + msg
+		formatted = "<b>" + html.EscapeString(title) + "</b><br>\n" + formatted
+	}
+
+	data := struct {
+		MsgType       string `json:"msgtype"`
+		Body          string `json:"body"`
+		Format        string `json:"format"`
+		FormattedBody string `json:"formatted_body"`
+	}{
+		MsgType:       "m.text",
+		Body:          text,
+		Format:        "org.matrix.custom.html",
+		FormattedBody: formatted,
+	}
+
+	// transaction ids must be unique per access token
+	txn := fmt.Sprintf("evcc-%d-%d", time.Now().UnixNano(), m.txn.Add(1))
+	uri := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s", m.uri, url.PathEscape(m.room), txn)
+
+	req, err := request.New(http.MethodPut, uri, request.MarshalJSON(data), map[string]string{
+		"Authorization": "Bearer " + m.token,
+		"Content-Type":  request.JSONContent,
+		"Accept":        request.JSONContent,
+	})
+
+	if err == nil {
+		_, err = m.DoBody(req)
+	}
+
+	return err
+}
+
+// Send sends to the room
+func (m *Matrix) Send(title, msg string) {
+	if err := m.send(title, msg); err != nil {
+		m.log.ERROR.Print(err)
+	}
+}
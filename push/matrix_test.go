@@ -0,0 +1,41 @@
+package push
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrix(t *testing.T) {
+	var (
+		path, auth string
+		res        map[string]string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, auth = r.URL.Path, r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&res)
+		_, _ = w.Write([]byte(`{"event_id":"$1"}`))
+	}))
+	defer srv.Close()
+
+	m, err := NewMatrixFromConfig(map[string]interface{}{
+		"uri":   srv.URL,
+		"token": "token",
+		"room":  "!room:example.com",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, m.(*Matrix).send("Charge started", "Started <pv>"))
+
+	assert.True(t, strings.HasPrefix(path, "/_matrix/client/v3/rooms/!room:example.com/send/m.room.message/"), path)
+	assert.Equal(t, "Bearer token", auth)
+	assert.Equal(t, "m.text", res["msgtype"])
+	assert.Equal(t, "Charge started\nStarted <pv>", res["body"])
+	assert.Equal(t, "<b>Charge started</b><br>\nStarted &lt;pv&gt;", res["formatted_body"])
+}
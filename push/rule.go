@@ -0,0 +1,152 @@
+package push
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+)
+
+// RuleConfig is the push message configuration for a rule over a published value
+type RuleConfig struct {
+	Value      string        // published value, e.g. batterySoc
+	Loadpoint  int           // optional loadpoint of the value, starting at 1
+	Above      *float64      // condition: value above threshold
+	Below      *float64      // condition: value below threshold
+	Equals     *string       // condition: formatted value equal
+	Hold       time.Duration // condition must hold for this duration before sending
+	Hysteresis float64       // value must return by hysteresis before sending again
+	Cooldown   time.Duration // minimum interval between messages
+	Title, Msg string
+	Messengers []string // messenger names, all if empty
+}
+
+// rule is the evaluation state of a rule
+type rule struct {
+	RuleConfig
+	name   string
+	since  time.Time // condition met since
+	active bool      // message sent, waiting for condition to clear
+	sent   time.Time // last message
+}
+
+func newRule(name string, cc RuleConfig) (*rule, error) {
+	if cc.Value == "" {
+		return nil, errors.New("missing value")
+	}
+
+	if cc.Above == nil && cc.Below == nil && cc.Equals == nil {
+		return nil, errors.New("missing condition: need above, below or equals")
+	}
+
+	if cc.Hysteresis < 0 {
+		return nil, errors.New("invalid hysteresis")
+	}
+
+	for _, tmpl := range []string{cc.Title, cc.Msg} {
+		if err := validateTemplate(tmpl); err != nil {
+			return nil, err
+		}
+	}
+
+	return &rule{RuleConfig: cc, name: name}, nil
+}
+
+// param returns the cache parameter evaluated by the rule
+func (r *rule) param() util.Param {
+	p := util.Param{Key: r.Value}
+	if r.Loadpoint > 0 {
+		lp := r.Loadpoint - 1
+		p.Loadpoint = &lp
+	}
+	return p
+}
+
+// number converts a published value to float
+func number(val any) (float64, bool) {
+	switch v := val.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case time.Duration:
+		return v.Seconds(), true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+// matches checks if the value meets all conditions of the rule. Offset widens numeric conditions for hysteresis.
+func (r *rule) matches(val any, offset float64) bool {
+	if r.Equals != nil && fmt.Sprintf("%v", val) != *r.Equals {
+		return false
+	}
+
+	if r.Above == nil && r.Below == nil {
+		return true
+	}
+
+	f, ok := number(val)
+	if !ok {
+		return false
+	}
+
+	if r.Above != nil && f <= *r.Above-offset {
+		return false
+	}
+
+	if r.Below != nil && f >= *r.Below+offset {
+		return false
+	}
+
+	return true
+}
+
+// evaluate updates the rule state with the current value and returns true if a message should be sent
+func (r *rule) evaluate(val any, ok bool, now time.Time) bool {
+	if !ok {
+		r.since = time.Time{}
+		return false
+	}
+
+	if r.active {
+		// condition must clear by hysteresis before the rule can trigger again
+		if !r.matches(val, r.Hysteresis) {
+			r.active = false
+			r.since = time.Time{}
+		}
+		return false
+	}
+
+	if !r.matches(val, 0) {
+		r.since = time.Time{}
+		return false
+	}
+
+	if r.since.IsZero() {
+		r.since = now
+	}
+
+	if now.Sub(r.since) < r.Hold {
+		return false
+	}
+
+	if !r.sent.IsZero() && now.Sub(r.sent) < r.Cooldown {
+		return false
+	}
+
+	r.active = true
+	r.sent = now
+
+	return true
+}
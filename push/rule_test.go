@@ -0,0 +1,99 @@
+package push
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMessenger struct {
+	msgs chan string
+}
+
+func (m *testMessenger) Send(title, msg string) {
+	m.msgs <- msg
+}
+
+func TestRuleHoldHysteresisCooldown(t *testing.T) {
+	above := 10e3
+	r, err := newRule("grid", RuleConfig{
+		Value:      "gridPower",
+		Above:      &above,
+		Hold:       5 * time.Minute,
+		Hysteresis: 1e3,
+		Cooldown:   time.Hour,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	// hold
+	assert.False(t, r.evaluate(12e3, true, now))
+	assert.False(t, r.evaluate(12e3, true, now.Add(4*time.Minute)))
+	assert.True(t, r.evaluate(12e3, true, now.Add(5*time.Minute)))
+	assert.False(t, r.evaluate(12e3, true, now.Add(10*time.Minute)))
+
+	// hysteresis
+	assert.False(t, r.evaluate(9.5e3, true, now.Add(11*time.Minute)))
+	assert.True(t, r.active)
+	assert.False(t, r.evaluate(8e3, true, now.Add(12*time.Minute)))
+	assert.False(t, r.active)
+
+	// cooldown
+	assert.False(t, r.evaluate(12e3, true, now.Add(20*time.Minute)))
+	assert.False(t, r.evaluate(12e3, true, now.Add(30*time.Minute)))
+	assert.True(t, r.evaluate(12e3, true, now.Add(65*time.Minute)))
+
+	// missing value resets hold
+	assert.False(t, r.evaluate(8e3, true, now.Add(70*time.Minute)))
+	assert.False(t, r.evaluate(12e3, true, now.Add(3*time.Hour)))
+	assert.False(t, r.evaluate(nil, false, now.Add(3*time.Hour+time.Minute)))
+	assert.False(t, r.evaluate(12e3, true, now.Add(3*time.Hour+4*time.Minute)))
+}
+
+func TestRuleEquals(t *testing.T) {
+	val := "true"
+	r, err := newRule("connected", RuleConfig{Value: "connected", Equals: &val})
+	require.NoError(t, err)
+
+	now := time.Now()
+	assert.True(t, r.evaluate(true, true, now))
+	assert.False(t, r.evaluate(true, true, now))
+	assert.False(t, r.evaluate(false, true, now))
+	assert.True(t, r.evaluate(true, true, now))
+
+	_, err = newRule("invalid", RuleConfig{Value: "connected"})
+	assert.Error(t, err)
+}
+
+func TestHubRules(t *testing.T) {
+	below := 20.0
+	cache := util.NewCache()
+
+	h, err := NewHub(nil, map[string]RuleConfig{
+		"battery": {Value: "batterySoc", Below: &below, Msg: "battery at ${value}%", Messengers: []string{"a"}},
+		"soc":     {Value: "vehicleSoc", Loadpoint: 1, Below: &below, Msg: "lp ${loadpoint}: ${value}%"},
+	}, cache)
+	require.NoError(t, err)
+
+	h.clock = clock.NewMock()
+
+	a := &testMessenger{make(chan string, 2)}
+	b := &testMessenger{make(chan string, 2)}
+	h.Add("a", a)
+	h.Add("b", b)
+
+	lp := 0
+	cache.Add("batterySoc", util.Param{Key: "batterySoc", Val: 15.0})
+	cache.Add("0.vehicleSoc", util.Param{Loadpoint: &lp, Key: "vehicleSoc", Val: 10.0})
+
+	h.evaluate()
+
+	msgs := []string{<-a.msgs, <-a.msgs}
+	assert.ElementsMatch(t, []string{"battery at 15%", "lp 1: 10%"}, msgs)
+	assert.Equal(t, "lp 1: 10%", <-b.msgs)
+}
@@ -0,0 +1,232 @@
+package push
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"html"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+func init() {
+	registry.Add("smtp", NewSMTPFromConfig)
+}
+
+// SMTP implements the email messenger
+type SMTP struct {
+	log                  *util.Logger
+	host, addr           string
+	user, password, from string
+	to                   []string
+	encryption           string
+	tlsConfig            *tls.Config
+	timeout              time.Duration
+}
+
+// NewSMTPFromConfig creates new SMTP messenger
+func NewSMTPFromConfig(other map[string]interface{}) (Messenger, error) {
+	cc := struct {
+		Host       string
+		Port       int
+		User       string
+		Password   string
+		From       string
+		To         []string
+		Encryption string // starttls, tls or none
+		Insecure   bool
+		Timeout    time.Duration
+	}{
+		Encryption: "starttls",
+		Timeout:    request.Timeout,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Host == "" {
+		return nil, errors.New("missing host")
+	}
+
+	if cc.From == "" || len(cc.To) == 0 {
+		return nil, errors.New("missing from or to address")
+	}
+
+	cc.Encryption = strings.ToLower(cc.Encryption)
+
+	switch cc.Encryption {
+	case "starttls", "tls", "none":
+	default:
+		return nil, fmt.Errorf("invalid encryption: %s", cc.Encryption)
+	}
+
+	if cc.Port == 0 {
+		switch cc.Encryption {
+		case "starttls":
+			cc.Port = 587
+		case "tls":
+			cc.Port = 465
+		case "none":
+			cc.Port = 25
+		}
+	}
+
+	m := &SMTP{
+		log:        util.NewLogger("smtp").Redact(cc.Password),
+		host:       cc.Host,
+		addr:       net.JoinHostPort(cc.Host, strconv.Itoa(cc.Port)),
+		user:       cc.User,
+		password:   cc.Password,
+		from:       cc.From,
+		to:         cc.To,
+		encryption: cc.Encryption,
+		tlsConfig: &tls.Config{
+			ServerName:         cc.Host,
+			InsecureSkipVerify: cc.Insecure,
+		},
+		timeout: cc.Timeout,
+	}
+
+	return m, nil
+}
+
+// htmlMessage converts the plain text message to html
+func htmlMessage(msg string) string {
+	return strings.ReplaceAll(html.EscapeString(msg), "\n", "<br>\n")
+}
+
+// body creates the multipart message with text and html parts
+func (m *SMTP) body(title, msg string) ([]byte, error) {
+	var b bytes.Buffer
+	mw := multipart.NewWriter(&b)
+
+	headers := []string{
+		"From: " + m.from,
+		"To: " + strings.Join(m.to, ", "),
+		"Subject: " + mime.QEncoding.Encode("utf-8", title),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + mw.Boundary(),
+	}
+
+	b.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	parts := []struct {
+		contentType, content string
+	}{
+		{"text/plain", msg},
+		{"text/html", "<html><body><h3>" + html.EscapeString(title) + "</h3><p>" + htmlMessage(msg) + "</p></body></html>"},
+	}
+
+	for _, part := range parts {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType + "; charset=utf-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qw := quotedprintable.NewWriter(w)
+		if _, err := qw.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qw.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	err := mw.Close()
+
+	return b.Bytes(), err
+}
+
+func (m *SMTP) send(title, msg string) error {
+	body, err := m.body(title, msg)
+	if err != nil {
+		return err
+	}
+
+	dialer := &net.Dialer{Timeout: m.timeout}
+
+	var conn net.Conn
+	if m.encryption == "tls" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", m.addr, m.tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", m.addr)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := conn.SetDeadline(time.Now().Add(m.timeout)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if m.encryption == "starttls" {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return errors.New("server does not support starttls")
+		}
+		if err := c.StartTLS(m.tlsConfig); err != nil {
+			return err
+		}
+	}
+
+	if m.user != "" {
+		if err := c.Auth(smtp.PlainAuth("", m.user, m.password, m.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(m.from); err != nil {
+		return err
+	}
+
+	for _, to := range m.to {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// Send sends to all receivers
+func (m *SMTP) Send(title, msg string) {
+	if err := m.send(title, msg); err != nil {
+		m.log.ERROR.Print(err)
+	}
+}
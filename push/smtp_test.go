@@ -0,0 +1,117 @@
+package push
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpStub accepts a single message without encryption and authentication
+func smtpStub(t *testing.T) (string, <-chan []string, <-chan string) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	rcpt := make(chan []string, 1)
+	data := make(chan string, 1)
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		_ = tp.PrintfLine("220 localhost ESMTP")
+
+		var to []string
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+
+			switch cmd := strings.ToUpper(strings.Fields(line)[0]); cmd {
+			case "EHLO", "HELO":
+				_ = tp.PrintfLine("250 localhost")
+			case "MAIL":
+				_ = tp.PrintfLine("250 ok")
+			case "RCPT":
+				to = append(to, line)
+				_ = tp.PrintfLine("250 ok")
+			case "DATA":
+				_ = tp.PrintfLine("354 go ahead")
+				b, _ := io.ReadAll(tp.DotReader())
+				rcpt <- to
+				data <- string(b)
+				_ = tp.PrintfLine("250 ok")
+			case "QUIT":
+				_ = tp.PrintfLine("221 bye")
+				return
+			default:
+				_ = tp.PrintfLine("502 not implemented")
+			}
+		}
+	}()
+
+	return l.Addr().String(), rcpt, data
+}
+
+func TestSMTP(t *testing.T) {
+	addr, rcptC, dataC := smtpStub(t)
+	host, port, _ := net.SplitHostPort(addr)
+
+	m, err := NewSMTPFromConfig(map[string]interface{}{
+		"host":       host,
+		"port":       port,
+		"encryption": "none",
+		"from":       "evcc@example.com",
+		"to":         []string{"a@example.com", "b@example.com"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, m.(*SMTP).send("Charge started", "Started <pv>\ncharging"))
+
+	assert.Equal(t, []string{"RCPT TO:<a@example.com>", "RCPT TO:<b@example.com>"}, <-rcptC)
+
+	msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(<-dataC)))
+	require.NoError(t, err)
+	assert.Equal(t, "Charge started", msg.Header.Get("Subject"))
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+
+	var parts []string
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		b, err := io.ReadAll(p)
+		require.NoError(t, err)
+		parts = append(parts, string(b))
+	}
+
+	require.Len(t, parts, 2)
+	assert.Equal(t, "Started <pv>\ncharging", parts[0])
+	assert.Contains(t, parts[1], "Started &lt;pv&gt;<br>\ncharging")
+
+	// invalid config
+	_, err = NewSMTPFromConfig(map[string]interface{}{"host": host, "from": "evcc@example.com", "to": []string{"a@example.com"}, "encryption": "foo"})
+	assert.Error(t, err)
+}
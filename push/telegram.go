@@ -4,7 +4,9 @@ import (
 	"errors"
 	"strconv"
 	"sync"
+	"time"
 
+	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/util"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -17,15 +19,18 @@ func init() {
 type Telegram struct {
 	log *util.Logger
 	sync.Mutex
-	bot   *tgbotapi.BotAPI
-	chats map[int64]struct{}
+	bot      *tgbotapi.BotAPI
+	chats    map[int64]struct{}
+	admins   map[int64]struct{} // users allowed to control the site
+	commands *commander         // nil unless controlling the site
 }
 
 // NewTelegramFromConfig creates new pushover messenger
 func NewTelegramFromConfig(other map[string]interface{}) (Messenger, error) {
 	var cc struct {
-		Token string
-		Chats []int64
+		Token  string
+		Chats  []int64
+		Admins []int64 // user ids allowed to control the site, control is disabled if empty
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
@@ -40,36 +45,131 @@ func NewTelegramFromConfig(other map[string]interface{}) (Messenger, error) {
 	log := util.NewLogger("telegram").Redact(cc.Token)
 	_ = tgbotapi.SetLogger(log.ERROR)
 
-	for _, i := range cc.Chats {
+	for _, i := range append(cc.Chats, cc.Admins...) {
 		log.Redact(strconv.FormatInt(i, 10))
 	}
 
 	m := &Telegram{
-		log:   log,
-		bot:   bot,
-		chats: make(map[int64]struct{}),
+		log:    log,
+		bot:    bot,
+		chats:  make(map[int64]struct{}),
+		admins: make(map[int64]struct{}),
 	}
 
 	for _, chat := range cc.Chats {
 		m.chats[chat] = struct{}{}
 	}
 
+	for _, user := range cc.Admins {
+		m.admins[user] = struct{}{}
+	}
+
 	go m.trackChats()
 
 	return m, nil
 }
 
-// trackChats captures ids of all chats that bot participates in
+// Control implements the Controller interface. Control requires admins to be configured.
+func (m *Telegram) Control(site site.API, cache *util.Cache) {
+	m.Lock()
+	defer m.Unlock()
+
+	if len(m.admins) == 0 {
+		return
+	}
+
+	m.commands = &commander{
+		site:  site,
+		cache: cache,
+		now:   time.Now,
+	}
+}
+
+// trackChats captures ids of all chats that bot participates in and handles commands
 func (m *Telegram) trackChats() {
 	conf := tgbotapi.NewUpdate(0)
 	conf.Timeout = 1000
 
 	for update := range m.bot.GetUpdatesChan(conf) {
-		m.Lock()
-		if _, ok := m.chats[update.Message.Chat.ID]; !ok {
-			m.log.INFO.Printf("new chat id: %d", update.Message.Chat.ID)
+		switch {
+		case update.CallbackQuery != nil:
+			m.handleCallback(update.CallbackQuery)
+
+		case update.Message != nil:
+			m.Lock()
+			_, ok := m.chats[update.Message.Chat.ID]
+			m.Unlock()
+
+			if !ok {
+				m.log.INFO.Printf("new chat id: %d", update.Message.Chat.ID)
+			}
+
+			if ok && update.Message.IsCommand() && update.Message.From != nil {
+				m.handleCommand(update.Message.Chat.ID, update.Message.From.ID, update.Message.Text)
+			}
 		}
-		m.Unlock()
+	}
+}
+
+// command executes the command if the chat is known and the user is an admin
+func (m *Telegram) command(chat, user int64, text string) (string, *tgbotapi.InlineKeyboardMarkup, error) {
+	m.Lock()
+	_, chatOk := m.chats[chat]
+	_, userOk := m.admins[user]
+	commands := m.commands
+	m.Unlock()
+
+	if !chatOk || !userOk || commands == nil {
+		return "", nil, errors.New("not authorised")
+	}
+
+	cmd, args := parseCommand(text)
+	m.log.DEBUG.Printf("command from %d in %d: %s %v", user, chat, cmd, args)
+
+	return commands.execute(cmd, args)
+}
+
+// handleCommand replies to a chat command
+func (m *Telegram) handleCommand(chat, user int64, text string) {
+	reply, keyboard, err := m.command(chat, user, text)
+	if err != nil {
+		reply = "error: " + err.Error()
+	}
+
+	msg := tgbotapi.NewMessage(chat, reply)
+	if keyboard != nil {
+		msg.ReplyMarkup = keyboard
+	}
+
+	if _, err := m.bot.Send(msg); err != nil {
+		m.log.ERROR.Println("send:", err)
+	}
+}
+
+// handleCallback executes the inline keyboard command and replaces the keyboard with the result
+func (m *Telegram) handleCallback(query *tgbotapi.CallbackQuery) {
+	if query.Message == nil {
+		return
+	}
+
+	chat := query.Message.Chat.ID
+
+	var user int64
+	if query.From != nil {
+		user = query.From.ID
+	}
+
+	reply, _, err := m.command(chat, user, query.Data)
+	if err != nil {
+		reply = "error: " + err.Error()
+	}
+
+	if _, err := m.bot.Request(tgbotapi.NewCallback(query.ID, reply)); err != nil {
+		m.log.ERROR.Println("callback:", err)
+	}
+
+	if _, err := m.bot.Send(tgbotapi.NewEditMessageText(chat, query.Message.MessageID, reply)); err != nil {
+		m.log.ERROR.Println("send:", err)
 	}
 }
 
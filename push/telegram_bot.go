@@ -0,0 +1,245 @@
+package push
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const botHelp = `/status - site and loadpoint summary
+/mode <loadpoint> [off|now|minpv|pv] - set charge mode
+/minsoc <loadpoint> <soc> - set min soc
+/target <loadpoint> <soc> [hh:mm] - set target soc and optional target time
+/vehicle <loadpoint> <vehicle> - set vehicle by name or number`
+
+// commander executes chat commands using the site and loadpoint apis
+type commander struct {
+	site  site.API
+	cache *util.Cache
+	now   func() time.Time
+}
+
+// loadpoint returns the loadpoint by id starting at 1
+func (c *commander) loadpoint(arg string) (loadpoint.API, error) {
+	loadpoints := c.site.Loadpoints()
+
+	id, err := strconv.Atoi(arg)
+	if err != nil || id < 1 || id > len(loadpoints) {
+		return nil, fmt.Errorf("invalid loadpoint: %s", arg)
+	}
+
+	return loadpoints[id-1], nil
+}
+
+// execute executes the command and returns the reply and optional keyboard
+func (c *commander) execute(cmd string, args []string) (string, *tgbotapi.InlineKeyboardMarkup, error) {
+	switch cmd {
+	case "start", "help":
+		return botHelp, nil, nil
+
+	case "status":
+		return c.status(), nil, nil
+	}
+
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("missing loadpoint: %s", botHelp)
+	}
+
+	lp, err := c.loadpoint(args[0])
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch cmd {
+	case "mode":
+		if len(args) < 2 {
+			return fmt.Sprintf("%s: select mode", lp.Title()), modeKeyboard(args[0]), nil
+		}
+
+		mode, err := api.ChargeModeString(args[1])
+		if err != nil {
+			return "", nil, err
+		}
+
+		lp.SetMode(mode)
+
+		return fmt.Sprintf("%s: mode %s", lp.Title(), lp.GetMode()), nil, nil
+
+	case "minsoc":
+		if len(args) < 2 {
+			return "", nil, errors.New("missing soc")
+		}
+
+		soc, err := strconv.Atoi(args[1])
+		if err != nil || soc < 0 || soc > 100 {
+			return "", nil, fmt.Errorf("invalid soc: %s", args[1])
+		}
+
+		lp.SetMinSoc(soc)
+
+		return fmt.Sprintf("%s: min soc %d%%", lp.Title(), lp.GetMinSoc()), nil, nil
+
+	case "target":
+		return c.target(lp, args[1:])
+
+	case "vehicle":
+		return c.vehicle(lp, strings.Join(args[1:], " "))
+
+	default:
+		return "", nil, fmt.Errorf("unknown command: %s", cmd)
+	}
+}
+
+// target sets target soc and optional target time
+func (c *commander) target(lp loadpoint.API, args []string) (string, *tgbotapi.InlineKeyboardMarkup, error) {
+	if len(args) < 1 {
+		return "", nil, errors.New("missing soc")
+	}
+
+	soc, err := strconv.Atoi(args[0])
+	if err != nil || soc < 0 || soc > 100 {
+		return "", nil, fmt.Errorf("invalid soc: %s", args[0])
+	}
+
+	var ts time.Time
+	if len(args) > 1 {
+		now := c.now()
+
+		t, err := time.ParseInLocation("15:04", args[1], now.Location())
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid time: %s", args[1])
+		}
+
+		// next occurrence of the given time
+		ts = time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if !ts.After(now) {
+			ts = ts.AddDate(0, 0, 1)
+		}
+	}
+
+	lp.SetTargetSoc(soc)
+
+	if ts.IsZero() {
+		return fmt.Sprintf("%s: target soc %d%%", lp.Title(), lp.GetTargetSoc()), nil, nil
+	}
+
+	if err := lp.SetTargetTime(ts); err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("%s: target soc %d%% at %s", lp.Title(), lp.GetTargetSoc(), lp.GetTargetTime().Format("Mon 15:04")), nil, nil
+}
+
+// vehicle sets the vehicle by title or number
+func (c *commander) vehicle(lp loadpoint.API, name string) (string, *tgbotapi.InlineKeyboardMarkup, error) {
+	vehicles := c.site.GetVehicles()
+
+	var vehicle api.Vehicle
+	if id, err := strconv.Atoi(name); err == nil && id >= 1 && id <= len(vehicles) {
+		vehicle = vehicles[id-1]
+	}
+
+	for _, v := range vehicles {
+		if vehicle == nil && strings.EqualFold(v.Title(), name) {
+			vehicle = v
+		}
+	}
+
+	if vehicle == nil {
+		return "", nil, fmt.Errorf("invalid vehicle: %s", name)
+	}
+
+	lp.SetVehicle(vehicle)
+
+	return fmt.Sprintf("%s: vehicle %s", lp.Title(), vehicle.Title()), nil, nil
+}
+
+// status returns the site and loadpoint summary
+func (c *commander) status() string {
+	var sb strings.Builder
+
+	values := make(map[string]any)
+	if c.cache != nil {
+		for _, p := range c.cache.All() {
+			if p.Loadpoint == nil {
+				values[p.Key] = p.Val
+			}
+		}
+	}
+
+	kw := func(key string) string {
+		if f, ok := values[key].(float64); ok {
+			return fmt.Sprintf("%.1fkW", f/1e3)
+		}
+		return "-"
+	}
+
+	if title, ok := values["siteTitle"].(string); ok && title != "" {
+		sb.WriteString(title + "\n")
+	}
+
+	fmt.Fprintf(&sb, "Grid: %s, PV: %s, Home: %s", kw("gridPower"), kw("pvPower"), kw("homePower"))
+	if soc, ok := values["batterySoc"].(float64); ok && values["batteryConfigured"] == true {
+		fmt.Fprintf(&sb, ", Battery: %.0f%%", soc)
+	}
+
+	for i, lp := range c.site.Loadpoints() {
+		fmt.Fprintf(&sb, "\n%d %s: %s, %s", i+1, lp.Title(), lp.GetMode(), statusText(lp.GetStatus()))
+
+		if power := lp.GetChargePower(); power > 0 {
+			fmt.Fprintf(&sb, " %.1fkW", power/1e3)
+		}
+
+		if v := lp.GetVehicle(); v != nil {
+			fmt.Fprintf(&sb, ", %s %.0f%%", v.Title(), lp.GetVehicleSoc())
+		}
+
+		fmt.Fprintf(&sb, ", min %d%%, target %d%%", lp.GetMinSoc(), lp.GetTargetSoc())
+	}
+
+	return sb.String()
+}
+
+// statusText describes the charge status
+func statusText(status api.ChargeStatus) string {
+	switch status {
+	case api.StatusB:
+		return "connected"
+	case api.StatusC:
+		return "charging"
+	default:
+		return "disconnected"
+	}
+}
+
+// modeKeyboard creates the inline keyboard for selecting the charge mode
+func modeKeyboard(lp string) *tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, mode := range []api.ChargeMode{api.ModeOff, api.ModeNow, api.ModeMinPV, api.ModePV} {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(string(mode), fmt.Sprintf("/mode %s %s", lp, mode)))
+	}
+
+	kb := tgbotapi.NewInlineKeyboardMarkup(row)
+	return &kb
+}
+
+// parseCommand splits the command text into command and arguments
+func parseCommand(text string) (string, []string) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	// strip leading slash and bot name
+	cmd, _, _ := strings.Cut(strings.TrimPrefix(fields[0], "/"), "@")
+
+	return strings.ToLower(cmd), fields[1:]
+}
@@ -0,0 +1,139 @@
+package push
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommander(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().Title().Return("Garage").AnyTimes()
+
+	vehicle := mock.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Title().Return("Zoe").AnyTimes()
+
+	st := site.NewMockAPI(ctrl)
+	st.EXPECT().Loadpoints().Return([]loadpoint.API{lp}).AnyTimes()
+	st.EXPECT().GetVehicles().Return([]api.Vehicle{vehicle}).AnyTimes()
+
+	now := time.Date(2023, 1, 1, 18, 0, 0, 0, time.UTC)
+	cache := util.NewCache()
+	cache.Add("gridPower", util.Param{Key: "gridPower", Val: 1500.0})
+
+	c := &commander{
+		site:  st,
+		cache: cache,
+		now:   func() time.Time { return now },
+	}
+
+	exec := func(text string) (string, error) {
+		cmd, args := parseCommand(text)
+		res, _, err := c.execute(cmd, args)
+		return res, err
+	}
+
+	// status
+	lp.EXPECT().GetMode().Return(api.ModePV)
+	lp.EXPECT().GetStatus().Return(api.StatusC)
+	lp.EXPECT().GetChargePower().Return(11e3)
+	lp.EXPECT().GetVehicle().Return(vehicle)
+	lp.EXPECT().GetVehicleSoc().Return(42.0)
+	lp.EXPECT().GetMinSoc().Return(20)
+	lp.EXPECT().GetTargetSoc().Return(80)
+
+	res, err := exec("/status")
+	require.NoError(t, err)
+	assert.Equal(t, "Grid: 1.5kW, PV: -, Home: -\n1 Garage: pv, charging 11.0kW, Zoe 42%, min 20%, target 80%", res)
+
+	// mode
+	lp.EXPECT().SetMode(api.ModeNow)
+	lp.EXPECT().GetMode().Return(api.ModeNow)
+
+	res, err = exec("/mode@evccbot 1 now")
+	require.NoError(t, err)
+	assert.Equal(t, "Garage: mode now", res)
+
+	_, kb, err := c.execute("mode", []string{"1"})
+	require.NoError(t, err)
+	require.NotNil(t, kb)
+	assert.Equal(t, "/mode 1 pv", *kb.InlineKeyboard[0][3].CallbackData)
+
+	_, err = exec("/mode 2 now")
+	assert.Error(t, err)
+
+	_, err = exec("/mode 1 foo")
+	assert.Error(t, err)
+
+	// min soc
+	lp.EXPECT().SetMinSoc(30)
+	lp.EXPECT().GetMinSoc().Return(30)
+
+	res, err = exec("/minsoc 1 30")
+	require.NoError(t, err)
+	assert.Equal(t, "Garage: min soc 30%", res)
+
+	// target with time on next day
+	ts := time.Date(2023, 1, 2, 7, 0, 0, 0, time.UTC)
+	lp.EXPECT().SetTargetSoc(80)
+	lp.EXPECT().SetTargetTime(ts).Return(nil)
+	lp.EXPECT().GetTargetSoc().Return(80)
+	lp.EXPECT().GetTargetTime().Return(ts)
+
+	res, err = exec("/target 1 80 07:00")
+	require.NoError(t, err)
+	assert.Equal(t, "Garage: target soc 80% at Mon 07:00", res)
+
+	_, err = exec("/target 1 80 25:00")
+	assert.Error(t, err)
+
+	// vehicle
+	lp.EXPECT().SetVehicle(vehicle)
+
+	res, err = exec("/vehicle 1 zoe")
+	require.NoError(t, err)
+	assert.Equal(t, "Garage: vehicle Zoe", res)
+
+	_, err = exec("/vehicle 1 foo")
+	assert.Error(t, err)
+}
+
+func TestTelegramAuthorisation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	m := &Telegram{
+		log:    util.NewLogger("foo"),
+		chats:  map[int64]struct{}{1: {}},
+		admins: make(map[int64]struct{}),
+	}
+
+	// control is disabled without admins
+	m.Control(site.NewMockAPI(ctrl), nil)
+
+	_, _, err := m.command(1, 42, "/help")
+	assert.Error(t, err)
+
+	m.admins[42] = struct{}{}
+	m.Control(site.NewMockAPI(ctrl), nil)
+
+	_, _, err = m.command(1, 42, "/help")
+	assert.NoError(t, err)
+
+	// other chat members are not authorised
+	_, _, err = m.command(1, 7, "/help")
+	assert.Error(t, err)
+
+	// unknown chat
+	_, _, err = m.command(2, 42, "/help")
+	assert.Error(t, err)
+}
@@ -0,0 +1,190 @@
+package push
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+func init() {
+	registry.Add("webhook", NewWebhookFromConfig)
+}
+
+// SignatureHeader is the header containing the hex-encoded HMAC-SHA256 signature of the webhook body
+const SignatureHeader = "X-Evcc-Signature"
+
+// Webhook implements a messenger sending structured messages to a http endpoint
+type Webhook struct {
+	*request.Helper
+	log     *util.Logger
+	uri     string
+	method  string
+	headers map[string]string
+	secret  []byte
+	body    *template.Template
+	retries uint64
+	backoff time.Duration
+}
+
+// NewWebhookFromConfig creates new webhook messenger
+func NewWebhookFromConfig(other map[string]interface{}) (Messenger, error) {
+	cc := struct {
+		URI     string
+		Method  string
+		Headers map[string]string
+		Secret  string
+		Body    string
+		Retries uint64
+		Backoff time.Duration
+		Timeout time.Duration
+	}{
+		Method:  http.MethodPost,
+		Retries: 3,
+		Backoff: time.Second,
+		Timeout: request.Timeout,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.URI == "" {
+		return nil, errors.New("missing uri")
+	}
+
+	log := util.NewLogger("webhook").Redact(cc.Secret)
+
+	m := &Webhook{
+		Helper:  request.NewHelper(log),
+		log:     log,
+		uri:     cc.URI,
+		method:  strings.ToUpper(cc.Method),
+		headers: cc.Headers,
+		secret:  []byte(cc.Secret),
+		retries: cc.Retries,
+		backoff: cc.Backoff,
+	}
+
+	m.Client.Timeout = cc.Timeout
+
+	if cc.Body != "" {
+		tmpl, err := template.New("body").Funcs(sprig.TxtFuncMap()).Parse(cc.Body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid body template: %w", err)
+		}
+		m.body = tmpl
+	}
+
+	return m, nil
+}
+
+// jsonValues replaces values that cannot be encoded as JSON
+func jsonValues(values map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(values))
+
+	for k, v := range values {
+		switch val := v.(type) {
+		case float64:
+			if math.IsNaN(val) || math.IsInf(val, 0) {
+				v = nil
+			}
+		case time.Duration:
+			v = val.Seconds()
+		case error:
+			v = val.Error()
+		case fmt.Stringer:
+			v = val.String()
+		}
+
+		if _, err := json.Marshal(v); err != nil {
+			v = fmt.Sprintf("%v", v)
+		}
+
+		res[k] = v
+	}
+
+	return res
+}
+
+// payload creates the request body
+func (m *Webhook) payload(msg Message) ([]byte, error) {
+	msg.Values = jsonValues(msg.Values)
+
+	if m.body == nil {
+		return json.Marshal(msg)
+	}
+
+	var b bytes.Buffer
+	err := m.body.Execute(&b, msg)
+
+	return b.Bytes(), err
+}
+
+// sign returns the HMAC-SHA256 signature of the body
+func (m *Webhook) sign(body []byte) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (m *Webhook) post(body []byte) error {
+	headers := map[string]string{
+		"Content-Type": request.JSONContent,
+	}
+
+	for k, v := range m.headers {
+		headers[k] = v
+	}
+
+	if len(m.secret) > 0 {
+		headers[SignatureHeader] = m.sign(body)
+	}
+
+	req, err := request.New(m.method, m.uri, bytes.NewReader(body), headers)
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+
+	_, err = m.DoBody(req)
+
+	// client errors are not retried
+	return request.BackoffPermanentError(err)
+}
+
+// SendEvent implements the EventMessenger interface
+func (m *Webhook) SendEvent(msg Message) {
+	body, err := m.payload(msg)
+	if err != nil {
+		m.log.ERROR.Printf("body: %v", err)
+		return
+	}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = m.backoff
+	bo.MaxElapsedTime = 0
+
+	if err := backoff.Retry(func() error {
+		return m.post(body)
+	}, backoff.WithMaxRetries(bo, m.retries)); err != nil {
+		m.log.ERROR.Println(err)
+	}
+}
+
+// Send implements the Messenger interface
+func (m *Webhook) Send(title, msg string) {
+	m.SendEvent(Message{Title: title, Msg: msg})
+}
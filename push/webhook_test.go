@@ -0,0 +1,93 @@
+package push
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook(t *testing.T) {
+	var (
+		requests int
+		body     []byte
+		header   http.Header
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header
+	}))
+	defer srv.Close()
+
+	m, err := NewWebhookFromConfig(map[string]interface{}{
+		"uri":     srv.URL,
+		"secret":  "secret",
+		"headers": map[string]string{"Authorization": "Bearer token"},
+		"backoff": time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	m.(EventMessenger).SendEvent(Message{
+		Event:          "start",
+		Loadpoint:      1,
+		LoadpointTitle: "Garage",
+		Title:          "Charge started",
+		Msg:            "Started charging",
+		Values:         map[string]interface{}{"vehicleSoc": 42.0, "pvPower": math.NaN(), "chargeDuration": time.Minute},
+	})
+
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, "Bearer token", header.Get("Authorization"))
+	assert.Equal(t, m.(*Webhook).sign(body), header.Get(SignatureHeader))
+
+	var res Message
+	require.NoError(t, json.Unmarshal(body, &res))
+	assert.Equal(t, "start", res.Event)
+	assert.Equal(t, 1, res.Loadpoint)
+	assert.Equal(t, "Garage", res.LoadpointTitle)
+	assert.Equal(t, "Started charging", res.Msg)
+	assert.Equal(t, map[string]interface{}{"vehicleSoc": 42.0, "pvPower": nil, "chargeDuration": 60.0}, res.Values)
+}
+
+func TestWebhookBody(t *testing.T) {
+	var (
+		requests int
+		body     []byte
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	m, err := NewWebhookFromConfig(map[string]interface{}{
+		"uri":     srv.URL,
+		"body":    `{"text":"{{ .Title }}: {{ .Msg }} ({{ .Values.vehicleSoc }}%)"}`,
+		"backoff": time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	m.(EventMessenger).SendEvent(Message{
+		Title:  "Soc",
+		Msg:    "charged",
+		Values: map[string]interface{}{"vehicleSoc": 80},
+	})
+
+	// client errors are not retried
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, `{"text":"Soc: charged (80%)"}`, string(body))
+}
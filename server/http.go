@@ -132,6 +132,7 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, cache *util.Cache) {
 			"targettime":       {[]string{"POST", "OPTIONS"}, "/target/time/{time:[0-9TZ:.-]+}", targetTimeHandler(lp)},
 			"targettime2":      {[]string{"DELETE", "OPTIONS"}, "/target/time", targetTimeRemoveHandler(lp)},
 			"plan":             {[]string{"GET"}, "/target/plan", planHandler(lp)},
+			"decisions":        {[]string{"GET"}, "/decisions", decisionsHandler(lp)},
 			"vehicle":          {[]string{"POST", "OPTIONS"}, "/vehicle/{vehicle:[1-9][0-9]*}", vehicleHandler(site, lp)},
 			"vehicle2":         {[]string{"DELETE", "OPTIONS"}, "/vehicle", vehicleRemoveHandler(lp)},
 			"vehicleDetect":    {[]string{"PATCH", "OPTIONS"}, "/vehicle", vehicleDetectHandler(lp)},
@@ -146,6 +147,85 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, cache *util.Cache) {
 	}
 }
 
+// RegisterReloadHandler connects the configuration reload handler
+func (s *HTTPd) RegisterReloadHandler(callback func() error) {
+	router := s.Server.Handler.(*mux.Router)
+
+	// api
+	api := router.PathPrefix("/api").Subrouter()
+	api.Use(jsonHandler)
+	api.Use(handlers.CompressHandler)
+	api.Use(handlers.CORS(
+		handlers.AllowedHeaders([]string{"Content-Type"}),
+	))
+
+	// site api
+	routes := map[string]route{
+		"reload": {[]string{"POST", "OPTIONS"}, "/config/reload", func(w http.ResponseWriter, r *http.Request) {
+			if err := callback(); err != nil {
+				jsonError(w, http.StatusBadRequest, err)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}},
+	}
+
+	for _, r := range routes {
+		api.Methods(r.Methods...).Path(r.Pattern).Handler(r.HandlerFunc)
+	}
+}
+
+// RegisterOCPPHandlers connects the OCPP chargepoint management endpoints to the http server.
+// Accessing chargepoint configuration and diagnostics or changing chargepoints requires the management token,
+// diagnostics uploads require a pending diagnostics request.
+func (s *HTTPd) RegisterOCPPHandlers(uploadURI, token string) {
+	router := s.Server.Handler.(*mux.Router)
+
+	// api
+	api := router.PathPrefix("/api").Subrouter()
+	api.Use(jsonHandler)
+	api.Use(handlers.CompressHandler)
+	api.Use(handlers.CORS(
+		handlers.AllowedHeaders([]string{"Content-Type", "Authorization"}),
+	))
+
+	uploads := newDiagnosticsUploads()
+
+	// ocpp api
+	routes := map[string]route{
+		"list":          {[]string{"GET"}, "/ocpp", ocppListHandler},
+		"configuration": {[]string{"GET"}, "/ocpp/{id}/configuration", ocppAuthHandler(token, ocppConfigurationHandler)},
+		"changeconfig":  {[]string{"POST", "OPTIONS"}, "/ocpp/{id}/configuration/{key}", ocppAuthHandler(token, ocppChangeConfigurationHandler)},
+		"reset":         {[]string{"POST", "OPTIONS"}, "/ocpp/{id}/reset/{type:soft|hard}", ocppAuthHandler(token, ocppResetHandler)},
+		"diagnostics":   {[]string{"GET"}, "/ocpp/{id}/diagnostics", ocppAuthHandler(token, ocppDiagnosticsHandler)},
+		"getdiag":       {[]string{"POST", "OPTIONS"}, "/ocpp/{id}/diagnostics", ocppAuthHandler(token, ocppRequestDiagnosticsHandler(uploadURI, uploads))},
+		"upload":        {[]string{"POST", "PUT"}, "/ocpp/{id}/diagnostics/upload/{token}", ocppDiagnosticsUploadHandler(uploads)},
+		"uploadfile":    {[]string{"POST", "PUT"}, "/ocpp/{id}/diagnostics/upload/{token}/{file}", ocppDiagnosticsUploadHandler(uploads)},
+		"download":      {[]string{"GET"}, "/ocpp/{id}/diagnostics/{file}", ocppAuthHandler(token, ocppDiagnosticsFileHandler)},
+		"errors":        {[]string{"GET"}, "/ocpp/{id}/errors", ocppErrorsHandler},
+	}
+
+	for _, r := range routes {
+		api.Methods(r.Methods...).Path(r.Pattern).Handler(r.HandlerFunc)
+	}
+}
+
+// RegisterModbusHandlers connects the modbus bus and proxy statistics endpoints to the http server
+func (s *HTTPd) RegisterModbusHandlers() {
+	router := s.Server.Handler.(*mux.Router)
+
+	// api
+	api := router.PathPrefix("/api").Subrouter()
+	api.Use(jsonHandler)
+	api.Use(handlers.CompressHandler)
+	api.Use(handlers.CORS(
+		handlers.AllowedHeaders([]string{"Content-Type"}),
+	))
+
+	api.Methods("GET").Path("/modbus").HandlerFunc(modbusStatsHandler)
+	api.Methods("GET").Path("/modbusproxy").HandlerFunc(modbusProxyStatsHandler)
+}
+
 // RegisterShutdownHandler connects the http handlers to the site
 func (s *HTTPd) RegisterShutdownHandler(callback func()) {
 	router := s.Server.Handler.(*mux.Router)
@@ -15,6 +15,7 @@ import (
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/server/assets"
+	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
 	"github.com/gorilla/mux"
 )
@@ -186,16 +187,17 @@ func healthHandler(site site.API) http.HandlerFunc {
 func tariffHandler(site site.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
-		tariff := vars["tariff"]
 
-		t := site.GetTariff(tariff)
+		t := site.GetTariff(vars["tariff"])
 		if t == nil {
 			jsonError(w, http.StatusNotFound, errors.New("tariff not available"))
 			return
 		}
 
 		rates, err := t.Rates()
-		if err != nil {
+
+		// outdated rates remain usable until they expire
+		if err != nil && !tariff.Outdated(rates, err) {
 			jsonError(w, http.StatusNotFound, err)
 			return
 		}
@@ -362,6 +364,13 @@ func vehicleDetectHandler(lp loadpoint.API) http.HandlerFunc {
 	}
 }
 
+// decisionsHandler returns the recent charging decisions
+func decisionsHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jsonResult(w, lp.GetDecisions())
+	}
+}
+
 // planHandler starts vehicle detection
 func planHandler(lp loadpoint.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
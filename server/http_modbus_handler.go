@@ -0,0 +1,18 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/evcc-io/evcc/server/modbus"
+	mbus "github.com/evcc-io/evcc/util/modbus"
+)
+
+// modbusStatsHandler returns the request statistics by modbus uri or device
+func modbusStatsHandler(w http.ResponseWriter, r *http.Request) {
+	jsonResult(w, mbus.Stats())
+}
+
+// modbusProxyStatsHandler returns the modbus proxy request statistics by port and client
+func modbusProxyStatsHandler(w http.ResponseWriter, r *http.Request) {
+	jsonResult(w, modbus.Stats())
+}
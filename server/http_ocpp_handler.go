@@ -0,0 +1,432 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/charger/ocpp"
+	"github.com/gorilla/mux"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+)
+
+const (
+	maxDiagnosticsSize  = 64 << 20  // maximum size of uploaded diagnostics files
+	maxDiagnosticsFiles = 5         // maximum number of diagnostics files kept per chargepoint
+	diagnosticsTimeout  = time.Hour // validity of a diagnostics upload location
+)
+
+// diagnosticsUploads tracks the pending diagnostics requests by chargepoint.
+// Uploads are only accepted for a pending request using the request's token.
+type diagnosticsUploads struct {
+	mu      sync.Mutex
+	clock   func() time.Time
+	pending map[string]pendingUpload
+}
+
+type pendingUpload struct {
+	token   string
+	expires time.Time
+}
+
+func newDiagnosticsUploads() *diagnosticsUploads {
+	return &diagnosticsUploads{
+		clock:   time.Now,
+		pending: make(map[string]pendingUpload),
+	}
+}
+
+// request creates the upload token for a new diagnostics request replacing any previous request
+func (u *diagnosticsUploads) request(id string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	token := hex.EncodeToString(b)
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.pending[id] = pendingUpload{
+		token:   token,
+		expires: u.clock().Add(diagnosticsTimeout),
+	}
+
+	return token, nil
+}
+
+// valid returns true if the token belongs to a pending request of the chargepoint
+func (u *diagnosticsUploads) valid(id, token string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	p, ok := u.pending[id]
+	if ok && u.clock().After(p.expires) {
+		delete(u.pending, id)
+		return false
+	}
+
+	return ok && subtle.ConstantTimeCompare([]byte(p.token), []byte(token)) == 1
+}
+
+// done removes the pending request of the chargepoint
+func (u *diagnosticsUploads) done(id string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	delete(u.pending, id)
+}
+
+// ocppAuthHandler restricts chargepoint management to requests presenting the configured token
+func ocppAuthHandler(token string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// cors preflight
+		if r.Method == http.MethodOptions {
+			h(w, r)
+			return
+		}
+
+		if token == "" {
+			jsonError(w, http.StatusForbidden, errors.New("ocpp management requires token"))
+			return
+		}
+
+		auth, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(auth), []byte(token)) != 1 {
+			jsonError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+			return
+		}
+
+		h(w, r)
+	}
+}
+
+// diagnosticsDir returns the directory where the chargepoint's diagnostics uploads are stored
+func diagnosticsDir(id string) string {
+	return filepath.Join(os.TempDir(), "evcc-ocpp-diagnostics", filepath.Base(id))
+}
+
+// ocppChargePoint returns the central system and the requested chargepoint
+func ocppChargePoint(w http.ResponseWriter, r *http.Request) (*ocpp.CS, *ocpp.CP, bool) {
+	cs := ocpp.Running()
+	if cs == nil {
+		jsonError(w, http.StatusNotFound, errors.New("ocpp not running"))
+		return nil, nil, false
+	}
+
+	cp, err := cs.ChargePoint(mux.Vars(r)["id"])
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return nil, nil, false
+	}
+
+	return cs, cp, true
+}
+
+// ocppListHandler returns the configured chargepoints
+func ocppListHandler(w http.ResponseWriter, r *http.Request) {
+	type chargepoint struct {
+		ID        string `json:"id"`
+		Connected bool   `json:"connected"`
+	}
+
+	res := []chargepoint{}
+
+	if cs := ocpp.Running(); cs != nil {
+		for _, id := range cs.ChargePoints() {
+			if cp, err := cs.ChargePoint(id); err == nil {
+				res = append(res, chargepoint{ID: id, Connected: cp.Connected()})
+			}
+		}
+	}
+
+	jsonResult(w, res)
+}
+
+// ocppConfigurationHandler returns the chargepoint's configuration keys
+func ocppConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	cs, cp, ok := ocppChargePoint(w, r)
+	if !ok {
+		return
+	}
+
+	res, err := cs.Configuration(cp.ID(), r.URL.Query()["key"])
+	if err != nil {
+		jsonError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	jsonResult(w, res)
+}
+
+// ocppChangeConfigurationHandler changes a chargepoint configuration key
+func ocppChangeConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	cs, cp, ok := ocppChargePoint(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Value string `json:"value"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	status, err := cs.SetConfiguration(cp.ID(), mux.Vars(r)["key"], req.Value)
+	if err != nil {
+		jsonError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	jsonResult(w, status)
+}
+
+// ocppResetHandler performs a soft or hard reset of the chargepoint
+func ocppResetHandler(w http.ResponseWriter, r *http.Request) {
+	cs, cp, ok := ocppChargePoint(w, r)
+	if !ok {
+		return
+	}
+
+	resetType := core.ResetTypeSoft
+	if strings.EqualFold(mux.Vars(r)["type"], "hard") {
+		resetType = core.ResetTypeHard
+	}
+
+	if err := cs.ResetChargePoint(cp.ID(), resetType); err != nil {
+		jsonError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	jsonResult(w, resetType)
+}
+
+// ocppRequestDiagnosticsHandler requests a diagnostics upload to the local receiver or the given location
+func ocppRequestDiagnosticsHandler(uploadURI string, uploads *diagnosticsUploads) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cs, cp, ok := ocppChargePoint(w, r)
+		if !ok {
+			return
+		}
+
+		var req struct {
+			Location string `json:"location"`
+		}
+
+		if r.ContentLength > 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				jsonError(w, http.StatusBadRequest, err)
+				return
+			}
+		}
+
+		local := req.Location == ""
+		if local {
+			token, err := uploads.request(cp.ID())
+			if err != nil {
+				jsonError(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			req.Location = fmt.Sprintf("%s/api/ocpp/%s/diagnostics/upload/%s", strings.TrimSuffix(uploadURI, "/"), cp.ID(), token)
+		}
+
+		file, err := cs.RequestDiagnostics(cp.ID(), req.Location)
+		if err != nil {
+			if local {
+				uploads.done(cp.ID())
+			}
+			jsonError(w, http.StatusBadGateway, err)
+			return
+		}
+
+		jsonResult(w, struct {
+			Location string `json:"location"`
+			FileName string `json:"fileName,omitempty"`
+		}{req.Location, file})
+	}
+}
+
+// ocppDiagnosticsHandler returns the diagnostics status and uploaded files
+func ocppDiagnosticsHandler(w http.ResponseWriter, r *http.Request) {
+	_, cp, ok := ocppChargePoint(w, r)
+	if !ok {
+		return
+	}
+
+	type file struct {
+		Name     string    `json:"name"`
+		Size     int64     `json:"size"`
+		Modified time.Time `json:"modified"`
+	}
+
+	res := struct {
+		Status         string `json:"status,omitempty"`
+		FirmwareStatus string `json:"firmwareStatus,omitempty"`
+		Files          []file `json:"files"`
+	}{
+		Status:         string(cp.DiagnosticsStatus()),
+		FirmwareStatus: string(cp.FirmwareStatus()),
+		Files:          []file{},
+	}
+
+	entries, _ := os.ReadDir(diagnosticsDir(cp.ID()))
+	for _, e := range entries {
+		if fi, err := e.Info(); err == nil && fi.Mode().IsRegular() {
+			res.Files = append(res.Files, file{Name: fi.Name(), Size: fi.Size(), Modified: fi.ModTime()})
+		}
+	}
+
+	jsonResult(w, res)
+}
+
+// ocppDiagnosticsFileHandler downloads an uploaded diagnostics file
+func ocppDiagnosticsFileHandler(w http.ResponseWriter, r *http.Request) {
+	_, cp, ok := ocppChargePoint(w, r)
+	if !ok {
+		return
+	}
+
+	name := filepath.Join(diagnosticsDir(cp.ID()), filepath.Base(mux.Vars(r)["file"]))
+	if _, err := os.Stat(name); err != nil {
+		jsonError(w, http.StatusNotFound, errors.New("file not found"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filepath.Base(name)))
+	http.ServeFile(w, r, name)
+}
+
+// ocppDiagnosticsUploadHandler receives diagnostics uploaded by the chargepoint as multipart form or raw body
+func ocppDiagnosticsUploadHandler(uploads *diagnosticsUploads) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, cp, ok := ocppChargePoint(w, r)
+		if !ok {
+			return
+		}
+
+		if !uploads.valid(cp.ID(), mux.Vars(r)["token"]) {
+			jsonError(w, http.StatusForbidden, errors.New("no diagnostics request pending"))
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxDiagnosticsSize)
+
+		name := mux.Vars(r)["file"]
+		body := io.Reader(r.Body)
+
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			mr, err := r.MultipartReader()
+			if err != nil {
+				jsonError(w, http.StatusBadRequest, err)
+				return
+			}
+
+			for {
+				part, err := mr.NextPart()
+				if err != nil {
+					jsonError(w, http.StatusBadRequest, fmt.Errorf("missing file: %w", err))
+					return
+				}
+
+				if part.FileName() != "" {
+					name, body = part.FileName(), part
+					break
+				}
+			}
+		}
+
+		if name = filepath.Base(name); name == "." || name == "/" || name == "" {
+			name = fmt.Sprintf("diagnostics-%s.log", time.Now().Format("20060102-150405"))
+		}
+
+		dir := diagnosticsDir(cp.ID())
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			jsonError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		f, err := os.Create(filepath.Join(dir, name))
+		if err == nil {
+			_, err = io.Copy(f, body)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}
+
+		if err != nil {
+			jsonError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		uploads.done(cp.ID())
+
+		if err := pruneDiagnostics(dir, maxDiagnosticsFiles); err != nil {
+			log.ERROR.Printf("ocpp: prune diagnostics: %v", err)
+		}
+
+		jsonResult(w, name)
+	}
+}
+
+// pruneDiagnostics removes the oldest files exceeding the maximum number of files
+func pruneDiagnostics(dir string, max int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var files []os.FileInfo
+	for _, e := range entries {
+		if fi, err := e.Info(); err == nil && fi.Mode().IsRegular() {
+			files = append(files, fi)
+		}
+	}
+
+	if len(files) <= max {
+		return nil
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().After(files[j].ModTime())
+	})
+
+	for _, fi := range files[max:] {
+		if err := os.Remove(filepath.Join(dir, fi.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ocppErrorsHandler returns the chargepoint's status notification error history
+func ocppErrorsHandler(w http.ResponseWriter, r *http.Request) {
+	_, cp, ok := ocppChargePoint(w, r)
+	if !ok {
+		return
+	}
+
+	res := cp.StatusErrors()
+	if res == nil {
+		res = []ocpp.StatusError{}
+	}
+
+	jsonResult(w, res)
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnosticsUploads(t *testing.T) {
+	now := time.Now()
+
+	u := newDiagnosticsUploads()
+	u.clock = func() time.Time { return now }
+
+	assert.False(t, u.valid("cp", ""), "no request pending")
+
+	token, err := u.request("cp")
+	require.NoError(t, err)
+
+	assert.True(t, u.valid("cp", token))
+	assert.False(t, u.valid("cp", "foo"), "wrong token")
+	assert.False(t, u.valid("other", token), "wrong chargepoint")
+
+	u.done("cp")
+	assert.False(t, u.valid("cp", token), "request done")
+
+	token, err = u.request("cp")
+	require.NoError(t, err)
+
+	now = now.Add(diagnosticsTimeout + time.Second)
+	assert.False(t, u.valid("cp", token), "request expired")
+}
+
+func TestOcppAuthHandler(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+
+	for _, tc := range []struct {
+		token, auth string
+		status      int
+	}{
+		{"", "", http.StatusForbidden},
+		{"", "Bearer ", http.StatusForbidden},
+		{"secret", "", http.StatusUnauthorized},
+		{"secret", "Bearer foo", http.StatusUnauthorized},
+		{"secret", "secret", http.StatusUnauthorized},
+		{"secret", "Bearer secret", http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		if tc.auth != "" {
+			req.Header.Set("Authorization", tc.auth)
+		}
+
+		w := httptest.NewRecorder()
+		ocppAuthHandler(tc.token, ok)(w, req)
+
+		assert.Equal(t, tc.status, w.Code, "%+v", tc)
+	}
+}
+
+func TestOcppRoutesRequireToken(t *testing.T) {
+	s := &HTTPd{Server: &http.Server{Handler: mux.NewRouter()}}
+	s.RegisterOCPPHandlers("http://localhost:7070", "secret")
+
+	for _, tc := range []struct {
+		method, path string
+	}{
+		{http.MethodGet, "/api/ocpp/cp/configuration"},
+		{http.MethodGet, "/api/ocpp/cp/diagnostics"},
+		{http.MethodGet, "/api/ocpp/cp/diagnostics/diag.zip"},
+		{http.MethodPost, "/api/ocpp/cp/configuration/foo"},
+		{http.MethodPost, "/api/ocpp/cp/reset/soft"},
+		{http.MethodPost, "/api/ocpp/cp/diagnostics"},
+	} {
+		w := httptest.NewRecorder()
+		s.Handler.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "%s %s", tc.method, tc.path)
+	}
+}
+
+func TestPruneDiagnostics(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	for i := 0; i < 7; i++ {
+		name := filepath.Join(dir, fmt.Sprintf("%d.log", i))
+		require.NoError(t, os.WriteFile(name, nil, 0o644))
+
+		ts := now.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, os.Chtimes(name, ts, ts))
+	}
+
+	require.NoError(t, pruneDiagnostics(dir, 5))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+
+	assert.Equal(t, []string{"2.log", "3.log", "4.log", "5.log", "6.log"}, names, "oldest files removed")
+}
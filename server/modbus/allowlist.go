@@ -0,0 +1,67 @@
+package modbus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// addrRange is an inclusive register address range
+type addrRange struct {
+	from, to uint16
+}
+
+// allowList contains the writable holding register addresses. A nil allow list permits all writes.
+type allowList []addrRange
+
+// parseAllowList parses register addresses and ranges like 100 or 100-110
+func parseAllowList(entries []string) (allowList, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	res := make(allowList, 0, len(entries))
+
+	for _, e := range entries {
+		from, to, isRange := strings.Cut(strings.TrimSpace(e), "-")
+
+		f, err := strconv.ParseUint(strings.TrimSpace(from), 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid writable register: %s", e)
+		}
+
+		t := f
+		if isRange {
+			if t, err = strconv.ParseUint(strings.TrimSpace(to), 10, 16); err != nil || t < f {
+				return nil, fmt.Errorf("invalid writable register range: %s", e)
+			}
+		}
+
+		res = append(res, addrRange{uint16(f), uint16(t)})
+	}
+
+	return res, nil
+}
+
+// Allowed checks if all registers from addr to addr+qty-1 are writable
+func (l allowList) Allowed(addr, qty uint16) bool {
+	if l == nil {
+		return true
+	}
+
+	for a := uint32(addr); a < uint32(addr)+uint32(qty); a++ {
+		var ok bool
+		for _, r := range l {
+			if a >= uint32(r.from) && a <= uint32(r.to) {
+				ok = true
+				break
+			}
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,111 @@
+package modbus
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// readCache caches read responses for a short time and coalesces identical concurrent reads
+type readCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+	gen     uint64 // incremented by Clear
+	group   singleflight.Group
+}
+
+type cacheEntry struct {
+	b       []byte
+	expires time.Time
+}
+
+func newReadCache(ttl time.Duration) *readCache {
+	return &readCache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// readKey identifies a read request
+func readKey(op string, unitID uint8, addr, qty uint16) string {
+	return fmt.Sprintf("%s:%d:%d:%d", op, unitID, addr, qty)
+}
+
+func (c *readCache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return e.b, true
+}
+
+func (c *readCache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.gen
+}
+
+// put stores the response unless the cache was cleared since the read started at generation gen
+func (c *readCache) put(key string, b []byte, gen uint64) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.gen != gen {
+		return
+	}
+
+	c.entries[key] = cacheEntry{b: b, expires: time.Now().Add(c.ttl)}
+}
+
+// Clear invalidates all cached responses
+func (c *readCache) Clear() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]cacheEntry)
+	c.gen++
+}
+
+// Read returns the cached response or executes the read. Identical concurrent reads
+// are executed only once. Cached and shared indicate how the response was obtained.
+func (c *readCache) Read(key string, read func() ([]byte, error)) (b []byte, cached, shared bool, err error) {
+	if c == nil {
+		b, err = read()
+		return b, false, false, err
+	}
+
+	if b, ok := c.get(key); ok {
+		return b, true, false, nil
+	}
+
+	res, err, shared := c.group.Do(key, func() (any, error) {
+		gen := c.generation()
+
+		b, err := read()
+		if err == nil {
+			c.put(key, b, gen)
+		}
+		return b, err
+	})
+
+	b, _ = res.([]byte)
+
+	return b, false, shared, err
+}
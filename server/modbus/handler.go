@@ -14,10 +14,69 @@ import (
 type handler struct {
 	log      *util.Logger
 	readOnly bool
+	writable allowList
+	cache    *readCache
+	stats    *stats
 	mbserver.RequestHandler
 	conn *modbus.Connection
 }
 
+// read executes the read request using cache and coalescing and records the client's statistics
+func (h *handler) read(clientAddr, key string, read func() ([]byte, error)) ([]byte, error) {
+	b, cached, shared, err := h.cache.Read(key, read)
+
+	h.stats.update(clientAddr, func(cs *ClientStats) {
+		cs.Reads++
+		if cached {
+			cs.CacheHits++
+		}
+		if shared {
+			cs.Coalesced++
+		}
+		if err != nil {
+			cs.Errors++
+		}
+	})
+
+	return b, err
+}
+
+// write checks write protection, executes the write request, invalidates cached reads and records the client's statistics.
+// The allow list contains holding registers only, coil writes are rejected if an allow list is configured.
+func (h *handler) write(clientAddr string, coil bool, addr, qty uint16, write func() ([]byte, error)) ([]byte, error) {
+	var (
+		b        []byte
+		err      error
+		rejected = true
+	)
+
+	switch {
+	case h.readOnly:
+		err = mbserver.ErrIllegalFunction
+	case coil && h.writable != nil, !h.writable.Allowed(addr, qty):
+		err = mbserver.ErrIllegalDataAddress
+	default:
+		rejected = false
+		b, err = write()
+		h.cache.Clear()
+	}
+
+	if rejected {
+		h.log.DEBUG.Printf("write rejected: %s addr %d qty %d", clientAddr, addr, qty)
+	}
+
+	h.stats.update(clientAddr, func(cs *ClientStats) {
+		cs.Writes++
+		if rejected {
+			cs.Rejected++
+		} else if err != nil {
+			cs.Errors++
+		}
+	})
+
+	return b, err
+}
+
 func bytesAsUint16(b []byte) []uint16 {
 	u := make([]uint16, 0, len(b)/2)
 	for i := 0; i < len(b)/2; i++ {
@@ -99,10 +158,6 @@ LOOP:
 
 func (h *handler) HandleCoils(req *mbserver.CoilsRequest) ([]bool, error) {
 	if req.IsWrite {
-		if h.readOnly {
-			return nil, mbserver.ErrIllegalFunction
-		}
-
 		if req.WriteFuncCode == gridx.FuncCodeWriteSingleCoil {
 			h.log.TRACE.Printf("write single coil: id %d addr %d val %t", req.UnitId, req.Addr, req.Args[0])
 			var u uint16
@@ -110,45 +165,55 @@ func (h *handler) HandleCoils(req *mbserver.CoilsRequest) ([]bool, error) {
 				u = 0xFF00
 			}
 
-			b, err := h.conn.WriteSingleCoilWithSlave(req.UnitId, req.Addr, u)
+			b, err := h.write(req.ClientAddr, true, req.Addr, req.Quantity, func() ([]byte, error) {
+				return h.conn.WriteSingleCoilWithSlave(req.UnitId, req.Addr, u)
+			})
 			return h.coilsToResult("write coil", req.Quantity, b, err)
 		}
 
 		h.log.TRACE.Printf("write coils: id %d addr %d qty %d val %v", req.UnitId, req.Addr, req.Quantity, req.Args)
 		args := coilsToBytes(req.Args)
-		b, err := h.conn.WriteMultipleCoilsWithSlave(req.UnitId, req.Addr, req.Quantity, args)
+		b, err := h.write(req.ClientAddr, true, req.Addr, req.Quantity, func() ([]byte, error) {
+			return h.conn.WriteMultipleCoilsWithSlave(req.UnitId, req.Addr, req.Quantity, args)
+		})
 		return h.coilsToResult("write coils", req.Quantity, b, err)
 	}
 
 	h.log.TRACE.Printf("read coil: id %d addr %d qty %d", req.UnitId, req.Addr, req.Quantity)
-	b, err := h.conn.ReadCoilsWithSlave(req.UnitId, req.Addr, req.Quantity)
+	b, err := h.read(req.ClientAddr, readKey("coil", req.UnitId, req.Addr, req.Quantity), func() ([]byte, error) {
+		return h.conn.ReadCoilsWithSlave(req.UnitId, req.Addr, req.Quantity)
+	})
 	return h.coilsToResult("read coil", req.Quantity, b, err)
 }
 
 func (h *handler) HandleInputRegisters(req *mbserver.InputRegistersRequest) (res []uint16, err error) {
 	h.log.TRACE.Printf("read input: id %d addr %d qty %d", req.UnitId, req.Addr, req.Quantity)
-	b, err := h.conn.ReadInputRegistersWithSlave(req.UnitId, req.Addr, req.Quantity)
+	b, err := h.read(req.ClientAddr, readKey("input", req.UnitId, req.Addr, req.Quantity), func() ([]byte, error) {
+		return h.conn.ReadInputRegistersWithSlave(req.UnitId, req.Addr, req.Quantity)
+	})
 	return h.exceptionToUint16AndError("read input", b, err)
 }
 
 func (h *handler) HandleHoldingRegisters(req *mbserver.HoldingRegistersRequest) (res []uint16, err error) {
 	if req.IsWrite {
-		if h.readOnly {
-			return nil, mbserver.ErrIllegalFunction
-		}
-
 		if req.WriteFuncCode == gridx.FuncCodeWriteSingleRegister {
 			h.log.TRACE.Printf("write holding: id %d addr %d val %04x", req.UnitId, req.Addr, req.Args[0])
-			b, err := h.conn.WriteSingleRegisterWithSlave(req.UnitId, req.Addr, req.Args[0])
+			b, err := h.write(req.ClientAddr, false, req.Addr, req.Quantity, func() ([]byte, error) {
+				return h.conn.WriteSingleRegisterWithSlave(req.UnitId, req.Addr, req.Args[0])
+			})
 			return h.exceptionToUint16AndError("write holding", b, err)
 		}
 
 		h.log.TRACE.Printf("write holding: id %d addr %d qty %d val %0x", req.UnitId, req.Addr, req.Quantity, asBytes(req.Args))
-		b, err := h.conn.WriteMultipleRegistersWithSlave(req.UnitId, req.Addr, req.Quantity, asBytes(req.Args))
+		b, err := h.write(req.ClientAddr, false, req.Addr, req.Quantity, func() ([]byte, error) {
+			return h.conn.WriteMultipleRegistersWithSlave(req.UnitId, req.Addr, req.Quantity, asBytes(req.Args))
+		})
 		return h.exceptionToUint16AndError("write multiple holding", b, err)
 	}
 
 	h.log.TRACE.Printf("read holding: id %d addr %d qty %d", req.UnitId, req.Addr, req.Quantity)
-	b, err := h.conn.ReadHoldingRegistersWithSlave(req.UnitId, req.Addr, req.Quantity)
+	b, err := h.read(req.ClientAddr, readKey("holding", req.UnitId, req.Addr, req.Quantity), func() ([]byte, error) {
+		return h.conn.ReadHoldingRegistersWithSlave(req.UnitId, req.Addr, req.Quantity)
+	})
 	return h.exceptionToUint16AndError("read holding", b, err)
 }
@@ -3,6 +3,7 @@ package modbus
 import (
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/andig/mbserver"
 	"github.com/evcc-io/evcc/api"
@@ -11,12 +12,26 @@ import (
 	"github.com/evcc-io/evcc/util/sponsor"
 )
 
-func StartProxy(port int, config modbus.Settings, readOnly bool) error {
+// StartProxy starts a Modbus TCP proxy for the device. Reads are cached for the ttl and identical
+// concurrent reads are coalesced. Writes are limited to the writable register addresses if given.
+func StartProxy(port int, config modbus.Settings, readOnly bool, writable []string, ttl time.Duration) error {
+	allowed, err := parseAllowList(writable)
+	if err != nil {
+		return err
+	}
+
 	conn, err := modbus.NewConnection(config.URI, config.Device, config.Comset, config.Baudrate, modbus.ProtocolFromRTU(config.RTU), config.ID)
 	if err != nil {
 		return err
 	}
 
+	// set non-default inter-frame delay of the shared bus
+	if config.FrameDelay > 0 {
+		conn.FrameDelay(config.FrameDelay)
+	}
+
+	conn.Priority(config.Priority)
+
 	if !sponsor.IsAuthorized() {
 		return api.ErrSponsorRequired
 	}
@@ -24,6 +39,9 @@ func StartProxy(port int, config modbus.Settings, readOnly bool) error {
 	h := &handler{
 		log:            util.NewLogger(fmt.Sprintf("proxy-%d", port)),
 		readOnly:       readOnly,
+		writable:       allowed,
+		cache:          newReadCache(ttl),
+		stats:          newStats(),
 		RequestHandler: new(mbserver.DummyHandler), // supplies HandleDiscreteInputs
 		conn:           conn,
 	}
@@ -41,5 +59,11 @@ func StartProxy(port int, config modbus.Settings, readOnly bool) error {
 		err = srv.Start(l)
 	}
 
+	if err == nil {
+		proxyMu.Lock()
+		proxyStats[port] = h.stats
+		proxyMu.Unlock()
+	}
+
 	return err
 }
@@ -142,3 +142,156 @@ func (h *echoHandler) HandleCoils(req *mbserver.CoilsRequest) (res []bool, err e
 
 	return res, err
 }
+
+type countingHandler struct {
+	mu    sync.Mutex
+	reads int
+	mbserver.RequestHandler
+}
+
+func (h *countingHandler) HandleHoldingRegisters(req *mbserver.HoldingRegistersRequest) (res []uint16, err error) {
+	if req.IsWrite {
+		return req.Args, nil
+	}
+
+	h.mu.Lock()
+	h.reads++
+	h.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	for u := uint16(0); u < req.Quantity; u++ {
+		res = append(res, req.Addr+u)
+	}
+
+	return res, nil
+}
+
+func TestProxyCacheAndAllowList(t *testing.T) {
+	// downstream server
+	l, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	defer l.Close()
+
+	downstream := &countingHandler{RequestHandler: new(mbserver.DummyHandler)}
+	srv, _ := mbserver.New(downstream)
+	assert.NoError(t, srv.Start(l))
+	defer func() { _ = srv.Stop() }()
+
+	// proxy server
+	pl, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	defer pl.Close()
+
+	downstreamConn, err := modbus.NewConnection(l.Addr().String(), "", "", 0, modbus.Tcp, 1)
+	assert.NoError(t, err)
+
+	writable, err := parseAllowList([]string{"10", "20-21"})
+	assert.NoError(t, err)
+
+	h := &handler{
+		log:            util.NewLogger("foo"),
+		writable:       writable,
+		cache:          newReadCache(time.Minute),
+		stats:          newStats(),
+		RequestHandler: new(mbserver.DummyHandler),
+		conn:           downstreamConn,
+	}
+
+	proxy, _ := mbserver.New(h)
+	assert.NoError(t, proxy.Start(pl))
+	defer func() { _ = proxy.Stop() }()
+
+	// concurrent identical reads are coalesced and cached
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			conn, err := modbus.NewConnection(pl.Addr().String(), "", "", 0, modbus.Tcp, 1)
+			assert.NoError(t, err)
+
+			b, err := conn.ReadHoldingRegisters(1, 2)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte{0, 1, 0, 2}, b)
+		}()
+	}
+	wg.Wait()
+
+	conn, err := modbus.NewConnection(pl.Addr().String(), "", "", 0, modbus.Tcp, 1)
+	assert.NoError(t, err)
+
+	_, err = conn.ReadHoldingRegisters(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, downstream.reads)
+
+	// write allow list
+	_, err = conn.WriteSingleRegister(10, 1)
+	assert.NoError(t, err)
+
+	_, err = conn.WriteMultipleRegisters(20, 2, []byte{0, 1, 0, 2})
+	assert.NoError(t, err)
+
+	_, err = conn.WriteMultipleRegisters(21, 2, []byte{0, 1, 0, 2})
+	assert.Error(t, err)
+
+	// coils are not covered by the holding register allow list
+	_, err = conn.WriteSingleCoil(10, 0xFF00)
+	assert.Error(t, err)
+
+	// writes invalidate the cache
+	_, err = conn.ReadHoldingRegisters(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, downstream.reads)
+
+	// statistics
+	stats := h.stats.Clients()["127.0.0.1"]
+	assert.Equal(t, int64(9), stats.Requests)
+	assert.Equal(t, int64(5), stats.Reads)
+	assert.Equal(t, int64(4), stats.Writes)
+	assert.Equal(t, int64(2), stats.Rejected)
+	assert.Equal(t, int64(0), stats.Errors)
+	assert.Equal(t, int64(5), stats.Coalesced+stats.CacheHits+2)
+}
+
+func TestParseAllowList(t *testing.T) {
+	l, err := parseAllowList([]string{"1", "5-7"})
+	assert.NoError(t, err)
+
+	assert.True(t, l.Allowed(1, 1))
+	assert.True(t, l.Allowed(5, 3))
+	assert.False(t, l.Allowed(1, 2))
+	assert.False(t, l.Allowed(7, 2))
+
+	_, err = parseAllowList([]string{"7-5"})
+	assert.Error(t, err)
+
+	var all allowList
+	assert.True(t, all.Allowed(0, 100))
+}
+
+func TestReadCacheClearDuringRead(t *testing.T) {
+	c := newReadCache(time.Minute)
+
+	// write completes while the read is in flight
+	b, _, _, err := c.Read("key", func() ([]byte, error) {
+		c.Clear()
+		return []byte{1}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1}, b)
+
+	_, ok := c.get("key")
+	assert.False(t, ok, "stale response cached")
+
+	_, _, _, err = c.Read("key", func() ([]byte, error) {
+		return []byte{2}, nil
+	})
+	assert.NoError(t, err)
+
+	b, ok = c.get("key")
+	assert.True(t, ok)
+	assert.Equal(t, []byte{2}, b)
+}
@@ -0,0 +1,95 @@
+package modbus
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// Register map of the evcc Modbus TCP server. Unit ids are ignored.
+//
+// Input registers (function code 4) are read-only float32 values, big-endian, spanning two registers each.
+// Site values start at address 0:
+//
+//	 0  grid power (W)
+//	 2  pv power (W)
+//	 4  battery power (W)
+//	 6  home power (W)
+//	 8  battery soc (%)
+//	10  number of loadpoints
+//
+// Loadpoint n (starting at 1) values start at address 100*n:
+//
+//	+0  charge power (W)
+//	+2  status (0: A, 1: B, 2: C, 3: D, 4: E, 5: F)
+//	+4  vehicle soc (%)
+//	+6  charged energy (Wh)
+//
+// Holding registers (function codes 3, 6 and 16) are uint16 values. Loadpoint n (starting at 1)
+// settings start at address 100*n:
+//
+//	+0  mode (0: off, 1: now, 2: minpv, 3: pv)
+//	+1  min current (A)
+//	+2  max current (A)
+//	+3  min soc (%)
+//	+4  target soc (%)
+
+const lpBlock = 100 // loadpoint register block size
+
+// site input registers
+var siteInputs = []string{"gridPower", "pvPower", "batteryPower", "homePower", "batterySoc", "loadpoints"}
+
+// loadpoint input registers
+var lpInputs = []string{"chargePower", "status", "vehicleSoc", "chargedEnergy"}
+
+// loadpoint holding registers
+var lpHoldings = []string{"mode", "minCurrent", "maxCurrent", "minSoc", "targetSoc"}
+
+// modes in register order
+var modes = []api.ChargeMode{api.ModeOff, api.ModeNow, api.ModeMinPV, api.ModePV}
+
+// statusValue converts charge status to register value
+func statusValue(status api.ChargeStatus) float64 {
+	if i := strings.Index("ABCDEF", string(status)); i >= 0 && len(status) == 1 {
+		return float64(i)
+	}
+	return 0
+}
+
+// modeValue converts charge mode to register value
+func modeValue(mode api.ChargeMode) uint16 {
+	for i, m := range modes {
+		if m == mode {
+			return uint16(i)
+		}
+	}
+	return 0
+}
+
+// inputRegister resolves an input register address to loadpoint (0 for site), name and register offset
+func inputRegister(addr uint16) (int, string, int, error) {
+	lp, offset := int(addr/lpBlock), int(addr%lpBlock)
+
+	names := siteInputs
+	if lp > 0 {
+		names = lpInputs
+	}
+
+	if offset/2 >= len(names) {
+		return 0, "", 0, fmt.Errorf("invalid input register: %d", addr)
+	}
+
+	return lp, names[offset/2], offset % 2, nil
+}
+
+// holdingRegister resolves a holding register address to loadpoint and name
+func holdingRegister(addr uint16) (int, string, error) {
+	lp, offset := int(addr/lpBlock), int(addr%lpBlock)
+
+	if lp == 0 || offset >= len(lpHoldings) {
+		return 0, "", fmt.Errorf("invalid holding register: %d", addr)
+	}
+
+	return lp, lpHoldings[offset], nil
+}
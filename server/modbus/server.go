@@ -0,0 +1,250 @@
+package modbus
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"sync"
+
+	"github.com/andig/mbserver"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"golang.org/x/exp/slices"
+)
+
+// Server is a Modbus TCP server exposing site and loadpoint values and settings
+type Server struct {
+	mbserver.RequestHandler // supplies HandleCoils and HandleDiscreteInputs
+	mu                      sync.RWMutex
+	log                     *util.Logger
+	site                    site.API
+	writable                []string
+	values                  map[string]float64 // published values by param id
+}
+
+// NewServer creates a Modbus TCP server. Holding registers not contained in writable are read-only.
+func NewServer(site site.API, readOnly bool, writable []string) (*Server, error) {
+	for _, name := range writable {
+		if !slices.Contains(lpHoldings, name) {
+			return nil, fmt.Errorf("invalid writable register: %s", name)
+		}
+	}
+
+	if len(writable) == 0 && !readOnly {
+		writable = lpHoldings
+	}
+
+	if readOnly {
+		writable = nil
+	}
+
+	return &Server{
+		RequestHandler: new(mbserver.DummyHandler),
+		log:            util.NewLogger("modbus"),
+		site:           site,
+		writable:       writable,
+		values:         make(map[string]float64),
+	}, nil
+}
+
+// Listen starts the Modbus TCP server on the given port
+func (s *Server) Listen(port int) error {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+
+	s.log.DEBUG.Printf("modbus server listening at :%d", port)
+
+	srv, err := mbserver.New(s, mbserver.Logger(&logger{log: s.log}))
+	if err == nil {
+		err = srv.Start(l)
+	}
+
+	return err
+}
+
+// Run caches the published site and loadpoint values
+func (s *Server) Run(in <-chan util.Param) {
+	for p := range in {
+		if val, ok := p.Val.(float64); ok {
+			s.mu.Lock()
+			s.values[p.UniqueID()] = val
+			s.mu.Unlock()
+		}
+	}
+}
+
+// loadpoint returns the loadpoint by register block
+func (s *Server) loadpoint(id int) (loadpoint.API, error) {
+	loadpoints := s.site.Loadpoints()
+	if id < 1 || id > len(loadpoints) {
+		return nil, mbserver.ErrIllegalDataAddress
+	}
+	return loadpoints[id-1], nil
+}
+
+// inputValue returns the value of the input register
+func (s *Server) inputValue(id int, name string) (float64, error) {
+	if id == 0 {
+		if name == "loadpoints" {
+			return float64(len(s.site.Loadpoints())), nil
+		}
+
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		return s.values[name], nil
+	}
+
+	lp, err := s.loadpoint(id)
+	if err != nil {
+		return 0, err
+	}
+
+	switch name {
+	case "chargePower":
+		return lp.GetChargePower(), nil
+	case "status":
+		return statusValue(lp.GetStatus()), nil
+	case "vehicleSoc":
+		return lp.GetVehicleSoc(), nil
+	default:
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		lpID := id - 1
+		return s.values[util.Param{Loadpoint: &lpID, Key: name}.UniqueID()], nil
+	}
+}
+
+// HandleInputRegisters implements mbserver.RequestHandler
+func (s *Server) HandleInputRegisters(req *mbserver.InputRegistersRequest) ([]uint16, error) {
+	res := make([]uint16, 0, req.Quantity)
+
+	var (
+		val  float64
+		prev string
+	)
+
+	for addr := req.Addr; addr < req.Addr+req.Quantity; addr++ {
+		id, name, word, err := inputRegister(addr)
+		if err != nil {
+			return nil, mbserver.ErrIllegalDataAddress
+		}
+
+		// read each value once for both of its registers
+		if key := fmt.Sprintf("%d.%s", id, name); key != prev || word == 0 {
+			if val, err = s.inputValue(id, name); err != nil {
+				return nil, err
+			}
+			prev = key
+		}
+
+		bits := math.Float32bits(float32(val))
+		res = append(res, uint16(bits>>(16*(1-word))))
+	}
+
+	return res, nil
+}
+
+// holdingValue returns the value of the holding register
+func (s *Server) holdingValue(lp loadpoint.API, name string) uint16 {
+	switch name {
+	case "mode":
+		return modeValue(lp.GetMode())
+	case "minCurrent":
+		return uint16(lp.GetMinCurrent())
+	case "maxCurrent":
+		return uint16(lp.GetMaxCurrent())
+	case "minSoc":
+		return uint16(lp.GetMinSoc())
+	default:
+		return uint16(lp.GetTargetSoc())
+	}
+}
+
+// validate checks if the holding register value may be written
+func (s *Server) validate(name string, val uint16) error {
+	if !slices.Contains(s.writable, name) {
+		return mbserver.ErrIllegalFunction
+	}
+
+	switch name {
+	case "mode":
+		if int(val) >= len(modes) {
+			return mbserver.ErrIllegalDataValue
+		}
+	case "minSoc", "targetSoc":
+		if val > 100 {
+			return mbserver.ErrIllegalDataValue
+		}
+	}
+
+	return nil
+}
+
+// setHoldingValue writes the holding register
+func (s *Server) setHoldingValue(lp loadpoint.API, name string, val uint16) {
+	s.log.DEBUG.Printf("write %s: %d", name, val)
+
+	switch name {
+	case "mode":
+		lp.SetMode(modes[val])
+	case "minCurrent":
+		lp.SetMinCurrent(float64(val))
+	case "maxCurrent":
+		lp.SetMaxCurrent(float64(val))
+	case "minSoc":
+		lp.SetMinSoc(int(val))
+	case "targetSoc":
+		lp.SetTargetSoc(int(val))
+	}
+}
+
+// HandleHoldingRegisters implements mbserver.RequestHandler
+func (s *Server) HandleHoldingRegisters(req *mbserver.HoldingRegistersRequest) ([]uint16, error) {
+	type register struct {
+		lp   loadpoint.API
+		name string
+	}
+
+	regs := make([]register, 0, req.Quantity)
+
+	for i := uint16(0); i < req.Quantity; i++ {
+		id, name, err := holdingRegister(req.Addr + i)
+		if err != nil {
+			return nil, mbserver.ErrIllegalDataAddress
+		}
+
+		lp, err := s.loadpoint(id)
+		if err != nil {
+			return nil, err
+		}
+
+		// validate all registers before writing any
+		if req.IsWrite {
+			if err := s.validate(name, req.Args[i]); err != nil {
+				return nil, err
+			}
+		}
+
+		regs = append(regs, register{lp, name})
+	}
+
+	if req.IsWrite {
+		for i, reg := range regs {
+			s.setHoldingValue(reg.lp, reg.name, req.Args[i])
+		}
+
+		return req.Args, nil
+	}
+
+	res := make([]uint16, 0, len(regs))
+	for _, reg := range regs {
+		res = append(res, s.holdingValue(reg.lp, reg.name))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,130 @@
+package modbus
+
+import (
+	"encoding/binary"
+	"math"
+	"net"
+	"testing"
+
+	"github.com/andig/mbserver"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/modbus"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSite(ctrl *gomock.Controller, loadpoints ...loadpoint.API) site.API {
+	st := site.NewMockAPI(ctrl)
+	st.EXPECT().Loadpoints().Return(loadpoints).AnyTimes()
+	return st
+}
+
+func startServer(t *testing.T, s *Server) *modbus.Connection {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	srv, err := mbserver.New(s)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(l))
+	t.Cleanup(func() { _ = srv.Stop() })
+
+	conn, err := modbus.NewConnection(l.Addr().String(), "", "", 0, modbus.Tcp, 1)
+	require.NoError(t, err)
+
+	return conn
+}
+
+func float32s(b []byte) []float64 {
+	var res []float64
+	for i := 0; i+4 <= len(b); i += 4 {
+		res = append(res, float64(math.Float32frombits(binary.BigEndian.Uint32(b[i:]))))
+	}
+	return res
+}
+
+func TestServerInputs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lp := loadpoint.NewMockAPI(ctrl)
+
+	s, err := NewServer(newTestSite(ctrl, lp), true, nil)
+	require.NoError(t, err)
+
+	lpID := 0
+	in := make(chan util.Param, 3)
+	in <- util.Param{Key: "gridPower", Val: -1500.0}
+	in <- util.Param{Key: "batterySoc", Val: 80.0}
+	in <- util.Param{Loadpoint: &lpID, Key: "chargedEnergy", Val: 2500.0}
+	close(in)
+	s.Run(in)
+
+	conn := startServer(t, s)
+
+	b, err := conn.ReadInputRegisters(0, 12)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{-1500, 0, 0, 0, 80, 1}, float32s(b))
+
+	lp.EXPECT().GetChargePower().Return(11000.0)
+	lp.EXPECT().GetStatus().Return(api.StatusC)
+	lp.EXPECT().GetVehicleSoc().Return(42.0)
+
+	b, err = conn.ReadInputRegisters(100, 8)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{11000, 2, 42, 2500}, float32s(b))
+
+	// invalid address
+	_, err = conn.ReadInputRegisters(200, 2)
+	assert.Error(t, err)
+
+	// read-only
+	_, err = conn.WriteSingleRegister(100, 1)
+	assert.Error(t, err)
+}
+
+func TestServerHoldings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lp := loadpoint.NewMockAPI(ctrl)
+
+	s, err := NewServer(newTestSite(ctrl, lp), false, []string{"mode", "targetSoc"})
+	require.NoError(t, err)
+
+	conn := startServer(t, s)
+
+	lp.EXPECT().GetMode().Return(api.ModePV)
+	lp.EXPECT().GetMinCurrent().Return(6.0)
+	lp.EXPECT().GetMaxCurrent().Return(16.0)
+	lp.EXPECT().GetMinSoc().Return(20)
+	lp.EXPECT().GetTargetSoc().Return(80)
+
+	b, err := conn.ReadHoldingRegisters(100, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 3, 0, 6, 0, 16, 0, 20, 0, 80}, b)
+
+	lp.EXPECT().SetMode(api.ModeNow)
+	_, err = conn.WriteSingleRegister(100, 1)
+	assert.NoError(t, err)
+
+	// invalid mode
+	_, err = conn.WriteSingleRegister(100, 4)
+	assert.Error(t, err)
+
+	// write protected
+	_, err = conn.WriteSingleRegister(101, 10)
+	assert.Error(t, err)
+
+	// no partial writes
+	_, err = conn.WriteMultipleRegisters(103, 2, []byte{0, 10, 0, 90})
+	assert.Error(t, err)
+
+	lp.EXPECT().SetTargetSoc(90)
+	_, err = conn.WriteMultipleRegisters(104, 1, []byte{0, 90})
+	assert.NoError(t, err)
+
+	// invalid register
+	_, err = NewServer(newTestSite(ctrl), false, []string{"foo"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,86 @@
+package modbus
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// ClientStats are the request statistics of a single proxy client
+type ClientStats struct {
+	Requests    int64     `json:"requests"`
+	Reads       int64     `json:"reads"`
+	Writes      int64     `json:"writes"`
+	CacheHits   int64     `json:"cacheHits"`
+	Coalesced   int64     `json:"coalesced"`
+	Rejected    int64     `json:"rejected"`
+	Errors      int64     `json:"errors"`
+	LastRequest time.Time `json:"lastRequest"`
+}
+
+// stats collects request statistics by client address
+type stats struct {
+	mu      sync.Mutex
+	clients map[string]*ClientStats
+}
+
+func newStats() *stats {
+	return &stats{clients: make(map[string]*ClientStats)}
+}
+
+// update records a request of the client
+func (s *stats) update(clientAddr string, fun func(*ClientStats)) {
+	if s == nil {
+		return
+	}
+
+	client := clientAddr
+	if host, _, err := net.SplitHostPort(clientAddr); err == nil {
+		client = host
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cs, ok := s.clients[client]
+	if !ok {
+		cs = new(ClientStats)
+		s.clients[client] = cs
+	}
+
+	cs.Requests++
+	cs.LastRequest = time.Now()
+
+	fun(cs)
+}
+
+// Clients returns a copy of the statistics by client
+func (s *stats) Clients() map[string]ClientStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make(map[string]ClientStats, len(s.clients))
+	for client, cs := range s.clients {
+		res[client] = *cs
+	}
+
+	return res
+}
+
+var (
+	proxyMu    sync.Mutex
+	proxyStats = make(map[int]*stats)
+)
+
+// Stats returns the request statistics of all running proxies by port and client
+func Stats() map[int]map[string]ClientStats {
+	proxyMu.Lock()
+	defer proxyMu.Unlock()
+
+	res := make(map[int]map[string]ClientStats, len(proxyStats))
+	for port, s := range proxyStats {
+		res[port] = s.Clients()
+	}
+
+	return res
+}
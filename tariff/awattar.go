@@ -10,20 +10,20 @@ import (
 	"github.com/evcc-io/evcc/tariff/awattar"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
-	"golang.org/x/exp/slices"
 )
 
 type Awattar struct {
 	*embed
-	mux     sync.Mutex
-	log     *util.Logger
-	uri     string
-	unit    string
-	data    api.Rates
-	updated time.Time
+	*cache
+	log  *util.Logger
+	uri  string
+	unit string
 }
 
-var _ api.Tariff = (*Awattar)(nil)
+var (
+	_ api.Tariff        = (*Awattar)(nil)
+	_ api.TariffUpdated = (*Awattar)(nil)
+)
 
 func init() {
 	registry.Add("awattar", NewAwattarFromConfig)
@@ -48,6 +48,7 @@ func NewAwattarFromConfig(other map[string]interface{}) (api.Tariff, error) {
 
 	t := &Awattar{
 		embed: &cc.embed,
+		cache: newCache("awattar", other, time.Hour),
 		log:   util.NewLogger("awattar"),
 		unit:  cc.Currency,
 		uri:   fmt.Sprintf(awattar.RegionURI, strings.ToLower(cc.Region)),
@@ -66,37 +67,30 @@ func (t *Awattar) run(done chan error) {
 
 	for ; true; <-time.Tick(time.Hour) {
 		var res awattar.Prices
-		if err := client.GetJSON(t.uri, &res); err != nil {
-			once.Do(func() { done <- err })
-
-			t.log.ERROR.Println(err)
+		if err := t.update(t.log, &once, done, func() error {
+			return client.GetJSON(t.uri, &res)
+		}); err != nil {
 			continue
 		}
 
-		once.Do(func() { close(done) })
-
-		t.mux.Lock()
-		t.updated = time.Now()
-
-		t.data = make(api.Rates, 0, len(res.Data))
+		data := make(api.Rates, 0, len(res.Data))
 		for _, r := range res.Data {
 			ar := api.Rate{
 				Start: r.StartTimestamp.Local(),
 				End:   r.EndTimestamp.Local(),
 				Price: t.totalPrice(r.Marketprice / 1e3),
 			}
-			t.data = append(t.data, ar)
+			data = append(data, ar)
 		}
 
-		t.mux.Unlock()
+		t.Set(data)
+		once.Do(func() { close(done) })
 	}
 }
 
 // Rates implements the api.Tariff interface
 func (t *Awattar) Rates() (api.Rates, error) {
-	t.mux.Lock()
-	defer t.mux.Unlock()
-	return slices.Clone(t.data), outdatedError(t.updated, time.Hour)
+	return t.Get()
 }
 
 // Type returns the tariff type
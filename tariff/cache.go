@@ -0,0 +1,141 @@
+package tariff
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"golang.org/x/exp/slices"
+)
+
+// cache holds the rates of a dynamic tariff. Rates are persisted to the settings database
+// and served until they expire, including after restarts and failed updates.
+type cache struct {
+	mux      sync.Mutex
+	key      string
+	interval time.Duration
+	clock    func() time.Time
+	data     api.Rates
+	updated  time.Time
+}
+
+type cacheEntry struct {
+	Updated time.Time
+	Rates   api.Rates
+}
+
+// cacheKey identifies the tariff by type and configuration
+func cacheKey(typ string, other map[string]interface{}) string {
+	// maps are printed in key order
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%v", other)))
+	return fmt.Sprintf("tariff.%s.%x", typ, hash[:8])
+}
+
+// newCache creates a rate cache for given tariff type and configuration and restores persisted rates
+func newCache(typ string, other map[string]interface{}, interval time.Duration) *cache {
+	c := &cache{
+		key:      cacheKey(typ, other),
+		interval: interval,
+		clock:    time.Now,
+	}
+
+	var entry cacheEntry
+	if err := settings.Json(c.key, &entry); err == nil {
+		c.updated = entry.Updated
+		c.data = c.valid(entry.Rates)
+	}
+
+	return c
+}
+
+// valid returns the rates that have not yet expired
+func (c *cache) valid(rates api.Rates) api.Rates {
+	now := c.clock()
+
+	var res api.Rates
+	for _, r := range rates {
+		if r.End.After(now) {
+			res = append(res, api.Rate{Start: r.Start.Local(), End: r.End.Local(), Price: r.Price})
+		}
+	}
+
+	return res
+}
+
+// Set updates and persists the rates
+func (c *cache) Set(rates api.Rates) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.updated = c.clock()
+	c.data = slices.Clone(rates)
+
+	_ = settings.SetJson(c.key, cacheEntry{
+		Updated: c.updated,
+		Rates:   c.data,
+	})
+}
+
+// Get returns the unexpired rates and api.ErrOutdated if the last update is older than twice the update interval
+func (c *cache) Get() (api.Rates, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.clock().Sub(c.updated) > 2*c.interval {
+		return c.valid(c.data), api.ErrOutdated
+	}
+
+	return c.valid(c.data), nil
+}
+
+// Updated implements the api.TariffUpdated interface
+func (c *cache) Updated() time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.updated
+}
+
+// initError returns the error of the initial update unless persisted rates are still available
+func (c *cache) initError(err error) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if len(c.valid(c.data)) > 0 {
+		return nil
+	}
+
+	return err
+}
+
+// retryBackoff limits update retries to a fraction of the update interval
+var retryBackoff = func() backoff.BackOff {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = time.Second
+	bo.MaxInterval = time.Minute
+	bo.MaxElapsedTime = 5 * time.Minute
+	return bo
+}
+
+// update executes fn with exponential backoff. Failed attempts complete the initial update
+// if persisted rates are still available, otherwise the initial update fails with the error.
+func (c *cache) update(log *util.Logger, once *sync.Once, done chan error, fn func() error) error {
+	err := backoff.RetryNotify(func() error {
+		return request.BackoffPermanentError(fn())
+	}, retryBackoff(), func(err error, d time.Duration) {
+		log.WARN.Printf("%v, retrying in %v", err, d.Round(time.Second))
+		once.Do(func() { done <- c.initError(err) })
+	})
+
+	if err != nil {
+		once.Do(func() { done <- c.initError(err) })
+		log.ERROR.Println(err)
+	}
+
+	return err
+}
@@ -0,0 +1,116 @@
+package tariff
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	other := map[string]interface{}{"region": "test"}
+
+	c := newCache("test", other, time.Hour)
+	c.clock = func() time.Time { return now }
+
+	rates, err := c.Get()
+	assert.ErrorIs(t, err, api.ErrOutdated, "never updated")
+	assert.Empty(t, rates)
+
+	c.Set(api.Rates{
+		{Start: now.Add(-time.Hour), End: now, Price: 1},
+		{Start: now, End: now.Add(time.Hour), Price: 2},
+		{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour), Price: 3},
+	})
+
+	// expired rates are dropped
+	rates, err = c.Get()
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	assert.Equal(t, 2.0, rates[0].Price)
+	assert.Equal(t, now, c.Updated())
+
+	// rates are restored from settings after restart
+	restored := newCache("test", other, time.Hour)
+	restored.clock = func() time.Time { return now.Add(90 * time.Minute) }
+
+	rates, err = restored.Get()
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	assert.Equal(t, 3.0, rates[0].Price)
+	assert.True(t, now.Equal(restored.Updated()))
+
+	// outdated rates are dropped once expired
+	restored.clock = func() time.Time { return now.Add(150 * time.Minute) }
+	rates, err = restored.Get()
+	assert.ErrorIs(t, err, api.ErrOutdated)
+	assert.Empty(t, rates)
+
+	// configurations do not share rates
+	rates, _ = newCache("test", map[string]interface{}{"region": "other"}, time.Hour).Get()
+	assert.Empty(t, rates)
+}
+
+func TestCacheUpdate(t *testing.T) {
+	defer func(bo func() backoff.BackOff) { retryBackoff = bo }(retryBackoff)
+	retryBackoff = func() backoff.BackOff {
+		return backoff.WithMaxRetries(&backoff.ZeroBackOff{}, 2)
+	}
+
+	log := util.NewLogger("foo")
+	errFoo := errors.New("foo")
+
+	// initial update fails without cached rates
+	c := newCache("test", map[string]interface{}{"update": 1}, time.Hour)
+
+	var (
+		once     sync.Once
+		attempts int
+	)
+	done := make(chan error, 1)
+
+	err := c.update(log, &once, done, func() error {
+		attempts++
+		return errFoo
+	})
+	assert.ErrorIs(t, err, errFoo)
+	assert.Equal(t, 3, attempts)
+	assert.ErrorIs(t, <-done, errFoo)
+
+	// initial update succeeds with cached rates
+	c.Set(api.Rates{{Start: time.Now(), End: time.Now().Add(time.Hour)}})
+
+	once = sync.Once{}
+	err = c.update(log, &once, done, func() error {
+		return errFoo
+	})
+	assert.Error(t, err)
+	assert.NoError(t, <-done)
+
+	// client errors are not retried
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	attempts = 0
+	once = sync.Once{}
+	err = c.update(log, &once, done, func() error {
+		_, err := request.NewHelper(log).GetBody(srv.URL)
+		return err
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+	<-done
+}
@@ -0,0 +1,161 @@
+package tariff
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"golang.org/x/exp/slices"
+)
+
+// Composite is a tariff adding the rates of multiple tariffs slot by slot
+type Composite struct {
+	*embed
+	tariffs []api.Tariff
+}
+
+var _ api.Tariff = (*Composite)(nil)
+
+func init() {
+	registry.Add("composite", NewCompositeFromConfig)
+}
+
+// NewCompositeFromConfig creates a composite tariff from its component tariffs.
+// Charges are added to the sum of the components, tax is applied to the total.
+func NewCompositeFromConfig(other map[string]interface{}) (api.Tariff, error) {
+	var cc struct {
+		embed   `mapstructure:",squash"`
+		Tariffs []struct {
+			Type  string
+			Other map[string]interface{} `mapstructure:",remain"`
+		}
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if len(cc.Tariffs) == 0 {
+		return nil, errors.New("missing tariffs")
+	}
+
+	t := &Composite{
+		embed: &cc.embed,
+	}
+
+	for i, tc := range cc.Tariffs {
+		tf, err := NewFromConfig(tc.Type, tc.Other)
+		if err != nil {
+			return nil, fmt.Errorf("tariff %d: %w", i+1, err)
+		}
+
+		if tf.Type() == api.TariffTypeCo2 {
+			return nil, fmt.Errorf("tariff %d: co2 tariffs cannot be combined", i+1)
+		}
+
+		t.tariffs = append(t.tariffs, tf)
+	}
+
+	return t, nil
+}
+
+// Rates implements the api.Tariff interface.
+// The result only covers slots for which all component tariffs provide rates.
+func (t *Composite) Rates() (api.Rates, error) {
+	var (
+		outdated error
+		rates    = make([]api.Rates, 0, len(t.tariffs))
+	)
+
+	for _, tf := range t.tariffs {
+		rr, err := tf.Rates()
+		if err != nil {
+			if !errors.Is(err, api.ErrOutdated) {
+				return nil, err
+			}
+			outdated = err
+		}
+
+		rr = slices.Clone(rr)
+		slices.SortFunc(rr, func(a, b api.Rate) bool {
+			return a.Start.Before(b.Start)
+		})
+
+		rates = append(rates, rr)
+	}
+
+	return t.merge(rates), outdated
+}
+
+// merge splits the rates at all slot boundaries and adds the component prices
+func (t *Composite) merge(rates []api.Rates) api.Rates {
+	var ts []time.Time
+	for _, rr := range rates {
+		for _, r := range rr {
+			ts = append(ts, r.Start, r.End)
+		}
+	}
+
+	slices.SortFunc(ts, func(a, b time.Time) bool {
+		return a.Before(b)
+	})
+	ts = slices.CompactFunc(ts, func(a, b time.Time) bool {
+		return a.Equal(b)
+	})
+
+	// index of the current rate per component
+	idx := make([]int, len(rates))
+
+	var res api.Rates
+
+SLOT:
+	for i := 0; i+1 < len(ts); i++ {
+		start, end := ts[i], ts[i+1]
+
+		var price float64
+		for j, rr := range rates {
+			for idx[j] < len(rr) && !rr[idx[j]].End.After(start) {
+				idx[j]++
+			}
+
+			if idx[j] == len(rr) || rr[idx[j]].Start.After(start) {
+				continue SLOT
+			}
+
+			price += rr[idx[j]].Price
+		}
+
+		res = append(res, api.Rate{
+			Start: start.Local(),
+			End:   end.Local(),
+			Price: t.totalPrice(price),
+		})
+	}
+
+	return res
+}
+
+// Type implements the api.Tariff interface
+func (t *Composite) Type() api.TariffType {
+	for _, tf := range t.tariffs {
+		if tf.Type() == api.TariffTypePriceDynamic {
+			return api.TariffTypePriceDynamic
+		}
+	}
+	return api.TariffTypePriceStatic
+}
+
+// Updated implements the api.TariffUpdated interface and returns the oldest component update
+func (t *Composite) Updated() time.Time {
+	var res time.Time
+	for _, tf := range t.tariffs {
+		if tu, ok := tf.(api.TariffUpdated); ok {
+			if ts := tu.Updated(); res.IsZero() || ts.Before(res) {
+				res = ts
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,102 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComposite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ts := time.Date(2023, 11, 14, 0, 0, 0, 0, time.Local)
+	at := func(minutes int) time.Time {
+		return ts.Add(time.Duration(minutes) * time.Minute)
+	}
+
+	spot := mock.NewMockTariff(ctrl)
+	spot.EXPECT().Type().Return(api.TariffTypePriceDynamic).AnyTimes()
+	spot.EXPECT().Rates().Return(api.Rates{
+		{Start: at(60), End: at(120), Price: 0.2},
+		{Start: at(0), End: at(60), Price: 0.1},
+	}, nil)
+
+	fee := mock.NewMockTariff(ctrl)
+	fee.EXPECT().Type().Return(api.TariffTypePriceStatic).AnyTimes()
+	fee.EXPECT().Rates().Return(api.Rates{
+		{Start: at(0), End: at(30), Price: 0.05},
+		{Start: at(30), End: at(180), Price: 0.1},
+	}, nil)
+
+	tf := &Composite{
+		embed:   &embed{Charges: 0.02, Tax: 0.19},
+		tariffs: []api.Tariff{spot, fee},
+	}
+
+	assert.Equal(t, api.TariffTypePriceDynamic, tf.Type())
+
+	rates, err := tf.Rates()
+	require.NoError(t, err)
+
+	// slots not covered by all tariffs are omitted
+	expect := []struct {
+		start, end int
+		price      float64
+	}{
+		{0, 30, (0.1 + 0.05 + 0.02) * 1.19},
+		{30, 60, (0.1 + 0.1 + 0.02) * 1.19},
+		{60, 120, (0.2 + 0.1 + 0.02) * 1.19},
+	}
+
+	require.Len(t, rates, len(expect))
+	for i, e := range expect {
+		assert.True(t, at(e.start).Equal(rates[i].Start), "start %d", i)
+		assert.True(t, at(e.end).Equal(rates[i].End), "end %d", i)
+		assert.InDelta(t, e.price, rates[i].Price, 1e-6, "price %d", i)
+	}
+
+	// outdated rates are still merged
+	spot.EXPECT().Rates().Return(api.Rates{{Start: at(0), End: at(60), Price: 0.1}}, api.ErrOutdated)
+	fee.EXPECT().Rates().Return(api.Rates{{Start: at(0), End: at(60), Price: 0.1}}, nil)
+
+	rates, err = tf.Rates()
+	assert.ErrorIs(t, err, api.ErrOutdated)
+	assert.Len(t, rates, 1)
+}
+
+func TestCompositeFromConfig(t *testing.T) {
+	tf, err := NewCompositeFromConfig(map[string]interface{}{
+		"tariffs": []map[string]interface{}{
+			{"type": "fixed", "price": 0.1},
+			{"type": "fixed", "price": 0.05, "zones": []map[string]interface{}{
+				{"hours": "0-6", "price": 0.01},
+			}},
+		},
+		"tax": 0.1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, api.TariffTypePriceStatic, tf.Type())
+
+	rates, err := tf.Rates()
+	require.NoError(t, err)
+	require.NotEmpty(t, rates)
+
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	r, err := rates.Current(midnight)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.11*1.1, r.Price, 1e-6)
+
+	r, err = rates.Current(midnight.Add(12 * time.Hour))
+	require.NoError(t, err)
+	assert.InDelta(t, 0.15*1.1, r.Price, 1e-6)
+
+	_, err = NewCompositeFromConfig(map[string]interface{}{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,288 @@
+package tariff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/provider"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/jq"
+	"github.com/itchyny/gojq"
+	"golang.org/x/exp/slices"
+)
+
+// Custom is a tariff retrieving rates from a http, script or mqtt source
+type Custom struct {
+	*embed
+	*cache
+	log      *util.Logger
+	typ      api.TariffType
+	get      func() (string, error)
+	rates    *gojq.Query
+	start    *gojq.Query
+	end      *gojq.Query
+	price    *gojq.Query
+	format   string
+	divisor  float64
+	interval time.Duration
+}
+
+var (
+	_ api.Tariff        = (*Custom)(nil)
+	_ api.TariffUpdated = (*Custom)(nil)
+)
+
+func init() {
+	registry.Add("custom", NewCustomFromConfig)
+}
+
+// NewCustomFromConfig creates a custom tariff. The source response is split into rates by the rates query,
+// start, end and price are extracted from each rate using the respective queries.
+func NewCustomFromConfig(other map[string]interface{}) (api.Tariff, error) {
+	cc := struct {
+		embed    `mapstructure:",squash"`
+		Tariff   string // price or co2
+		Forecast provider.Config
+		Rates    string
+		Start    string
+		End      string
+		Price    string
+		Format   string // rfc3339, unix, unixms or time layout
+		Divisor  float64
+		Interval time.Duration
+	}{
+		Tariff:   "price",
+		Rates:    ".[]",
+		Start:    ".start",
+		End:      ".end",
+		Price:    ".price",
+		Format:   "rfc3339",
+		Divisor:  1,
+		Interval: time.Hour,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	typ := api.TariffTypePriceDynamic
+	switch strings.ToLower(cc.Tariff) {
+	case "price":
+	case "co2":
+		typ = api.TariffTypeCo2
+	default:
+		return nil, fmt.Errorf("invalid tariff type: %s", cc.Tariff)
+	}
+
+	if cc.Divisor == 0 {
+		return nil, errors.New("invalid divisor")
+	}
+
+	get, err := provider.NewStringGetterFromConfig(cc.Forecast)
+	if err != nil {
+		return nil, fmt.Errorf("forecast: %w", err)
+	}
+
+	t := &Custom{
+		embed:    &cc.embed,
+		cache:    newCache("custom", other, cc.Interval),
+		log:      util.NewLogger("custom"),
+		typ:      typ,
+		get:      get,
+		format:   strings.ToLower(cc.Format),
+		divisor:  cc.Divisor,
+		interval: cc.Interval,
+	}
+
+	for _, q := range []struct {
+		query *(*gojq.Query)
+		expr  string
+	}{
+		{&t.rates, cc.Rates},
+		{&t.start, cc.Start},
+		{&t.end, cc.End},
+		{&t.price, cc.Price},
+	} {
+		if *q.query, err = gojq.Parse(q.expr); err != nil {
+			return nil, fmt.Errorf("invalid jq query '%s': %w", q.expr, err)
+		}
+	}
+
+	done := make(chan error)
+	go t.run(done)
+	err = <-done
+
+	return t, err
+}
+
+func (t *Custom) run(done chan error) {
+	var once sync.Once
+
+	for ; true; <-time.Tick(t.interval) {
+		var data api.Rates
+		if err := t.update(t.log, &once, done, func() error {
+			s, err := t.get()
+			if err != nil {
+				return err
+			}
+
+			// invalid responses are not retried
+			if data, err = t.parse([]byte(s)); err != nil {
+				return backoff.Permanent(err)
+			}
+
+			return nil
+		}); err != nil {
+			continue
+		}
+
+		t.Set(data)
+		once.Do(func() { close(done) })
+	}
+}
+
+// parse converts the response into rates
+func (t *Custom) parse(b []byte) (api.Rates, error) {
+	var j interface{}
+	if err := json.Unmarshal(b, &j); err != nil {
+		return nil, err
+	}
+
+	var res api.Rates
+
+	iter := t.rates.Run(j)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+
+		if err, ok := v.(error); ok {
+			return nil, fmt.Errorf("rates: %w", err)
+		}
+
+		r, err := t.rate(v)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, r)
+	}
+
+	if len(res) == 0 {
+		return nil, errors.New("no rates")
+	}
+
+	slices.SortFunc(res, func(a, b api.Rate) bool {
+		return a.Start.Before(b.Start)
+	})
+
+	return res, nil
+}
+
+// query runs the query against a single rate and expects a single result
+func query(q *gojq.Query, v interface{}) (interface{}, error) {
+	iter := q.Run(v)
+
+	res, ok := iter.Next()
+	if !ok {
+		return nil, errors.New("empty result")
+	}
+
+	if err, ok := res.(error); ok {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// rate extracts a single rate
+func (t *Custom) rate(v interface{}) (api.Rate, error) {
+	var res api.Rate
+
+	start, err := query(t.start, v)
+	if err == nil {
+		res.Start, err = t.timestamp(start)
+	}
+	if err != nil {
+		return res, fmt.Errorf("start: %w", err)
+	}
+
+	end, err := query(t.end, v)
+	if err == nil {
+		res.End, err = t.timestamp(end)
+	}
+	if err != nil {
+		return res, fmt.Errorf("end: %w", err)
+	}
+
+	price, err := query(t.price, v)
+	if err == nil {
+		res.Price, err = number(price)
+	}
+	if err != nil {
+		return res, fmt.Errorf("price: %w", err)
+	}
+
+	res.Price /= t.divisor
+	if t.typ != api.TariffTypeCo2 {
+		res.Price = t.totalPrice(res.Price)
+	}
+
+	return res, nil
+}
+
+// number converts numeric or string values to float
+func number(v interface{}) (float64, error) {
+	if s, ok := v.(string); ok {
+		return strconv.ParseFloat(strings.TrimSpace(s), 64)
+	}
+	return jq.Float64(v)
+}
+
+// timestamp converts the value to time according to the configured format
+func (t *Custom) timestamp(v interface{}) (time.Time, error) {
+	switch t.format {
+	case "unix", "unixms":
+		f, err := number(v)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if t.format == "unixms" {
+			return time.UnixMilli(int64(f)).Local(), nil
+		}
+		return time.Unix(int64(f), 0).Local(), nil
+
+	default:
+		s, ok := v.(string)
+		if !ok {
+			return time.Time{}, fmt.Errorf("unexpected time type: %T", v)
+		}
+
+		layout := time.RFC3339
+		if t.format != "rfc3339" {
+			layout = t.format
+		}
+
+		ts, err := time.ParseInLocation(layout, s, time.Local)
+		return ts.Local(), err
+	}
+}
+
+// Rates implements the api.Tariff interface
+func (t *Custom) Rates() (api.Rates, error) {
+	return t.Get()
+}
+
+// Type implements the api.Tariff interface
+func (t *Custom) Type() api.TariffType {
+	return t.typ
+}
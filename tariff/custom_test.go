@@ -0,0 +1,78 @@
+package tariff
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustom(t *testing.T) {
+	ts := time.Now().Truncate(time.Hour).Unix()
+
+	tf, err := NewCustomFromConfig(map[string]interface{}{
+		"forecast": map[string]interface{}{
+			"source": "script",
+			"cmd": fmt.Sprintf(`echo '{"data":[{"from":%d,"to":%d,"value":"200"},{"from":%d,"to":%d,"value":100}]}'`,
+				ts+3600, ts+7200, ts, ts+3600),
+		},
+		"rates":   ".data[]",
+		"start":   ".from",
+		"end":     ".to",
+		"price":   ".value",
+		"format":  "unix",
+		"divisor": 1000,
+		"charges": 0.1,
+		"tax":     0.5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, api.TariffTypePriceDynamic, tf.Type())
+
+	rates, err := tf.Rates()
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+
+	assert.Equal(t, time.Unix(ts, 0), rates[0].Start)
+	assert.Equal(t, time.Unix(ts+3600, 0), rates[0].End)
+	assert.InDelta(t, 0.3, rates[0].Price, 1e-6)
+	assert.InDelta(t, 0.45, rates[1].Price, 1e-6)
+}
+
+func TestCustomCo2(t *testing.T) {
+	ts := time.Now().UTC().Truncate(time.Hour)
+
+	tf, err := NewCustomFromConfig(map[string]interface{}{
+		"tariff": "co2",
+		"forecast": map[string]interface{}{
+			"source": "script",
+			"cmd": fmt.Sprintf(`echo '[{"start":"%s","end":"%s","price":300}]'`,
+				ts.Format(time.RFC3339), ts.Add(time.Hour).Format(time.RFC3339)),
+		},
+		"charges": 0.1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, api.TariffTypeCo2, tf.Type())
+
+	rates, err := tf.Rates()
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+
+	assert.True(t, ts.Equal(rates[0].Start))
+	assert.Equal(t, 300.0, rates[0].Price)
+
+	// invalid rates
+	_, err = NewCustomFromConfig(map[string]interface{}{
+		"forecast": map[string]interface{}{
+			"source": "script",
+			"cmd":    `echo '[{"start":"foo"}]'`,
+		},
+	})
+	assert.Error(t, err)
+
+	// invalid type
+	_, err = NewCustomFromConfig(map[string]interface{}{"tariff": "foo"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,33 @@
+package ecb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// URI is the ECB daily euro foreign exchange reference rates feed
+const URI = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+type Envelope struct {
+	Rates []Rate `xml:"Cube>Cube>Cube"`
+}
+
+type Rate struct {
+	Currency string  `xml:"currency,attr"`
+	Rate     float64 `xml:"rate,attr"`
+}
+
+// Rate returns the exchange rate from EUR to the given currency
+func (e Envelope) Rate(currency string) (float64, error) {
+	if strings.EqualFold(currency, "EUR") {
+		return 1, nil
+	}
+
+	for _, r := range e.Rates {
+		if strings.EqualFold(r.Currency, currency) {
+			return r.Rate, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown currency: %s", currency)
+}
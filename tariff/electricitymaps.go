@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
@@ -15,12 +16,10 @@ import (
 
 type ElectricityMaps struct {
 	*request.Helper
-	log     *util.Logger
-	mux     sync.Mutex
-	uri     string
-	zone    string
-	data    []CarbonIntensitySlot
-	updated time.Time
+	*cache
+	log  *util.Logger
+	uri  string
+	zone string
 }
 
 type CarbonIntensity struct {
@@ -34,7 +33,10 @@ type CarbonIntensitySlot struct {
 	Datetime        time.Time // "2022-12-12T16:00:00.000Z"
 }
 
-var _ api.Tariff = (*ElectricityMaps)(nil)
+var (
+	_ api.Tariff        = (*ElectricityMaps)(nil)
+	_ api.TariffUpdated = (*ElectricityMaps)(nil)
+)
 
 func init() {
 	registry.Add("electricitymaps", NewElectricityMapsFromConfig)
@@ -56,6 +58,7 @@ func NewElectricityMapsFromConfig(other map[string]interface{}) (api.Tariff, err
 	log := util.NewLogger("em").Redact(cc.Token)
 
 	t := &ElectricityMaps{
+		cache:  newCache("electricitymaps", other, time.Hour),
 		log:    log,
 		Helper: request.NewHelper(log),
 		uri:    util.DefaultScheme(strings.TrimRight(cc.Uri, "/"), "https"),
@@ -82,41 +85,34 @@ func (t *ElectricityMaps) run(done chan error) {
 
 	for ; true; <-time.Tick(time.Hour) {
 		var res CarbonIntensity
-		if err := t.GetJSON(uri, &res); err != nil {
-			if res.Error != "" {
-				err = errors.New(res.Error)
+		if err := t.update(t.log, &once, done, func() error {
+			err := t.GetJSON(uri, &res)
+			if err != nil && res.Error != "" {
+				err = backoff.Permanent(errors.New(res.Error))
 			}
-
-			once.Do(func() { done <- err })
-
-			t.log.ERROR.Println(err)
+			return err
+		}); err != nil {
 			continue
 		}
 
-		once.Do(func() { close(done) })
+		data := make(api.Rates, 0, len(res.Forecast))
+		for _, r := range res.Forecast {
+			ar := api.Rate{
+				Start: r.Datetime.Local(),
+				End:   r.Datetime.Add(time.Hour).Local(),
+				Price: r.CarbonIntensity,
+			}
+			data = append(data, ar)
+		}
 
-		t.mux.Lock()
-		t.updated = time.Now()
-		t.data = res.Forecast
-		t.mux.Unlock()
+		t.Set(data)
+		once.Do(func() { close(done) })
 	}
 }
 
+// Rates implements the api.Tariff interface
 func (t *ElectricityMaps) Rates() (api.Rates, error) {
-	t.mux.Lock()
-	defer t.mux.Unlock()
-
-	res := make(api.Rates, 0, len(t.data))
-	for _, r := range t.data {
-		ar := api.Rate{
-			Start: r.Datetime.Local(),
-			End:   r.Datetime.Add(time.Hour).Local(),
-			Price: r.CarbonIntensity,
-		}
-		res = append(res, ar)
-	}
-
-	return res, outdatedError(t.updated, time.Hour)
+	return t.Get()
 }
 
 // Type returns the tariff type
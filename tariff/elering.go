@@ -12,20 +12,20 @@ import (
 	"github.com/evcc-io/evcc/tariff/elering"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
-	"golang.org/x/exp/slices"
 )
 
 type Elering struct {
 	*embed
-	mux     sync.Mutex
-	log     *util.Logger
-	unit    string
-	region  string
-	data    api.Rates
-	updated time.Time
+	*cache
+	log    *util.Logger
+	unit   string
+	region string
 }
 
-var _ api.Tariff = (*Elering)(nil)
+var (
+	_ api.Tariff        = (*Elering)(nil)
+	_ api.TariffUpdated = (*Elering)(nil)
+)
 
 func init() {
 	registry.Add("elering", NewEleringFromConfig)
@@ -48,6 +48,7 @@ func NewEleringFromConfig(other map[string]interface{}) (api.Tariff, error) {
 
 	t := &Elering{
 		embed:  &cc.embed,
+		cache:  newCache("elering", other, time.Hour),
 		log:    util.NewLogger("Elering"),
 		unit:   cc.Currency,
 		region: strings.ToLower(cc.Region),
@@ -72,22 +73,14 @@ func (t *Elering) run(done chan error) {
 			url.QueryEscape(ts.Format(time.RFC3339)),
 			url.QueryEscape(ts.Add(48*time.Hour).Format(time.RFC3339)))
 
-		if err := client.GetJSON(uri, &res); err != nil {
-			once.Do(func() { done <- err })
-
-			t.log.ERROR.Println(err)
+		if err := t.update(t.log, &once, done, func() error {
+			return client.GetJSON(uri, &res)
+		}); err != nil {
 			continue
 		}
 
-		once.Do(func() { close(done) })
-
-		t.mux.Lock()
-		t.updated = time.Now()
-
-		data := res.Data[t.region]
-
-		t.data = make(api.Rates, 0, len(data))
-		for _, r := range data {
+		data := make(api.Rates, 0, len(res.Data[t.region]))
+		for _, r := range res.Data[t.region] {
 			ts := time.Unix(r.Timestamp, 0)
 
 			ar := api.Rate{
@@ -95,18 +88,17 @@ func (t *Elering) run(done chan error) {
 				End:   ts.Add(time.Hour).Local(),
 				Price: t.totalPrice(r.Price / 1e3),
 			}
-			t.data = append(t.data, ar)
+			data = append(data, ar)
 		}
 
-		t.mux.Unlock()
+		t.Set(data)
+		once.Do(func() { close(done) })
 	}
 }
 
 // Rates implements the api.Tariff interface
 func (t *Elering) Rates() (api.Rates, error) {
-	t.mux.Lock()
-	defer t.mux.Unlock()
-	return slices.Clone(t.data), outdatedError(t.updated, time.Hour)
+	return t.Get()
 }
 
 // Type returns the tariff type
@@ -0,0 +1,194 @@
+package tariff
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff/ecb"
+	"github.com/evcc-io/evcc/tariff/entsoe"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"golang.org/x/exp/slices"
+)
+
+type Entsoe struct {
+	*embed
+	*cache
+	log      *util.Logger
+	client   *request.Helper
+	uri      string
+	fxURI    string
+	token    string
+	domain   string
+	currency string
+}
+
+var (
+	_ api.Tariff        = (*Entsoe)(nil)
+	_ api.TariffUpdated = (*Entsoe)(nil)
+)
+
+func init() {
+	registry.Add("entsoe", NewEntsoeFromConfig)
+}
+
+// NewEntsoeFromConfig creates an ENTSO-E Transparency Platform day-ahead tariff.
+// Prices are published in EUR and converted using the ECB reference rates for other currencies.
+func NewEntsoeFromConfig(other map[string]interface{}) (api.Tariff, error) {
+	cc := struct {
+		embed    `mapstructure:",squash"`
+		Token    string
+		Domain   string
+		Currency string
+	}{
+		Currency: "EUR",
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Token == "" {
+		return nil, errors.New("missing token")
+	}
+
+	if cc.Domain == "" {
+		return nil, errors.New("missing domain")
+	}
+
+	log := util.NewLogger("entsoe").Redact(cc.Token)
+
+	t := &Entsoe{
+		embed:    &cc.embed,
+		cache:    newCache("entsoe", other, time.Hour),
+		log:      log,
+		client:   request.NewHelper(log),
+		uri:      entsoe.URI,
+		fxURI:    ecb.URI,
+		token:    cc.Token,
+		domain:   entsoe.Domain(cc.Domain),
+		currency: strings.ToUpper(cc.Currency),
+	}
+
+	done := make(chan error)
+	go t.run(done)
+	err := <-done
+
+	return t, err
+}
+
+func (t *Entsoe) run(done chan error) {
+	var once sync.Once
+
+	for ; true; <-time.Tick(time.Hour) {
+		var data api.Rates
+		if err := t.update(t.log, &once, done, func() (err error) {
+			data, err = t.rates(time.Now())
+			return err
+		}); err != nil {
+			continue
+		}
+
+		t.Set(data)
+		once.Do(func() { close(done) })
+	}
+}
+
+// rates retrieves today's and tomorrow's prices
+func (t *Entsoe) rates(now time.Time) (api.Rates, error) {
+	start := now.UTC().Truncate(24 * time.Hour)
+
+	uri := fmt.Sprintf("%s?securityToken=%s&documentType=A44&in_Domain=%s&out_Domain=%s&periodStart=%s&periodEnd=%s", t.uri,
+		url.QueryEscape(t.token), url.QueryEscape(t.domain), url.QueryEscape(t.domain),
+		start.Format(entsoe.TimeFormat), start.Add(48*time.Hour).Format(entsoe.TimeFormat))
+
+	b, err := t.client.GetBody(uri)
+	if err != nil {
+		var ack entsoe.AcknowledgementMarketDocument
+		if xml.Unmarshal(b, &ack) == nil && len(ack.Reason) > 0 {
+			err = backoff.Permanent(ack)
+		}
+		return nil, err
+	}
+
+	var doc entsoe.PublicationMarketDocument
+	if err := xml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	var res api.Rates
+	for _, ts := range doc.TimeSeries {
+		if !strings.EqualFold(ts.MeasureUnit, "MWH") {
+			return nil, fmt.Errorf("unexpected unit: %s", ts.MeasureUnit)
+		}
+
+		rate, err := t.exchangeRate(ts.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range ts.Period {
+			prices, err := p.Prices()
+			if err != nil {
+				return nil, err
+			}
+
+			for _, p := range prices {
+				res = append(res, api.Rate{
+					Start: p.Start.Local(),
+					End:   p.End.Local(),
+					Price: t.totalPrice(p.Value * rate / 1e3),
+				})
+			}
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, errors.New("no prices")
+	}
+
+	slices.SortFunc(res, func(a, b api.Rate) bool {
+		return a.Start.Before(b.Start)
+	})
+
+	return res, nil
+}
+
+// exchangeRate returns the conversion rate from the published to the configured currency
+func (t *Entsoe) exchangeRate(currency string) (float64, error) {
+	if currency == "" || strings.EqualFold(currency, t.currency) {
+		return 1, nil
+	}
+
+	if !strings.EqualFold(currency, "EUR") {
+		return 0, fmt.Errorf("unexpected currency: %s", currency)
+	}
+
+	var res ecb.Envelope
+	b, err := t.client.GetBody(t.fxURI)
+	if err == nil {
+		err = xml.Unmarshal(b, &res)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("exchange rate: %w", err)
+	}
+
+	return res.Rate(t.currency)
+}
+
+// Rates implements the api.Tariff interface
+func (t *Entsoe) Rates() (api.Rates, error) {
+	return t.Get()
+}
+
+// Type returns the tariff type
+func (t *Entsoe) Type() api.TariffType {
+	return api.TariffTypePriceDynamic
+}
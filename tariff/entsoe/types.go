@@ -0,0 +1,170 @@
+package entsoe
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// URI is the ENTSO-E Transparency Platform restful api
+const URI = "https://web-api.tp.entsoe.eu/api"
+
+// TimeFormat is the period start and end format of the api
+const TimeFormat = "200601021504"
+
+// Domains maps bidding zones to their EIC codes
+var Domains = map[string]string{
+	"AT":    "10YAT-APG------L",
+	"BE":    "10YBE----------2",
+	"CH":    "10YCH-SWISSGRIDZ",
+	"CZ":    "10YCZ-CEPS-----N",
+	"DE-LU": "10Y1001A1001A82H",
+	"DK1":   "10YDK-1--------W",
+	"DK2":   "10YDK-2--------M",
+	"EE":    "10Y1001A1001A39I",
+	"ES":    "10YES-REE------0",
+	"FI":    "10YFI-1--------U",
+	"FR":    "10YFR-RTE------C",
+	"HU":    "10YHU-MAVIR----U",
+	"LT":    "10YLT-1001A0008Q",
+	"LV":    "10YLV-1001A00074",
+	"NL":    "10YNL----------L",
+	"NO1":   "10YNO-1--------2",
+	"NO2":   "10YNO-2--------T",
+	"NO3":   "10YNO-3--------J",
+	"NO4":   "10YNO-4--------9",
+	"NO5":   "10Y1001A1001A48H",
+	"PL":    "10YPL-AREA-----S",
+	"PT":    "10YPT-REN------W",
+	"SE1":   "10Y1001A1001A44P",
+	"SE2":   "10Y1001A1001A45N",
+	"SE3":   "10Y1001A1001A46L",
+	"SE4":   "10Y1001A1001A47J",
+	"SI":    "10YSI-ELES-----O",
+}
+
+// Domain returns the EIC code for the bidding zone. Unknown zones are assumed to be EIC codes.
+func Domain(zone string) string {
+	if eic, ok := Domains[strings.ToUpper(zone)]; ok {
+		return eic
+	}
+	return zone
+}
+
+// PublicationMarketDocument is the day-ahead prices (A44) document
+type PublicationMarketDocument struct {
+	TimeSeries []TimeSeries
+}
+
+// AcknowledgementMarketDocument is returned if the request could not be served
+type AcknowledgementMarketDocument struct {
+	Reason []struct {
+		Code string `xml:"code"`
+		Text string `xml:"text"`
+	}
+}
+
+func (d AcknowledgementMarketDocument) Error() string {
+	var res []string
+	for _, r := range d.Reason {
+		res = append(res, r.Text)
+	}
+	return strings.Join(res, ", ")
+}
+
+type TimeSeries struct {
+	Currency    string `xml:"currency_Unit.name"`
+	MeasureUnit string `xml:"price_Measure_Unit.name"`
+	Period      []Period
+}
+
+type Period struct {
+	TimeInterval struct {
+		Start string `xml:"start"`
+		End   string `xml:"end"`
+	} `xml:"timeInterval"`
+	Resolution string `xml:"resolution"`
+	Point      []Point
+}
+
+type Point struct {
+	Position int     `xml:"position"`
+	Price    float64 `xml:"price.amount"`
+}
+
+// Price is a single price per MWh
+type Price struct {
+	Start, End time.Time
+	Value      float64
+}
+
+// intervalFormat is the time interval format of the document
+const intervalFormat = "2006-01-02T15:04Z"
+
+// resolution parses ISO 8601 durations of the PT<n>M or PT<n>H form
+func resolution(s string) (time.Duration, error) {
+	if !strings.HasPrefix(s, "PT") || len(s) < 4 {
+		return 0, fmt.Errorf("invalid resolution: %s", s)
+	}
+
+	n, err := strconv.Atoi(s[2 : len(s)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid resolution: %s", s)
+	}
+
+	switch s[len(s)-1] {
+	case 'M':
+		return time.Duration(n) * time.Minute, nil
+	case 'H':
+		return time.Duration(n) * time.Hour, nil
+	default:
+		return 0, fmt.Errorf("invalid resolution: %s", s)
+	}
+}
+
+// Prices returns the period's prices. Omitted positions repeat the previous price.
+func (p Period) Prices() ([]Price, error) {
+	start, err := time.Parse(intervalFormat, p.TimeInterval.Start)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := time.Parse(intervalFormat, p.TimeInterval.End)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resolution(p.Resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(p.Point) == 0 {
+		return nil, errors.New("no prices")
+	}
+
+	var (
+		prices []Price
+		point  int
+	)
+
+	for pos, ts := 1, start; ts.Before(end); pos, ts = pos+1, ts.Add(res) {
+		for point+1 < len(p.Point) && p.Point[point+1].Position <= pos {
+			point++
+		}
+
+		if p.Point[point].Position > pos {
+			continue
+		}
+
+		prices = append(prices, Price{
+			Start: ts,
+			End:   ts.Add(res),
+			Value: p.Point[point].Price,
+		})
+	}
+
+	return prices, nil
+}
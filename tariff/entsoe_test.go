@@ -0,0 +1,149 @@
+package tariff
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/tariff/ecb"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const entsoeSample = `<?xml version="1.0" encoding="UTF-8"?>
+<Publication_MarketDocument xmlns="urn:iec62325.351:tc57wg16:451-3:publicationdocument:7:0">
+	<mRID>3b1a1ee5a7e24a1c8d4c4f0d3e8a7d55</mRID>
+	<revisionNumber>1</revisionNumber>
+	<type>A44</type>
+	<period.timeInterval>
+		<start>2023-11-13T23:00Z</start>
+		<end>2023-11-14T23:00Z</end>
+	</period.timeInterval>
+	<TimeSeries>
+		<mRID>1</mRID>
+		<businessType>A62</businessType>
+		<in_Domain.mRID codingScheme="A01">10YNL----------L</in_Domain.mRID>
+		<out_Domain.mRID codingScheme="A01">10YNL----------L</out_Domain.mRID>
+		<currency_Unit.name>EUR</currency_Unit.name>
+		<price_Measure_Unit.name>MWH</price_Measure_Unit.name>
+		<curveType>A03</curveType>
+		<Period>
+			<timeInterval>
+				<start>2023-11-13T23:00Z</start>
+				<end>2023-11-14T03:00Z</end>
+			</timeInterval>
+			<resolution>PT60M</resolution>
+			<Point>
+				<position>1</position>
+				<price.amount>100.00</price.amount>
+			</Point>
+			<Point>
+				<position>2</position>
+				<price.amount>90.50</price.amount>
+			</Point>
+			<Point>
+				<position>4</position>
+				<price.amount>120.00</price.amount>
+			</Point>
+		</Period>
+	</TimeSeries>
+</Publication_MarketDocument>`
+
+const entsoeAcknowledgement = `<?xml version="1.0" encoding="UTF-8"?>
+<Acknowledgement_MarketDocument xmlns="urn:iec62325.351:tc57wg16:451-1:acknowledgementdocument:7:0">
+	<mRID>6e4f5b1f-4b1c-4b8e-9d7b-0c9d5d6a2f3e</mRID>
+	<Reason>
+		<code>999</code>
+		<text>No matching data found for Data item Day-ahead Prices [12.1.D]</text>
+	</Reason>
+</Acknowledgement_MarketDocument>`
+
+const ecbSample = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+		<Cube time='2023-11-14'>
+			<Cube currency='USD' rate='1.0724'/>
+			<Cube currency='NOK' rate='11.8'/>
+			<Cube currency='SEK' rate='11.6'/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func TestEntsoe(t *testing.T) {
+	var query string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ecb":
+			_, _ = w.Write([]byte(ecbSample))
+		case "/nodata":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(entsoeAcknowledgement))
+		default:
+			query = r.URL.RawQuery
+			_, _ = w.Write([]byte(entsoeSample))
+		}
+	}))
+	defer srv.Close()
+
+	log := util.NewLogger("foo")
+
+	tf := &Entsoe{
+		embed:    &embed{Charges: 0.1},
+		log:      log,
+		client:   request.NewHelper(log),
+		uri:      srv.URL,
+		fxURI:    srv.URL + "/ecb",
+		token:    "token",
+		domain:   "10YNL----------L",
+		currency: "EUR",
+	}
+
+	now := time.Date(2023, 11, 14, 12, 0, 0, 0, time.UTC)
+
+	rates, err := tf.rates(now)
+	require.NoError(t, err)
+
+	assert.Contains(t, query, "documentType=A44")
+	assert.Contains(t, query, "periodStart=202311140000&periodEnd=202311160000")
+
+	// omitted position repeats previous price
+	require.Len(t, rates, 4)
+	for i, price := range []float64{0.2, 0.1905, 0.1905, 0.22} {
+		assert.InDelta(t, price, rates[i].Price, 1e-6)
+		assert.True(t, time.Date(2023, 11, 13, 23+i, 0, 0, 0, time.UTC).Equal(rates[i].Start))
+	}
+
+	// currency conversion
+	tf.currency = "NOK"
+	rates, err = tf.rates(now)
+	require.NoError(t, err)
+	assert.InDelta(t, 100*11.8/1e3+0.1, rates[0].Price, 1e-6)
+
+	// acknowledgement
+	tf.uri = srv.URL + "/nodata"
+	_, err = tf.rates(now)
+	require.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "No matching data found"), err.Error())
+}
+
+func TestEcbRate(t *testing.T) {
+	var res ecb.Envelope
+	require.NoError(t, xml.Unmarshal([]byte(ecbSample), &res))
+
+	rate, err := res.Rate("sek")
+	require.NoError(t, err)
+	assert.Equal(t, 11.6, rate)
+
+	_, err = res.Rate("XXX")
+	assert.Error(t, err)
+}
@@ -3,19 +3,21 @@ package tariff
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/tariff/fixed"
 	"github.com/evcc-io/evcc/util"
-	"github.com/golang-module/carbon/v2"
 )
 
 type Fixed struct {
-	unit    string
-	clock   clock.Clock
-	zones   fixed.Zones
-	dynamic bool
+	unit       string
+	clock      clock.Clock
+	zones      fixed.Zones
+	holidays   *fixed.Holidays
+	holidayDay fixed.Day
+	dynamic    bool
 }
 
 var _ api.Tariff = (*Fixed)(nil)
@@ -29,8 +31,13 @@ func NewFixedFromConfig(other map[string]interface{}) (api.Tariff, error) {
 		Currency string // TODO deprecated
 		Price    float64
 		Zones    []struct {
-			Price       float64
-			Days, Hours string
+			Price                      float64
+			Days, Hours, Months, Dates string
+		}
+		Holidays struct {
+			Region string   // ISO country or subdivision code
+			Dates  []string // custom dates or date ranges
+			Day    string   // weekday holidays are treated like
 		}
 	}
 
@@ -38,10 +45,24 @@ func NewFixedFromConfig(other map[string]interface{}) (api.Tariff, error) {
 		return nil, err
 	}
 
+	holidays, err := fixed.NewHolidays(cc.Holidays.Region, cc.Holidays.Dates)
+	if err != nil {
+		return nil, err
+	}
+
+	holidayDay := fixed.Sunday
+	if cc.Holidays.Day != "" {
+		if holidayDay, err = fixed.ParseDay(cc.Holidays.Day); err != nil {
+			return nil, err
+		}
+	}
+
 	t := &Fixed{
-		unit:    cc.Currency,
-		clock:   clock.New(),
-		dynamic: len(cc.Zones) > 1,
+		unit:       cc.Currency,
+		clock:      clock.New(),
+		holidays:   holidays,
+		holidayDay: holidayDay,
+		dynamic:    len(cc.Zones) > 1,
 	}
 
 	for _, z := range cc.Zones {
@@ -55,24 +76,39 @@ func NewFixedFromConfig(other map[string]interface{}) (api.Tariff, error) {
 			return nil, err
 		}
 
+		months, err := fixed.ParseMonths(z.Months)
+		if err != nil {
+			return nil, err
+		}
+
+		dates, err := fixed.ParseDateRanges(z.Dates)
+		if err != nil {
+			return nil, err
+		}
+
 		if len(hours) == 0 {
 			t.zones = append(t.zones, fixed.Zone{
-				Price: z.Price,
-				Days:  days,
+				Price:  z.Price,
+				Days:   days,
+				Months: months,
+				Dates:  dates,
 			})
 			continue
 		}
 
 		for _, h := range hours {
 			t.zones = append(t.zones, fixed.Zone{
-				Price: z.Price,
-				Days:  days,
-				Hours: h,
+				Price:  z.Price,
+				Days:   days,
+				Hours:  h,
+				Months: months,
+				Dates:  dates,
 			})
 		}
 	}
 
-	sort.Sort(t.zones)
+	// keep configuration order for zones with identical hours
+	sort.Stable(t.zones)
 
 	// prepend catch-all zone
 	t.zones = append([]fixed.Zone{
@@ -86,20 +122,30 @@ func NewFixedFromConfig(other map[string]interface{}) (api.Tariff, error) {
 func (t *Fixed) Rates() (api.Rates, error) {
 	var res api.Rates
 
-	start := carbon.FromStdTime(t.clock.Now().Local()).StartOfDay()
+	now := t.clock.Now().Local()
 	for i := 0; i < 7; i++ {
-		dow := fixed.Day((start.DayOfWeek() + i) % 7)
+		dayStart := time.Date(now.Year(), now.Month(), now.Day()+i, 0, 0, 0, 0, time.Local)
+		date := fixed.DateOf(dayStart)
 
-		zones := t.zones.ForDay(dow)
+		dow := fixed.Day(dayStart.Weekday())
+		if t.holidays.Contains(date) {
+			dow = t.holidayDay
+		}
+
+		zones := t.zones.ForDate(date, dow)
 		if len(zones) == 0 {
-			return nil, fmt.Errorf("no zones for weekday %d", dow)
+			return nil, fmt.Errorf("no zones for %s", date)
+		}
+
+		// wall clock time of the marker, adjusted for DST changes
+		at := func(m fixed.HourMin) time.Time {
+			return time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(), m.Hour, m.Min, 0, 0, time.Local)
 		}
 
-		dayStart := start.AddDays(i)
 		markers := zones.TimeTableMarkers()
 
 		for i, m := range markers {
-			ts := dayStart.AddMinutes(m.Minutes())
+			ts := at(m)
 
 			var zone *fixed.Zone
 			for j := len(zones) - 1; j >= 0; j-- {
@@ -114,15 +160,20 @@ func (t *Fixed) Rates() (api.Rates, error) {
 			}
 
 			// end rate at end of day or next marker
-			end := dayStart.AddDay()
+			end := time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day()+1, 0, 0, 0, 0, time.Local)
 			if i+1 < len(markers) {
-				end = dayStart.AddMinutes(markers[i+1].Minutes())
+				end = at(markers[i+1])
+			}
+
+			// skip markers within DST gap
+			if !end.After(ts) {
+				continue
 			}
 
 			rate := api.Rate{
 				Price: zone.Price,
-				Start: ts.ToStdTime(),
-				End:   end.ToStdTime(),
+				Start: ts,
+				End:   end,
 			}
 
 			res = append(res, rate)
@@ -0,0 +1,118 @@
+package fixed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Date is a calendar date. Dates without year recur annually.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date of the given time
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{y, m, d}
+}
+
+func (d Date) IsRecurring() bool {
+	return d.Year == 0
+}
+
+// key returns a comparable representation of the date, ignoring the year for recurring dates
+func (d Date) key(recurring bool) int {
+	res := 100*int(d.Month) + d.Day
+	if !recurring {
+		res += 10000 * d.Year
+	}
+	return res
+}
+
+func (d Date) String() string {
+	if d.IsRecurring() {
+		return fmt.Sprintf("%02d-%02d", d.Month, d.Day)
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// ParseDate parses a date in YYYY-MM-DD or recurring MM-DD format
+func ParseDate(s string) (Date, error) {
+	s = strings.TrimSpace(s)
+
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return DateOf(t), nil
+	}
+
+	// leap year to allow Feb 29
+	if t, err := time.Parse("2006-01-02", "2000-"+s); err == nil {
+		return Date{Month: t.Month(), Day: t.Day()}, nil
+	}
+
+	return Date{}, fmt.Errorf("invalid date: %s", s)
+}
+
+// DateRange is an inclusive range of dates
+type DateRange struct {
+	From, To Date
+}
+
+// Contains returns true if the date is within the range. Recurring ranges may wrap around the year end.
+func (dr DateRange) Contains(d Date) bool {
+	recurring := dr.From.IsRecurring()
+	from, to, k := dr.From.key(recurring), dr.To.key(recurring), d.key(recurring)
+
+	if from <= to {
+		return from <= k && k <= to
+	}
+
+	return k >= from || k <= to
+}
+
+func (dr DateRange) String() string {
+	return dr.From.String() + ".." + dr.To.String()
+}
+
+// ParseDateRanges converts a date ranges string into a slice of date ranges
+// Date ranges format:
+//
+//	date[..date][, ...]
+func ParseDateRanges(s string) ([]DateRange, error) {
+	var res []DateRange
+
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	for _, segment := range strings.Split(s, ",") {
+		from, to, ok := strings.Cut(segment, "..")
+		if !ok {
+			to = from
+		}
+
+		f, err := ParseDate(from)
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := ParseDate(to)
+		if err != nil {
+			return nil, err
+		}
+
+		if f.IsRecurring() != t.IsRecurring() {
+			return nil, fmt.Errorf("invalid date range: %s, mixed recurring and absolute dates", segment)
+		}
+
+		if !f.IsRecurring() && f.key(false) > t.key(false) {
+			return nil, fmt.Errorf("invalid date range: %s, <from> must be before <to>", segment)
+		}
+
+		res = append(res, DateRange{f, t})
+	}
+
+	return res, nil
+}
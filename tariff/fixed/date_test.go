@@ -0,0 +1,44 @@
+package fixed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDateRanges(t *testing.T) {
+	dr, err := ParseDateRanges(" 12-24 .. 12-26, 2024-06-01..2024-08-31 ")
+	require.NoError(t, err)
+	assert.Equal(t, []DateRange{
+		{Date{0, time.December, 24}, Date{0, time.December, 26}},
+		{Date{2024, time.June, 1}, Date{2024, time.August, 31}},
+	}, dr)
+
+	dr, err = ParseDateRanges("02-29")
+	require.NoError(t, err)
+	assert.Equal(t, []DateRange{{Date{0, time.February, 29}, Date{0, time.February, 29}}}, dr)
+
+	_, err = ParseDateRanges("12-24..2024-12-26")
+	assert.Error(t, err)
+
+	_, err = ParseDateRanges("2024-12-26..2024-12-24")
+	assert.Error(t, err)
+
+	_, err = ParseDateRanges("13-01")
+	assert.Error(t, err)
+}
+
+func TestDateRangeContains(t *testing.T) {
+	// recurring range wrapping year end
+	dr := DateRange{Date{0, time.December, 15}, Date{0, time.January, 15}}
+	assert.True(t, dr.Contains(Date{2023, time.December, 31}))
+	assert.True(t, dr.Contains(Date{2024, time.January, 15}))
+	assert.False(t, dr.Contains(Date{2024, time.January, 16}))
+
+	// absolute range
+	dr = DateRange{Date{2024, time.June, 1}, Date{2024, time.August, 31}}
+	assert.True(t, dr.Contains(Date{2024, time.June, 1}))
+	assert.False(t, dr.Contains(Date{2023, time.July, 1}))
+}
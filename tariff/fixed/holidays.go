@@ -0,0 +1,229 @@
+package fixed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// holiday returns the holiday's date for the given year or false if it is not observed in that year
+type holiday func(year int) (Date, bool)
+
+// fixedDate is a holiday on the same date every year
+func fixedDate(month time.Month, day int) holiday {
+	return func(year int) (Date, bool) {
+		return Date{year, month, day}, true
+	}
+}
+
+// easter is a holiday relative to Easter Sunday
+func easter(offset int) holiday {
+	return func(year int) (Date, bool) {
+		return DateOf(easterSunday(year).AddDate(0, 0, offset)), true
+	}
+}
+
+// weekdayFrom is the first given weekday on or after the date
+func weekdayFrom(month time.Month, day int, weekday time.Weekday) holiday {
+	return func(year int) (Date, bool) {
+		t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return DateOf(t.AddDate(0, 0, (int(weekday)-int(t.Weekday())+7)%7)), true
+	}
+}
+
+// weekdayBefore is the last given weekday before the date
+func weekdayBefore(month time.Month, day int, weekday time.Weekday) holiday {
+	return func(year int) (Date, bool) {
+		t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return DateOf(t.AddDate(0, 0, -((int(t.Weekday())-int(weekday)+6)%7 + 1))), true
+	}
+}
+
+// kingsDay is the Dutch King's Day, moved to Saturday if on Sunday
+func kingsDay(year int) (Date, bool) {
+	t := time.Date(year, time.April, 27, 0, 0, 0, 0, time.UTC)
+	if t.Weekday() == time.Sunday {
+		t = t.AddDate(0, 0, -1)
+	}
+	return DateOf(t), true
+}
+
+// since restricts the holiday to years starting with the given year
+func since(first int, h holiday) holiday {
+	return func(year int) (Date, bool) {
+		if year < first {
+			return Date{}, false
+		}
+		return h(year)
+	}
+}
+
+// until restricts the holiday to years up to and including the given year
+func until(last int, h holiday) holiday {
+	return func(year int) (Date, bool) {
+		if year > last {
+			return Date{}, false
+		}
+		return h(year)
+	}
+}
+
+// easterSunday calculates Easter Sunday using the anonymous Gregorian algorithm
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// public holidays by ISO 3166 country or subdivision code
+var holidays = map[string][]holiday{
+	"AT": {
+		fixedDate(time.January, 1), fixedDate(time.January, 6), easter(1), fixedDate(time.May, 1), easter(39), easter(50), easter(60),
+		fixedDate(time.August, 15), fixedDate(time.October, 26), fixedDate(time.November, 1), fixedDate(time.December, 8),
+		fixedDate(time.December, 25), fixedDate(time.December, 26),
+	},
+	"BE": {
+		fixedDate(time.January, 1), easter(1), fixedDate(time.May, 1), easter(39), easter(50), fixedDate(time.July, 21),
+		fixedDate(time.August, 15), fixedDate(time.November, 1), fixedDate(time.November, 11), fixedDate(time.December, 25),
+	},
+	"CH": {
+		fixedDate(time.January, 1), easter(-2), easter(1), easter(39), easter(50), fixedDate(time.August, 1),
+		fixedDate(time.December, 25), fixedDate(time.December, 26),
+	},
+	"DE": {
+		fixedDate(time.January, 1), easter(-2), easter(1), fixedDate(time.May, 1), easter(39), easter(50),
+		fixedDate(time.October, 3), fixedDate(time.December, 25), fixedDate(time.December, 26),
+	},
+	"DE-BB": {easter(0), easter(49), fixedDate(time.October, 31)},
+	"DE-BE": {since(2019, fixedDate(time.March, 8))},
+	"DE-BW": {fixedDate(time.January, 6), easter(60), fixedDate(time.November, 1)},
+	"DE-BY": {fixedDate(time.January, 6), easter(60), fixedDate(time.August, 15), fixedDate(time.November, 1)},
+	"DE-HB": {fixedDate(time.October, 31)},
+	"DE-HE": {easter(60)},
+	"DE-HH": {fixedDate(time.October, 31)},
+	"DE-MV": {since(2023, fixedDate(time.March, 8)), fixedDate(time.October, 31)},
+	"DE-NI": {fixedDate(time.October, 31)},
+	"DE-NW": {easter(60), fixedDate(time.November, 1)},
+	"DE-RP": {easter(60), fixedDate(time.November, 1)},
+	"DE-SH": {fixedDate(time.October, 31)},
+	"DE-SL": {easter(60), fixedDate(time.August, 15), fixedDate(time.November, 1)},
+	"DE-SN": {fixedDate(time.October, 31), weekdayBefore(time.November, 23, time.Wednesday)},
+	"DE-ST": {fixedDate(time.January, 6), fixedDate(time.October, 31)},
+	"DE-TH": {since(2019, fixedDate(time.September, 20)), fixedDate(time.October, 31)},
+	"DK": {
+		fixedDate(time.January, 1), easter(-3), easter(-2), easter(0), easter(1), until(2023, easter(26)), easter(39),
+		easter(49), easter(50), fixedDate(time.December, 25), fixedDate(time.December, 26),
+	},
+	"FI": {
+		fixedDate(time.January, 1), fixedDate(time.January, 6), easter(-2), easter(0), easter(1), fixedDate(time.May, 1),
+		easter(39), easter(49), weekdayFrom(time.June, 20, time.Saturday), weekdayFrom(time.October, 31, time.Saturday),
+		fixedDate(time.December, 6), fixedDate(time.December, 25), fixedDate(time.December, 26),
+	},
+	"FR": {
+		fixedDate(time.January, 1), easter(1), fixedDate(time.May, 1), fixedDate(time.May, 8), easter(39), easter(50),
+		fixedDate(time.July, 14), fixedDate(time.August, 15), fixedDate(time.November, 1), fixedDate(time.November, 11),
+		fixedDate(time.December, 25),
+	},
+	"IT": {
+		fixedDate(time.January, 1), fixedDate(time.January, 6), easter(0), easter(1), fixedDate(time.April, 25),
+		fixedDate(time.May, 1), fixedDate(time.June, 2), fixedDate(time.August, 15), fixedDate(time.November, 1),
+		fixedDate(time.December, 8), fixedDate(time.December, 25), fixedDate(time.December, 26),
+	},
+	"LU": {
+		fixedDate(time.January, 1), easter(1), fixedDate(time.May, 1), fixedDate(time.May, 9), easter(39), easter(50),
+		fixedDate(time.June, 23), fixedDate(time.August, 15), fixedDate(time.November, 1), fixedDate(time.December, 25),
+		fixedDate(time.December, 26),
+	},
+	"NL": {
+		fixedDate(time.January, 1), easter(0), easter(1), kingsDay, easter(39), easter(49), easter(50),
+		fixedDate(time.December, 25), fixedDate(time.December, 26),
+	},
+	"NO": {
+		fixedDate(time.January, 1), easter(-3), easter(-2), easter(0), easter(1), fixedDate(time.May, 1),
+		fixedDate(time.May, 17), easter(39), easter(49), easter(50), fixedDate(time.December, 25), fixedDate(time.December, 26),
+	},
+	"PL": {
+		fixedDate(time.January, 1), fixedDate(time.January, 6), easter(0), easter(1), fixedDate(time.May, 1),
+		fixedDate(time.May, 3), easter(49), easter(60), fixedDate(time.August, 15), fixedDate(time.November, 1),
+		fixedDate(time.November, 11), since(2025, fixedDate(time.December, 24)), fixedDate(time.December, 25),
+		fixedDate(time.December, 26),
+	},
+	"SE": {
+		fixedDate(time.January, 1), fixedDate(time.January, 6), easter(-2), easter(0), easter(1), fixedDate(time.May, 1),
+		easter(39), easter(49), fixedDate(time.June, 6), weekdayFrom(time.June, 20, time.Saturday),
+		weekdayFrom(time.October, 31, time.Saturday), fixedDate(time.December, 25), fixedDate(time.December, 26),
+	},
+}
+
+// Holidays is a calendar of public holidays and custom dates
+type Holidays struct {
+	holidays []holiday
+	dates    []DateRange
+}
+
+// NewHolidays creates a holiday calendar for the given country or subdivision like DE or DE-BY and additional custom dates
+func NewHolidays(region string, dates []string) (*Holidays, error) {
+	res := new(Holidays)
+
+	if region = strings.ToUpper(strings.TrimSpace(region)); region != "" {
+		country, _, _ := strings.Cut(region, "-")
+
+		h, ok := holidays[country]
+		if !ok {
+			return nil, fmt.Errorf("unknown holiday region: %s", region)
+		}
+		res.holidays = append(res.holidays, h...)
+
+		if region != country {
+			h, ok := holidays[region]
+			if !ok {
+				return nil, fmt.Errorf("unknown holiday region: %s", region)
+			}
+			res.holidays = append(res.holidays, h...)
+		}
+	}
+
+	for _, s := range dates {
+		dr, err := ParseDateRanges(s)
+		if err != nil {
+			return nil, err
+		}
+		res.dates = append(res.dates, dr...)
+	}
+
+	return res, nil
+}
+
+// Contains returns true if the date is a holiday
+func (h *Holidays) Contains(d Date) bool {
+	if h == nil {
+		return false
+	}
+
+	for _, hd := range h.holidays {
+		if date, ok := hd(d.Year); ok && date == d {
+			return true
+		}
+	}
+
+	for _, dr := range h.dates {
+		if dr.Contains(d) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,70 @@
+package fixed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEasterSunday(t *testing.T) {
+	for year, date := range map[int]Date{
+		2019: {2019, time.April, 21},
+		2023: {2023, time.April, 9},
+		2024: {2024, time.March, 31},
+		2025: {2025, time.April, 20},
+	} {
+		assert.Equal(t, date, DateOf(easterSunday(year)), year)
+	}
+}
+
+func TestHolidays(t *testing.T) {
+	h, err := NewHolidays("de-by", []string{"12-24", "2024-08-01..2024-08-02"})
+	require.NoError(t, err)
+
+	for _, d := range []Date{
+		{2024, time.January, 1},   // new year
+		{2024, time.March, 29},    // good friday
+		{2024, time.May, 20},      // whit monday
+		{2024, time.May, 30},      // corpus christi (BY)
+		{2024, time.October, 3},   // german unity
+		{2024, time.December, 24}, // custom
+		{2025, time.December, 24}, // custom recurring
+		{2024, time.August, 2},    // custom range
+	} {
+		assert.True(t, h.Contains(d), d)
+	}
+
+	for _, d := range []Date{
+		{2024, time.March, 28},
+		{2024, time.October, 31}, // reformation day (not BY)
+		{2024, time.August, 3},
+	} {
+		assert.False(t, h.Contains(d), d)
+	}
+
+	// moving holidays
+	h, err = NewHolidays("DE-SN", nil)
+	require.NoError(t, err)
+	assert.True(t, h.Contains(Date{2023, time.November, 22})) // day of repentance
+	assert.True(t, h.Contains(Date{2024, time.November, 20}))
+
+	h, err = NewHolidays("SE", nil)
+	require.NoError(t, err)
+	assert.True(t, h.Contains(Date{2024, time.June, 22})) // midsummer
+	assert.True(t, h.Contains(Date{2024, time.November, 2}))
+
+	h, err = NewHolidays("NL", nil)
+	require.NoError(t, err)
+	assert.True(t, h.Contains(Date{2025, time.April, 26})) // kings day moved from sunday
+
+	// nil calendar
+	assert.False(t, (*Holidays)(nil).Contains(Date{2024, time.January, 1}))
+
+	_, err = NewHolidays("XX", nil)
+	assert.Error(t, err)
+
+	_, err = NewHolidays("DE-XX", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,113 @@
+package fixed
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+var shortMonths = map[string]time.Month{
+	// english
+	"jan": time.January,
+	"feb": time.February,
+	"mar": time.March,
+	"apr": time.April,
+	"may": time.May,
+	"jun": time.June,
+	"jul": time.July,
+	"aug": time.August,
+	"sep": time.September,
+	"oct": time.October,
+	"nov": time.November,
+	"dec": time.December,
+	// german
+	"mär":      time.March,
+	"mai":      time.May,
+	"okt":      time.October,
+	"dez":      time.December,
+	"januar":   time.January,
+	"februar":  time.February,
+	"märz":     time.March,
+	"juni":     time.June,
+	"juli":     time.July,
+	"oktober":  time.October,
+	"dezember": time.December,
+}
+
+// ParseMonth parses a single month
+func ParseMonth(s string) (time.Month, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	// full string
+	for m := time.January; m <= time.December; m++ {
+		if s == strings.ToLower(m.String()) {
+			return m, nil
+		}
+	}
+
+	// short or german string
+	if m, ok := shortMonths[s]; ok {
+		return m, nil
+	}
+
+	m, err := strconv.Atoi(s)
+	if m < 1 || m > 12 || err != nil {
+		return 0, fmt.Errorf("invalid month: %s", s)
+	}
+
+	return time.Month(m), nil
+}
+
+// ParseMonths converts a months string into a slice of individual months
+// Months format:
+//
+//	month[-month][, ...]
+func ParseMonths(s string) ([]time.Month, error) {
+	var res []time.Month
+
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	for _, segment := range strings.Split(s, ",") {
+		fromto := strings.SplitN(segment, "-", 2)
+
+		from, err := ParseMonth(fromto[0])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, from)
+
+		if len(fromto) == 2 {
+			to, err := ParseMonth(fromto[1])
+			if err != nil {
+				return nil, err
+			}
+
+			if to < from {
+				to += 12
+			}
+
+			for m := from + 1; m <= to; m++ {
+				res = append(res, (m-1)%12+1)
+			}
+		}
+	}
+
+	if len(res) > 12 {
+		return nil, errors.New("too many months")
+	}
+
+	sorted := slices.Clone(res)
+	slices.Sort(sorted)
+
+	if len(slices.Compact(sorted)) < len(res) {
+		return nil, errors.New("duplicate months")
+	}
+
+	return res, nil
+}
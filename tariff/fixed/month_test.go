@@ -0,0 +1,36 @@
+package fixed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMonths(t *testing.T) {
+	m, err := ParseMonths(" january ")
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Month{time.January}, m)
+
+	m, err = ParseMonths("Mär-Mai")
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Month{time.March, time.April, time.May}, m)
+
+	m, err = ParseMonths("oct-mar")
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Month{time.October, time.November, time.December, time.January, time.February, time.March}, m)
+
+	m, err = ParseMonths("6, 8-9")
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Month{time.June, time.August, time.September}, m)
+
+	m, err = ParseMonths("")
+	assert.NoError(t, err)
+	assert.Nil(t, m)
+
+	_, err = ParseMonths("13")
+	assert.Error(t, err)
+
+	_, err = ParseMonths("jan,jan")
+	assert.Error(t, err)
+}
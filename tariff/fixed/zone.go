@@ -1,13 +1,32 @@
 package fixed
 
 import (
+	"time"
+
 	"golang.org/x/exp/slices"
 )
 
 type Zone struct {
-	Price float64
-	Days  []Day
-	Hours TimeRange
+	Price  float64
+	Days   []Day
+	Hours  TimeRange
+	Months []time.Month
+	Dates  []DateRange
+}
+
+// ContainsDate returns true if the zone's months and date ranges include the date
+func (z Zone) ContainsDate(d Date) bool {
+	if len(z.Months) > 0 && !slices.Contains(z.Months, d.Month) {
+		return false
+	}
+
+	if len(z.Dates) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(z.Dates, func(dr DateRange) bool {
+		return dr.Contains(d)
+	})
 }
 
 type Zones []Zone
@@ -40,6 +59,19 @@ func (r Zones) ForDay(day Day) Zones {
 	return zones
 }
 
+// ForDate returns the zones for given date in ascending order.
+// The day is passed separately to allow treating holidays like a different weekday.
+func (r Zones) ForDate(d Date, day Day) Zones {
+	var zones Zones
+	for _, z := range r.ForDay(day) {
+		if z.ContainsDate(d) {
+			zones = append(zones, z)
+		}
+	}
+
+	return zones
+}
+
 // TimeTableMarkers returns list of zone start/end markers
 func (r Zones) TimeTableMarkers() []HourMin {
 	res := []HourMin{{Hour: 0, Min: 0}}
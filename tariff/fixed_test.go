@@ -2,12 +2,15 @@ package tariff
 
 import (
 	"testing"
+	"time"
+	_ "time/tzdata"
 
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/tariff/fixed"
 	"github.com/golang-module/carbon/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFixed(t *testing.T) {
@@ -101,3 +104,66 @@ func TestFixedSplitZones(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expect, rates)
 }
+
+func TestFixedSeasonsHolidaysDST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	local := time.Local
+	time.Local = loc
+	defer func() { time.Local = local }()
+
+	at, err := NewFixedFromConfig(map[string]interface{}{
+		"price": 0.3,
+		"zones": []map[string]interface{}{
+			{"months": "oct-mar", "days": "mo-fr", "hours": "17-20", "price": 0.4},
+			{"months": "apr-sep", "days": "mo-fr", "hours": "17-20", "price": 0.35},
+		},
+		"holidays": map[string]interface{}{
+			"region": "DE",
+		},
+	})
+	require.NoError(t, err)
+
+	// good friday before DST change
+	tf := at.(*Fixed)
+	clk := clock.NewMock()
+	clk.Set(time.Date(2024, 3, 29, 12, 0, 0, 0, loc))
+	tf.clock = clk
+
+	rates, err := tf.Rates()
+	require.NoError(t, err)
+
+	price := func(month time.Month, day, hour int) float64 {
+		r, err := rates.Current(time.Date(2024, month, day, hour, 30, 0, 0, loc))
+		require.NoError(t, err)
+		return r.Price
+	}
+
+	assert.Equal(t, 0.3, price(time.March, 29, 18), "good friday")
+	assert.Equal(t, 0.3, price(time.March, 30, 18), "saturday")
+	assert.Equal(t, 0.3, price(time.April, 1, 18), "easter monday")
+	assert.Equal(t, 0.35, price(time.April, 2, 18), "summer")
+	assert.Equal(t, 0.3, price(time.April, 2, 12), "summer off-peak")
+
+	// rates are contiguous across DST change
+	for i := 1; i < len(rates); i++ {
+		assert.True(t, rates[i-1].End.After(rates[i-1].Start), "empty rate %v", rates[i-1])
+		assert.True(t, rates[i-1].End.Equal(rates[i].Start), "gap at %v", rates[i].Start)
+	}
+
+	// DST day has 23 hours
+	var dst time.Duration
+	for _, r := range rates {
+		if y, m, d := r.Start.Date(); y == 2024 && m == time.March && d == 31 {
+			dst += r.End.Sub(r.Start)
+		}
+	}
+	assert.Equal(t, 23*time.Hour, dst)
+
+	// winter peak
+	clk.Set(time.Date(2024, 3, 25, 12, 0, 0, 0, loc))
+	rates, err = tf.Rates()
+	require.NoError(t, err)
+	assert.Equal(t, 0.4, price(time.March, 25, 18), "winter")
+}
@@ -6,19 +6,17 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
-	"golang.org/x/exp/slices"
 )
 
 type GrünStromIndex struct {
 	*request.Helper
-	log     *util.Logger
-	mux     sync.Mutex
-	zip     string
-	data    api.Rates
-	updated time.Time
+	*cache
+	log *util.Logger
+	zip string
 }
 
 type gsiForecast struct {
@@ -58,7 +56,10 @@ type gsiForecast struct {
 	Message any
 }
 
-var _ api.Tariff = (*GrünStromIndex)(nil)
+var (
+	_ api.Tariff        = (*GrünStromIndex)(nil)
+	_ api.TariffUpdated = (*GrünStromIndex)(nil)
+)
 
 func init() {
 	registry.Add("grünstromindex", NewGrünStromIndexFromConfig)
@@ -76,6 +77,7 @@ func NewGrünStromIndexFromConfig(other map[string]interface{}) (api.Tariff, err
 	log := util.NewLogger("gsi").Redact(cc.Zip)
 
 	t := &GrünStromIndex{
+		cache:  newCache("grünstromindex", other, time.Hour),
 		log:    log,
 		Helper: request.NewHelper(log),
 		zip:    cc.Zip,
@@ -94,45 +96,37 @@ func (t *GrünStromIndex) run(done chan error) {
 
 	for ; true; <-time.Tick(time.Hour) {
 		var res gsiForecast
-		err := t.GetJSON(uri, &res)
-		if err == nil && res.Err {
-			if s, ok := res.Message.(string); ok {
-				err = errors.New(s)
-			} else {
-				err = api.ErrNotAvailable
+		if err := t.update(t.log, &once, done, func() error {
+			err := t.GetJSON(uri, &res)
+			if err == nil && res.Err {
+				if s, ok := res.Message.(string); ok {
+					err = backoff.Permanent(errors.New(s))
+				} else {
+					err = backoff.Permanent(api.ErrNotAvailable)
+				}
 			}
-		}
-
-		if err != nil {
-			once.Do(func() { done <- err })
-
-			t.log.ERROR.Println(err)
+			return err
+		}); err != nil {
 			continue
 		}
 
-		once.Do(func() { close(done) })
-
-		t.mux.Lock()
-		t.updated = time.Now()
-
-		t.data = make(api.Rates, 0, len(res.Forecast))
+		data := make(api.Rates, 0, len(res.Forecast))
 		for _, r := range res.Forecast {
-			t.data = append(t.data, api.Rate{
+			data = append(data, api.Rate{
 				Price: float64(r.Co2GStandard),
 				Start: time.UnixMilli(r.Timeframe.Start).Local(),
 				End:   time.UnixMilli(r.Timeframe.End).Local(),
 			})
 		}
 
-		t.mux.Unlock()
+		t.Set(data)
+		once.Do(func() { close(done) })
 	}
 }
 
 // Rates implements the api.Tariff interface
 func (t *GrünStromIndex) Rates() (api.Rates, error) {
-	t.mux.Lock()
-	defer t.mux.Unlock()
-	return slices.Clone(t.data), outdatedError(t.updated, time.Hour)
+	return t.Get()
 }
 
 // Type returns the tariff type
@@ -0,0 +1,155 @@
+package tariff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff/nordpool"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+type NordPool struct {
+	*embed
+	*cache
+	log      *util.Logger
+	client   *request.Helper
+	uri      string
+	area     string
+	currency string
+}
+
+var (
+	_ api.Tariff        = (*NordPool)(nil)
+	_ api.TariffUpdated = (*NordPool)(nil)
+)
+
+func init() {
+	registry.Add("nordpool", NewNordPoolFromConfig)
+}
+
+// NewNordPoolFromConfig creates a Nord Pool day-ahead tariff for the given delivery area
+func NewNordPoolFromConfig(other map[string]interface{}) (api.Tariff, error) {
+	cc := struct {
+		embed    `mapstructure:",squash"`
+		Area     string
+		Currency string
+	}{
+		Currency: "EUR",
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Area == "" {
+		return nil, errors.New("missing area")
+	}
+
+	log := util.NewLogger("nordpool")
+
+	t := &NordPool{
+		embed:    &cc.embed,
+		cache:    newCache("nordpool", other, time.Hour),
+		log:      log,
+		client:   request.NewHelper(log),
+		uri:      nordpool.URI,
+		area:     strings.ToUpper(cc.Area),
+		currency: strings.ToUpper(cc.Currency),
+	}
+
+	done := make(chan error)
+	go t.run(done)
+	err := <-done
+
+	return t, err
+}
+
+func (t *NordPool) run(done chan error) {
+	var once sync.Once
+
+	for ; true; <-time.Tick(time.Hour) {
+		var data api.Rates
+		if err := t.update(t.log, &once, done, func() (err error) {
+			data, err = t.rates(time.Now())
+			return err
+		}); err != nil {
+			continue
+		}
+
+		t.Set(data)
+		once.Do(func() { close(done) })
+	}
+}
+
+// rates retrieves today's and, if already published, tomorrow's prices
+func (t *NordPool) rates(now time.Time) (api.Rates, error) {
+	var res api.Rates
+
+	for day := 0; day < 2; day++ {
+		date := now.AddDate(0, 0, day).Format(nordpool.DateFormat)
+
+		uri := fmt.Sprintf("%s?market=DayAhead&date=%s&deliveryArea=%s&currency=%s", t.uri,
+			date, url.QueryEscape(t.area), url.QueryEscape(t.currency))
+
+		req, err := request.New(http.MethodGet, uri, nil, request.AcceptJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := t.client.DoBody(req)
+		if err != nil {
+			return nil, err
+		}
+
+		// prices not yet published
+		if len(b) == 0 {
+			continue
+		}
+
+		var prices nordpool.DayAheadPrices
+		if err := json.Unmarshal(b, &prices); err != nil {
+			return nil, err
+		}
+
+		if !strings.EqualFold(prices.Currency, t.currency) {
+			return nil, fmt.Errorf("unexpected currency: %s", prices.Currency)
+		}
+
+		for _, e := range prices.MultiAreaEntries {
+			price, ok := e.EntryPerArea[t.area]
+			if !ok {
+				return nil, fmt.Errorf("missing area: %s", t.area)
+			}
+
+			res = append(res, api.Rate{
+				Start: e.DeliveryStart.Local(),
+				End:   e.DeliveryEnd.Local(),
+				Price: t.totalPrice(price / 1e3),
+			})
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, errors.New("no prices")
+	}
+
+	return res, nil
+}
+
+// Rates implements the api.Tariff interface
+func (t *NordPool) Rates() (api.Rates, error) {
+	return t.Get()
+}
+
+// Type returns the tariff type
+func (t *NordPool) Type() api.TariffType {
+	return api.TariffTypePriceDynamic
+}
@@ -0,0 +1,21 @@
+package nordpool
+
+import "time"
+
+// URI is the Nord Pool data portal day-ahead prices api
+const URI = "https://dataportal-api.nordpoolgroup.com/api/DayAheadPrices"
+
+// DateFormat is the delivery date format of the api
+const DateFormat = "2006-01-02"
+
+type DayAheadPrices struct {
+	DeliveryDateCET  string
+	Currency         string
+	MultiAreaEntries []Entry
+}
+
+type Entry struct {
+	DeliveryStart time.Time
+	DeliveryEnd   time.Time
+	EntryPerArea  map[string]float64
+}
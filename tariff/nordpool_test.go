@@ -0,0 +1,84 @@
+package tariff
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nordpoolSample = `{
+	"deliveryDateCET": "2023-11-14",
+	"version": 3,
+	"updatedAt": "2023-11-13T12:44:21.0961539Z",
+	"deliveryAreas": ["NO1"],
+	"market": "DayAhead",
+	"multiAreaEntries": [
+		{
+			"deliveryStart": "2023-11-13T23:00:00Z",
+			"deliveryEnd": "2023-11-14T00:00:00Z",
+			"entryPerArea": { "NO1": 1012.3 }
+		},
+		{
+			"deliveryStart": "2023-11-14T00:00:00Z",
+			"deliveryEnd": "2023-11-14T01:00:00Z",
+			"entryPerArea": { "NO1": 987.65 }
+		}
+	],
+	"blockPriceAggregates": [],
+	"currency": "NOK",
+	"exchangeRate": 11.8,
+	"areaStates": [{ "state": "Final", "areas": ["NO1"] }]
+}`
+
+func TestNordPool(t *testing.T) {
+	var dates []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		date := r.URL.Query().Get("date")
+		dates = append(dates, date)
+
+		// tomorrow not yet published
+		if date != "2023-11-14" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		assert.Equal(t, "NO1", r.URL.Query().Get("deliveryArea"))
+
+		_, _ = w.Write([]byte(nordpoolSample))
+	}))
+	defer srv.Close()
+
+	log := util.NewLogger("foo")
+
+	tf := &NordPool{
+		embed:    &embed{Tax: 0.25},
+		log:      log,
+		client:   request.NewHelper(log),
+		uri:      srv.URL,
+		area:     "NO1",
+		currency: "NOK",
+	}
+
+	rates, err := tf.rates(time.Date(2023, 11, 14, 12, 0, 0, 0, time.Local))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"2023-11-14", "2023-11-15"}, dates)
+
+	require.Len(t, rates, 2)
+	assert.True(t, time.Date(2023, 11, 13, 23, 0, 0, 0, time.UTC).Equal(rates[0].Start))
+	assert.True(t, time.Date(2023, 11, 14, 0, 0, 0, 0, time.UTC).Equal(rates[0].End))
+	assert.InDelta(t, 1.0123*1.25, rates[0].Price, 1e-6)
+	assert.InDelta(t, 0.98765*1.25, rates[1].Price, 1e-6)
+
+	// currency mismatch
+	tf.currency = "SEK"
+	_, err = tf.rates(time.Date(2023, 11, 14, 12, 0, 0, 0, time.Local))
+	assert.Error(t, err)
+}
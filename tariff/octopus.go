@@ -9,19 +9,19 @@ import (
 	"github.com/evcc-io/evcc/tariff/octopus"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
-	"golang.org/x/exp/slices"
 )
 
 type Octopus struct {
-	mux     sync.Mutex
-	log     *util.Logger
-	uri     string
-	region  string
-	data    api.Rates
-	updated time.Time
+	*cache
+	log    *util.Logger
+	uri    string
+	region string
 }
 
-var _ api.Tariff = (*Octopus)(nil)
+var (
+	_ api.Tariff        = (*Octopus)(nil)
+	_ api.TariffUpdated = (*Octopus)(nil)
+)
 
 func init() {
 	registry.Add("octopusenergy", NewOctopusFromConfig)
@@ -45,6 +45,7 @@ func NewOctopusFromConfig(other map[string]interface{}) (api.Tariff, error) {
 	}
 
 	t := &Octopus{
+		cache:  newCache("octopusenergy", other, time.Hour),
 		log:    util.NewLogger("octopus"),
 		uri:    octopus.ConstructRatesAPI(cc.Tariff, cc.Region),
 		region: cc.Tariff,
@@ -63,19 +64,13 @@ func (t *Octopus) run(done chan error) {
 
 	for ; true; <-time.Tick(time.Hour) {
 		var res octopus.UnitRates
-		if err := client.GetJSON(t.uri, &res); err != nil {
-			once.Do(func() { done <- err })
-
-			t.log.ERROR.Println(err)
+		if err := t.update(t.log, &once, done, func() error {
+			return client.GetJSON(t.uri, &res)
+		}); err != nil {
 			continue
 		}
 
-		once.Do(func() { close(done) })
-
-		t.mux.Lock()
-		t.updated = time.Now()
-
-		t.data = make(api.Rates, 0, len(res.Results))
+		data := make(api.Rates, 0, len(res.Results))
 		for _, r := range res.Results {
 			ar := api.Rate{
 				Start: r.ValidityStart,
@@ -83,10 +78,11 @@ func (t *Octopus) run(done chan error) {
 				// UnitRates are supplied inclusive of tax, though this could be flipped easily with a config flag.
 				Price: r.PriceInclusiveTax / 1e2,
 			}
-			t.data = append(t.data, ar)
+			data = append(data, ar)
 		}
 
-		t.mux.Unlock()
+		t.Set(data)
+		once.Do(func() { close(done) })
 	}
 }
 
@@ -98,9 +94,7 @@ func (t *Octopus) Unit() string {
 
 // Rates implements the api.Tariff interface
 func (t *Octopus) Rates() (api.Rates, error) {
-	t.mux.Lock()
-	defer t.mux.Unlock()
-	return slices.Clone(t.data), outdatedError(t.updated, time.Hour)
+	return t.Get()
 }
 
 // Type returns the tariff type
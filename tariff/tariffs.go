@@ -1,6 +1,7 @@
 package tariff
 
 import (
+	"errors"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -22,9 +23,14 @@ func NewTariffs(currency currency.Unit, grid, feedin, co2 api.Tariff, planner ap
 	}
 }
 
+// Outdated returns true if the rates are outdated but remain usable until they expire
+func Outdated(rates api.Rates, err error) bool {
+	return errors.Is(err, api.ErrOutdated) && len(rates) > 0
+}
+
 func currentPrice(t api.Tariff) (float64, error) {
 	if t != nil {
-		if rr, err := t.Rates(); err == nil {
+		if rr, err := t.Rates(); err == nil || Outdated(rr, err) {
 			if r, err := rr.Current(time.Now()); err == nil {
 				return r.Price, nil
 			}
@@ -50,11 +56,3 @@ func (t *Tariffs) CurrentCo2() (float64, error) {
 	}
 	return 0, api.ErrNotAvailable
 }
-
-// outdatedError returns api.ErrOutdated if t is older than 2*d
-func outdatedError(t time.Time, d time.Duration) error {
-	if time.Since(t) > 2*d {
-		return api.ErrOutdated
-	}
-	return nil
-}
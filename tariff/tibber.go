@@ -11,20 +11,20 @@ import (
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/shurcooL/graphql"
-	"golang.org/x/exp/slices"
 )
 
 type Tibber struct {
-	mux     sync.Mutex
-	log     *util.Logger
-	homeID  string
-	unit    string
-	client  *tibber.Client
-	data    api.Rates
-	updated time.Time
+	*cache
+	log    *util.Logger
+	homeID string
+	unit   string
+	client *tibber.Client
 }
 
-var _ api.Tariff = (*Tibber)(nil)
+var (
+	_ api.Tariff        = (*Tibber)(nil)
+	_ api.TariffUpdated = (*Tibber)(nil)
+)
 
 func init() {
 	registry.Add("tibber", NewTibberFromConfig)
@@ -48,6 +48,7 @@ func NewTibberFromConfig(other map[string]interface{}) (api.Tariff, error) {
 	log := util.NewLogger("tibber").Redact(cc.Token, cc.HomeID)
 
 	t := &Tibber{
+		cache:  newCache("tibber", other, time.Hour),
 		log:    log,
 		homeID: cc.HomeID,
 		unit:   cc.Unit,
@@ -93,27 +94,17 @@ func (t *Tibber) run(done chan error) {
 	}
 
 	for ; true; <-time.Tick(time.Hour) {
-		ctx, cancel := context.WithTimeout(context.Background(), request.Timeout)
-		err := t.client.Query(ctx, &res, v)
-		cancel()
-
-		if err != nil {
-			once.Do(func() { done <- err })
-
-			t.log.ERROR.Println(err)
+		if err := t.update(t.log, &once, done, func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), request.Timeout)
+			defer cancel()
+			return t.client.Query(ctx, &res, v)
+		}); err != nil {
 			continue
 		}
 
-		once.Do(func() { close(done) })
-
-		t.mux.Lock()
-		t.updated = time.Now()
-
 		pi := res.Viewer.Home.CurrentSubscription.PriceInfo
-		t.data = make(api.Rates, 0, len(pi.Today)+len(pi.Tomorrow))
-		t.data = append(t.rates(pi.Today), t.rates(pi.Tomorrow)...)
-
-		t.mux.Unlock()
+		t.Set(append(t.rates(pi.Today), t.rates(pi.Tomorrow)...))
+		once.Do(func() { close(done) })
 	}
 }
 
@@ -132,9 +123,7 @@ func (t *Tibber) rates(pi []tibber.Price) api.Rates {
 
 // Rates implements the api.Tariff interface
 func (t *Tibber) Rates() (api.Rates, error) {
-	t.mux.Lock()
-	defer t.mux.Unlock()
-	return slices.Clone(t.data), outdatedError(t.updated, time.Hour)
+	return t.Get()
 }
 
 // Type returns the tariff type
@@ -134,7 +134,7 @@ type uiWriter struct {
 
 func (w *uiWriter) Write(p []byte) (n int, err error) {
 	// trim level and timestamp
-	s := string(w.re.ReplaceAll(p, []byte{}))
+	s := RedactSecrets(string(w.re.ReplaceAll(p, []byte{})))
 
 	uiChan <- Param{
 		Key: w.level,
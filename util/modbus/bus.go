@@ -0,0 +1,224 @@
+package modbus
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/volkszaehler/mbmd/meters"
+)
+
+// BusStats are the request statistics of a physical modbus connection
+type BusStats struct {
+	Requests   int64         `json:"requests"`
+	Errors     int64         `json:"errors"`
+	AvgWait    time.Duration `json:"avgWait"`    // average time waiting for the bus
+	AvgLatency time.Duration `json:"avgLatency"` // average request duration
+	MaxLatency time.Duration `json:"maxLatency"`
+	LastError  string        `json:"lastError,omitempty"`
+}
+
+// busRequest is a request waiting for the bus
+type busRequest struct {
+	priority int
+	seq      uint64
+	ready    chan struct{}
+}
+
+// busQueue orders waiting requests by priority and arrival
+type busQueue []*busRequest
+
+func (q busQueue) Len() int { return len(q) }
+
+func (q busQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q busQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *busQueue) Push(x any) { *q = append(*q, x.(*busRequest)) }
+
+func (q *busQueue) Pop() any {
+	old := *q
+	n := len(old)
+	r := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return r
+}
+
+// bus serialises access to a physical connection shared by all slave connections using it.
+// Waiting requests are granted by descending priority, then in order of arrival.
+type bus struct {
+	mu      sync.Mutex
+	clock   clock.Clock
+	conn    meters.Connection
+	delay   time.Duration // inter-frame delay
+	busy    bool
+	last    time.Time // end of last frame
+	seq     uint64
+	waiting busQueue
+
+	requests, errors int64
+	wait, latency    time.Duration
+	maxLatency       time.Duration
+	lastError        string
+}
+
+func newBus(conn meters.Connection) *bus {
+	return &bus{
+		clock: clock.New(),
+		conn:  conn,
+	}
+}
+
+// FrameDelay sets the minimum delay between subsequent frames on the bus. The largest delay requested by any connection wins.
+func (b *bus) FrameDelay(delay time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if delay > b.delay {
+		b.delay = delay
+	}
+}
+
+// acquire blocks until the bus is granted and returns the end of the previous frame
+func (b *bus) acquire(priority int) time.Time {
+	b.mu.Lock()
+
+	if !b.busy && len(b.waiting) == 0 {
+		b.busy = true
+		defer b.mu.Unlock()
+		return b.last
+	}
+
+	b.seq++
+	r := &busRequest{
+		priority: priority,
+		seq:      b.seq,
+		ready:    make(chan struct{}),
+	}
+	heap.Push(&b.waiting, r)
+	b.mu.Unlock()
+
+	<-r.ready
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.last
+}
+
+// release hands the bus to the next waiting request
+func (b *bus) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.last = b.clock.Now()
+
+	if len(b.waiting) == 0 {
+		b.busy = false
+		return
+	}
+
+	r := heap.Pop(&b.waiting).(*busRequest)
+	close(r.ready)
+}
+
+// exec executes the request with exclusive bus access
+func (b *bus) exec(priority int, fun func() ([]byte, error)) ([]byte, error) {
+	queued := b.clock.Now()
+	last := b.acquire(priority)
+
+	b.mu.Lock()
+	delay := b.delay
+	b.mu.Unlock()
+
+	if wait := delay - b.clock.Since(last); !last.IsZero() && wait > 0 {
+		b.clock.Sleep(wait)
+	}
+
+	started := b.clock.Now()
+	res, err := fun()
+	finished := b.clock.Now()
+
+	b.update(started.Sub(queued), finished.Sub(started), err)
+	b.release()
+
+	return res, err
+}
+
+// update records request statistics
+func (b *bus) update(wait, latency time.Duration, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.requests++
+	b.wait += wait
+	b.latency += latency
+
+	if latency > b.maxLatency {
+		b.maxLatency = latency
+	}
+
+	if err != nil {
+		b.errors++
+		b.lastError = err.Error()
+	}
+}
+
+// Stats returns the bus request statistics
+func (b *bus) Stats() BusStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	res := BusStats{
+		Requests:   b.requests,
+		Errors:     b.errors,
+		MaxLatency: b.maxLatency,
+		LastError:  b.lastError,
+	}
+
+	if b.requests > 0 {
+		res.AvgWait = b.wait / time.Duration(b.requests)
+		res.AvgLatency = b.latency / time.Duration(b.requests)
+	}
+
+	return res
+}
+
+var (
+	buses = make(map[string]*bus)
+	mu    sync.Mutex
+)
+
+// registeredBus returns the bus for the physical connection key, creating it if required
+func registeredBus(key string, newConn func() meters.Connection) *bus {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if b, ok := buses[key]; ok {
+		return b
+	}
+
+	b := newBus(newConn())
+	buses[key] = b
+
+	return b
+}
+
+// Stats returns the request statistics of all physical connections by uri or device
+func Stats() map[string]BusStats {
+	mu.Lock()
+	defer mu.Unlock()
+
+	res := make(map[string]BusStats, len(buses))
+	for key, b := range buses {
+		res[key] = b.Stats()
+	}
+
+	return res
+}
@@ -0,0 +1,103 @@
+package modbus
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusPriority(t *testing.T) {
+	b := newBus(nil)
+
+	started := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		_, _ = b.exec(0, func() ([]byte, error) {
+			close(started)
+			<-done
+			return nil, nil
+		})
+	}()
+	<-started
+
+	var (
+		mu    sync.Mutex
+		order []int
+		wg    sync.WaitGroup
+	)
+
+	for i, prio := range []int{0, 1, -1, 1} {
+		wg.Add(1)
+		go func(id, prio int) {
+			defer wg.Done()
+			_, _ = b.exec(prio, func() ([]byte, error) {
+				mu.Lock()
+				order = append(order, id)
+				mu.Unlock()
+				return nil, nil
+			})
+		}(i, prio)
+
+		// wait for request to be queued
+		require.Eventually(t, func() bool {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			return len(b.waiting) == i+1
+		}, time.Second, time.Millisecond)
+	}
+
+	close(done)
+	wg.Wait()
+
+	// higher priority first, then in order of arrival
+	assert.Equal(t, []int{1, 3, 0, 2}, order)
+}
+
+func TestBusFrameDelay(t *testing.T) {
+	b := newBus(nil)
+	b.FrameDelay(20 * time.Millisecond)
+	b.FrameDelay(10 * time.Millisecond)
+
+	var ts []time.Time
+	for i := 0; i < 2; i++ {
+		_, _ = b.exec(0, func() ([]byte, error) {
+			ts = append(ts, time.Now())
+			return nil, nil
+		})
+	}
+
+	assert.GreaterOrEqual(t, ts[1].Sub(ts[0]), 20*time.Millisecond)
+}
+
+func TestBusStats(t *testing.T) {
+	b := newBus(nil)
+
+	_, _ = b.exec(0, func() ([]byte, error) {
+		return nil, nil
+	})
+	_, err := b.exec(0, func() ([]byte, error) {
+		return nil, errors.New("timeout")
+	})
+	assert.Error(t, err)
+
+	s := b.Stats()
+	assert.Equal(t, int64(2), s.Requests)
+	assert.Equal(t, int64(1), s.Errors)
+	assert.Equal(t, "timeout", s.LastError)
+}
+
+func TestSharedBus(t *testing.T) {
+	c1, err := NewConnection("localhost:1502", "", "", 0, Tcp, 1)
+	require.NoError(t, err)
+
+	c2, err := NewConnection("localhost:1502", "", "", 0, Tcp, 2)
+	require.NoError(t, err)
+
+	assert.Same(t, c1.bus, c2.bus)
+	assert.Contains(t, Stats(), "localhost:1502")
+}
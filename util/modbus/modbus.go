@@ -6,7 +6,6 @@ import (
 	"math"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/evcc-io/evcc/util"
@@ -43,7 +42,9 @@ type Settings struct {
 	SubDevice           int
 	URI, Device, Comset string
 	Baudrate            int
-	RTU                 *bool // indicates RTU over TCP if true
+	RTU                 *bool         // indicates RTU over TCP if true
+	Priority            int           // priority for accessing the physical connection, higher values first
+	FrameDelay          time.Duration // minimum delay between frames on the physical connection
 }
 
 func (s *Settings) String() string {
@@ -55,17 +56,22 @@ func (s *Settings) String() string {
 
 // Connection decorates a meters.Connection with transparent slave id and error handling
 type Connection struct {
-	slaveID uint8
-	mu      sync.Mutex
-	conn    meters.Connection
-	delay   time.Duration
-}
-
-func (mb *Connection) prepare(slaveID uint8) {
-	mb.conn.Slave(slaveID)
-	if mb.delay > 0 {
-		time.Sleep(mb.delay)
-	}
+	slaveID  uint8
+	bus      *bus
+	conn     meters.Connection
+	delay    time.Duration
+	priority int
+}
+
+// exec executes the operation with exclusive access to the physical connection
+func (mb *Connection) exec(slaveID uint8, fun func(client modbus.Client) ([]byte, error)) ([]byte, error) {
+	return mb.bus.exec(mb.priority, func() ([]byte, error) {
+		mb.conn.Slave(slaveID)
+		if mb.delay > 0 {
+			time.Sleep(mb.delay)
+		}
+		return mb.handle(fun(mb.conn.ModbusClient()))
+	})
 }
 
 func (mb *Connection) handle(res []byte, err error) ([]byte, error) {
@@ -80,6 +86,16 @@ func (mb *Connection) Delay(delay time.Duration) {
 	mb.delay = delay
 }
 
+// FrameDelay sets the minimum delay between subsequent frames on the physical connection shared by all devices
+func (mb *Connection) FrameDelay(delay time.Duration) {
+	mb.bus.FrameDelay(delay)
+}
+
+// Priority sets the priority for accessing the physical connection. Higher priority operations are executed first.
+func (mb *Connection) Priority(priority int) {
+	mb.priority = priority
+}
+
 // ConnectDelay sets the initial delay after connecting before starting communication
 func (mb *Connection) ConnectDelay(delay time.Duration) {
 	mb.conn.ConnectDelay(delay)
@@ -97,90 +113,79 @@ func (mb *Connection) Timeout(timeout time.Duration) {
 
 // ReadCoils wraps the underlying implementation
 func (mb *Connection) ReadCoilsWithSlave(slaveID uint8, address, quantity uint16) ([]byte, error) {
-	mb.mu.Lock()
-	defer mb.mu.Unlock()
-	mb.prepare(slaveID)
-	return mb.handle(mb.conn.ModbusClient().ReadCoils(address, quantity))
+	return mb.exec(slaveID, func(client modbus.Client) ([]byte, error) {
+		return client.ReadCoils(address, quantity)
+	})
 }
 
 // WriteSingleCoil wraps the underlying implementation
 func (mb *Connection) WriteSingleCoilWithSlave(slaveID uint8, address, value uint16) ([]byte, error) {
-	mb.mu.Lock()
-	defer mb.mu.Unlock()
-	mb.prepare(slaveID)
-	return mb.handle(mb.conn.ModbusClient().WriteSingleCoil(address, value))
+	return mb.exec(slaveID, func(client modbus.Client) ([]byte, error) {
+		return client.WriteSingleCoil(address, value)
+	})
 }
 
 // ReadInputRegisters wraps the underlying implementation
 func (mb *Connection) ReadInputRegistersWithSlave(slaveID uint8, address, quantity uint16) ([]byte, error) {
-	mb.mu.Lock()
-	defer mb.mu.Unlock()
-	mb.prepare(slaveID)
-	return mb.handle(mb.conn.ModbusClient().ReadInputRegisters(address, quantity))
+	return mb.exec(slaveID, func(client modbus.Client) ([]byte, error) {
+		return client.ReadInputRegisters(address, quantity)
+	})
 }
 
 // ReadHoldingRegisters wraps the underlying implementation
 func (mb *Connection) ReadHoldingRegistersWithSlave(slaveID uint8, address, quantity uint16) ([]byte, error) {
-	mb.mu.Lock()
-	defer mb.mu.Unlock()
-	mb.prepare(slaveID)
-	return mb.handle(mb.conn.ModbusClient().ReadHoldingRegisters(address, quantity))
+	return mb.exec(slaveID, func(client modbus.Client) ([]byte, error) {
+		return client.ReadHoldingRegisters(address, quantity)
+	})
 }
 
 // WriteSingleRegister wraps the underlying implementation
 func (mb *Connection) WriteSingleRegisterWithSlave(slaveID uint8, address, value uint16) ([]byte, error) {
-	mb.mu.Lock()
-	defer mb.mu.Unlock()
-	mb.prepare(slaveID)
-	return mb.handle(mb.conn.ModbusClient().WriteSingleRegister(address, value))
+	return mb.exec(slaveID, func(client modbus.Client) ([]byte, error) {
+		return client.WriteSingleRegister(address, value)
+	})
 }
 
 // WriteMultipleRegisters wraps the underlying implementation
 func (mb *Connection) WriteMultipleRegistersWithSlave(slaveID uint8, address, quantity uint16, value []byte) ([]byte, error) {
-	mb.mu.Lock()
-	defer mb.mu.Unlock()
-	mb.prepare(slaveID)
-	return mb.handle(mb.conn.ModbusClient().WriteMultipleRegisters(address, quantity, value))
+	return mb.exec(slaveID, func(client modbus.Client) ([]byte, error) {
+		return client.WriteMultipleRegisters(address, quantity, value)
+	})
 }
 
 // ReadDiscreteInputs wraps the underlying implementation
 func (mb *Connection) ReadDiscreteInputsWithSlave(slaveID uint8, address, quantity uint16) (results []byte, err error) {
-	mb.mu.Lock()
-	defer mb.mu.Unlock()
-	mb.prepare(slaveID)
-	return mb.handle(mb.conn.ModbusClient().ReadDiscreteInputs(address, quantity))
+	return mb.exec(slaveID, func(client modbus.Client) ([]byte, error) {
+		return client.ReadDiscreteInputs(address, quantity)
+	})
 }
 
 // WriteMultipleCoils wraps the underlying implementation
 func (mb *Connection) WriteMultipleCoilsWithSlave(slaveID uint8, address, quantity uint16, value []byte) (results []byte, err error) {
-	mb.mu.Lock()
-	defer mb.mu.Unlock()
-	mb.prepare(slaveID)
-	return mb.handle(mb.conn.ModbusClient().WriteMultipleCoils(address, quantity, value))
+	return mb.exec(slaveID, func(client modbus.Client) ([]byte, error) {
+		return client.WriteMultipleCoils(address, quantity, value)
+	})
 }
 
 // ReadWriteMultipleRegisters wraps the underlying implementation
 func (mb *Connection) ReadWriteMultipleRegistersWithSlave(slaveID uint8, readAddress, readQuantity, writeAddress, writeQuantity uint16, value []byte) (results []byte, err error) {
-	mb.mu.Lock()
-	defer mb.mu.Unlock()
-	mb.prepare(slaveID)
-	return mb.handle(mb.conn.ModbusClient().ReadWriteMultipleRegisters(readAddress, readQuantity, writeAddress, writeQuantity, value))
+	return mb.exec(slaveID, func(client modbus.Client) ([]byte, error) {
+		return client.ReadWriteMultipleRegisters(readAddress, readQuantity, writeAddress, writeQuantity, value)
+	})
 }
 
 // MaskWriteRegister wraps the underlying implementation
 func (mb *Connection) MaskWriteRegisterWithSlave(slaveID uint8, address, andMask, orMask uint16) (results []byte, err error) {
-	mb.mu.Lock()
-	defer mb.mu.Unlock()
-	mb.prepare(slaveID)
-	return mb.handle(mb.conn.ModbusClient().MaskWriteRegister(address, andMask, orMask))
+	return mb.exec(slaveID, func(client modbus.Client) ([]byte, error) {
+		return client.MaskWriteRegister(address, andMask, orMask)
+	})
 }
 
 // ReadFIFOQueue wraps the underlying implementation
 func (mb *Connection) ReadFIFOQueueWithSlave(slaveID uint8, address uint16) (results []byte, err error) {
-	mb.mu.Lock()
-	defer mb.mu.Unlock()
-	mb.prepare(slaveID)
-	return mb.handle(mb.conn.ModbusClient().ReadFIFOQueue(address))
+	return mb.exec(slaveID, func(client modbus.Client) ([]byte, error) {
+		return client.ReadFIFOQueue(address)
+	})
 }
 
 func (mb *Connection) ReadCoils(address, quantity uint16) ([]byte, error) {
@@ -227,24 +232,6 @@ func (mb *Connection) ReadFIFOQueue(address uint16) (results []byte, err error)
 	return mb.ReadFIFOQueueWithSlave(mb.slaveID, address)
 }
 
-var (
-	connections = make(map[string]meters.Connection)
-	mu          sync.Mutex
-)
-
-func registeredConnection(key string, newConn meters.Connection) meters.Connection {
-	mu.Lock()
-	defer mu.Unlock()
-
-	if conn, ok := connections[key]; ok {
-		return conn
-	}
-
-	connections[key] = newConn
-
-	return newConn
-}
-
 // ProtocolFromRTU identifies the wire format from the RTU setting
 func ProtocolFromRTU(rtu *bool) Protocol {
 	if rtu != nil && *rtu {
@@ -255,7 +242,10 @@ func ProtocolFromRTU(rtu *bool) Protocol {
 
 // NewConnection creates physical modbus device from config
 func NewConnection(uri, device, comset string, baudrate int, proto Protocol, slaveID uint8) (*Connection, error) {
-	var conn meters.Connection
+	var (
+		b   *bus
+		key string
+	)
 
 	if device != "" && uri != "" {
 		return nil, errors.New("invalid modbus configuration: can only have either uri or device")
@@ -274,33 +264,44 @@ func NewConnection(uri, device, comset string, baudrate int, proto Protocol, sla
 			return nil, errors.New("invalid modbus configuration: need baudrate and comset")
 		}
 
+		key = device
 		if proto == Ascii {
-			conn = registeredConnection(device, meters.NewASCII(device, baudrate, comset))
+			b = registeredBus(device, func() meters.Connection { return meters.NewASCII(device, baudrate, comset) })
 		} else {
-			conn = registeredConnection(device, meters.NewRTU(device, baudrate, comset))
+			b = registeredBus(device, func() meters.Connection { return meters.NewRTU(device, baudrate, comset) })
 		}
 	}
 
 	if uri != "" {
 		uri = util.DefaultPort(uri, 502)
+		key = uri
 
 		switch proto {
 		case Rtu:
-			conn = registeredConnection(uri, meters.NewRTUOverTCP(uri))
+			b = registeredBus(uri, func() meters.Connection { return meters.NewRTUOverTCP(uri) })
 		case Ascii:
-			conn = registeredConnection(uri, meters.NewASCIIOverTCP(uri))
+			b = registeredBus(uri, func() meters.Connection { return meters.NewASCIIOverTCP(uri) })
 		default:
-			conn = registeredConnection(uri, meters.NewTCP(uri))
+			b = registeredBus(uri, func() meters.Connection { return meters.NewTCP(uri) })
 		}
 	}
 
-	if conn == nil {
+	if b == nil {
 		return nil, errors.New("invalid modbus configuration: need either uri or device")
 	}
 
 	slaveConn := &Connection{
 		slaveID: slaveID,
-		conn:    conn,
+		bus:     b,
+		conn:    b.conn,
+	}
+
+	// apply settings registered for devices that don't configure the connection themselves
+	if s, ok := registeredSettings(key, slaveID); ok {
+		slaveConn.Priority(s.Priority)
+		if s.FrameDelay > 0 {
+			slaveConn.FrameDelay(s.FrameDelay)
+		}
 	}
 
 	return slaveConn, nil
@@ -0,0 +1,84 @@
+package modbus
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/evcc-io/evcc/util"
+)
+
+var (
+	settings   = make(map[string]Settings)
+	settingsMu sync.Mutex
+)
+
+// settingsKey returns the key of the slave id on the physical connection
+func settingsKey(key string, id uint8) string {
+	return fmt.Sprintf("%s@%d", key, id)
+}
+
+// RegisterSettings registers priority and frame delay for connections created afterwards with the same
+// uri or device and slave id. A zero slave id applies to all slave ids of the physical connection.
+func RegisterSettings(s Settings) {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+
+	key := s.Device
+	if s.URI != "" {
+		key = util.DefaultPort(s.URI, 502)
+	}
+
+	settings[settingsKey(key, s.ID)] = s
+}
+
+// registeredSettings returns the settings registered for the physical connection key and slave id
+func registeredSettings(key string, id uint8) (Settings, bool) {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+
+	if s, ok := settings[settingsKey(key, id)]; ok {
+		return s, true
+	}
+
+	s, ok := settings[settingsKey(key, 0)]
+	return s, ok
+}
+
+// ExtractSettings registers priority and frame delay of a device configuration with uri or device
+// and returns the configuration without them. This applies the settings to devices like chargers
+// which create their connection without decoding them.
+func ExtractSettings(other map[string]interface{}) (map[string]interface{}, error) {
+	res := make(map[string]interface{}, len(other))
+	conf := make(map[string]interface{})
+
+	var found, connection bool
+	for k, v := range other {
+		switch strings.ToLower(k) {
+		case "priority", "framedelay":
+			found = true
+			conf[k] = v
+			continue
+		case "uri", "device":
+			connection = true
+			conf[k] = v
+		case "id":
+			conf[k] = v
+		}
+		res[k] = v
+	}
+
+	// not a modbus configuration or nothing to extract
+	if !found || !connection {
+		return other, nil
+	}
+
+	var cc Settings
+	if err := util.DecodeOther(conf, &cc); err != nil {
+		return nil, err
+	}
+
+	RegisterSettings(cc)
+
+	return res, nil
+}
@@ -0,0 +1,39 @@
+package modbus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractSettings(t *testing.T) {
+	other := map[string]interface{}{
+		"uri":        "192.0.2.3",
+		"id":         2,
+		"priority":   5,
+		"framedelay": "10ms",
+		"timeout":    "5s",
+	}
+
+	res, err := ExtractSettings(other)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"uri": "192.0.2.3", "id": 2, "timeout": "5s"}, res)
+
+	conn, err := NewConnection("192.0.2.3:502", "", "", 0, Tcp, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 5, conn.priority)
+	assert.Equal(t, 10*time.Millisecond, conn.bus.delay)
+
+	conn, err = NewConnection("192.0.2.3:502", "", "", 0, Tcp, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 0, conn.priority, "other slave id")
+
+	// not a modbus configuration
+	other = map[string]interface{}{"priority": true}
+
+	res, err = ExtractSettings(other)
+	require.NoError(t, err)
+	assert.Equal(t, other, res)
+}
@@ -40,7 +40,7 @@ func (l *Redactor) Write(p []byte) (n int, err error) {
 		p = bytes.ReplaceAll(p, []byte(s), []byte(RedactReplacement))
 	}
 	l.mu.Unlock()
-	return os.Stdout.Write(p)
+	return os.Stdout.Write([]byte(RedactSecrets(string(p))))
 }
 
 // RedactDefaultHook expands a redaction item to include URL encoding
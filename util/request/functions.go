@@ -1,9 +1,12 @@
 package request
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/cenkalti/backoff/v4"
 )
 
 var (
@@ -60,6 +63,22 @@ func (e StatusError) HasStatus(codes ...int) bool {
 	return false
 }
 
+// BackoffPermanentError marks client errors other than too many requests as permanent to stop backoff retries
+func BackoffPermanentError(err error) error {
+	var pe *backoff.PermanentError
+	if errors.As(err, &pe) {
+		return err
+	}
+
+	var se StatusError
+	if errors.As(err, &se) {
+		if code := se.StatusCode(); code >= 400 && code < 500 && code != http.StatusTooManyRequests {
+			return backoff.Permanent(err)
+		}
+	}
+	return err
+}
+
 // ResponseError turns an HTTP status code into an error
 func ResponseError(resp *http.Response) error {
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
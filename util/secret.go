@@ -0,0 +1,151 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"golang.org/x/exp/slices"
+)
+
+// secretRef matches ${env:NAME} and ${file:/path} references
+var secretRef = regexp.MustCompile(`\$\{(env|file):([^}]+)\}`)
+
+var (
+	secretsMu sync.RWMutex
+	secrets   []string
+)
+
+// secretKeys are the configuration keys whose resolved values are registered for redaction
+var secretKeys = []string{"password", "token", "secret", "key", "pin"}
+
+// isSecretKey checks if the configuration key holds a secret
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	return slices.ContainsFunc(secretKeys, func(s string) bool {
+		return strings.Contains(key, s)
+	})
+}
+
+// ResolveSecrets replaces ${env:NAME} and ${file:/path} references in all strings of
+// a configuration tree. Values resolved for secret keys like password or token are registered for redaction.
+func ResolveSecrets(v any) (any, error) {
+	return resolveSecrets("", v)
+}
+
+func resolveSecrets(key string, v any) (any, error) {
+	switch typed := v.(type) {
+	case string:
+		return resolveSecretString(typed, isSecretKey(key))
+
+	case map[string]any:
+		res := make(map[string]any, len(typed))
+		for k, v := range typed {
+			val, err := resolveSecrets(k, v)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			res[k] = val
+		}
+		return res, nil
+
+	case map[any]any:
+		res := make(map[any]any, len(typed))
+		for k, v := range typed {
+			val, err := resolveSecrets(fmt.Sprint(k), v)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", k, err)
+			}
+			res[k] = val
+		}
+		return res, nil
+
+	case []any:
+		res := make([]any, 0, len(typed))
+		for i, v := range typed {
+			val, err := resolveSecrets(key, v)
+			if err != nil {
+				return nil, fmt.Errorf("%d: %w", i, err)
+			}
+			res = append(res, val)
+		}
+		return res, nil
+
+	default:
+		return v, nil
+	}
+}
+
+func resolveSecretString(s string, secret bool) (string, error) {
+	var err error
+
+	res := secretRef.ReplaceAllStringFunc(s, func(ref string) string {
+		match := secretRef.FindStringSubmatch(ref)
+
+		val, resErr := resolveSecret(match[1], strings.TrimSpace(match[2]))
+		if resErr != nil {
+			if err == nil {
+				err = resErr
+			}
+			return ref
+		}
+
+		if secret {
+			addSecret(val)
+		}
+
+		return val
+	})
+
+	return res, err
+}
+
+func resolveSecret(kind, ref string) (string, error) {
+	switch kind {
+	case "env":
+		val, ok := os.LookupEnv(ref)
+		if !ok {
+			return "", fmt.Errorf("undefined environment variable: %s", ref)
+		}
+		return val, nil
+
+	case "file":
+		b, err := os.ReadFile(ref)
+		if err != nil {
+			return "", fmt.Errorf("cannot read secret file: %w", err)
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+
+	default:
+		return "", fmt.Errorf("invalid secret reference: %s", kind)
+	}
+}
+
+func addSecret(s string) {
+	if s == "" {
+		return
+	}
+
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+
+	for _, v := range RedactDefaultHook(s) {
+		if !slices.Contains(secrets, v) {
+			secrets = append(secrets, v)
+		}
+	}
+}
+
+// RedactSecrets replaces all resolved secret values in s
+func RedactSecrets(s string) string {
+	secretsMu.RLock()
+	defer secretsMu.RUnlock()
+
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, RedactReplacement)
+	}
+
+	return s
+}
@@ -17,4 +17,10 @@ rtu: false
 # configuration error - should not happen
 modbusConnectionTypeNotDefined: {{ .modbus }}
 {{- end }}
+{{- if .priority }}
+priority: {{ .priority }}
+{{- end }}
+{{- if .framedelay }}
+framedelay: {{ .framedelay }}
+{{- end }}
 {{- end }}
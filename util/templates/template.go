@@ -184,6 +184,14 @@ func (t *Template) ParamByName(name string) (int, Param) {
 	return -1, Param{}
 }
 
+// IsValidKey returns true if key is a template param or a predefined template property
+func (t *Template) IsValidKey(key string) bool {
+	if i, _ := t.ParamByName(key); i > -1 {
+		return true
+	}
+	return slices.Contains(predefinedTemplateProperties, strings.ToLower(key))
+}
+
 // Usages returns the list of supported usages
 func (t *Template) Usages() []string {
 	if i, p := t.ParamByName(ParamUsage); i > -1 {
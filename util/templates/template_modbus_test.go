@@ -0,0 +1,32 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModbusBusSettings(t *testing.T) {
+	instance, err := RenderInstance(Charger, map[string]interface{}{
+		"template": "alfen",
+		"modbus":   "tcpip",
+		"host":     "192.0.2.2",
+	})
+	require.NoError(t, err)
+
+	assert.NotContains(t, instance.Other, "priority", "not rendered by default")
+	assert.NotContains(t, instance.Other, "framedelay", "not rendered by default")
+
+	instance, err = RenderInstance(Charger, map[string]interface{}{
+		"template":   "alfen",
+		"modbus":     "tcpip",
+		"host":       "192.0.2.2",
+		"priority":   1,
+		"framedelay": "10ms",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, instance.Other["priority"])
+	assert.Equal(t, "10ms", instance.Other["framedelay"])
+}
@@ -35,6 +35,9 @@ const (
 	ModbusParamNamePort     = "port"
 	ModbusParamNameRTU      = "rtu"
 
+	ModbusParamNamePriority   = "priority"
+	ModbusParamNameFrameDelay = "framedelay"
+
 	TemplateRenderModeDocs     = "docs"
 	TemplateRenderModeUnitTest = "unittest"
 	TemplateRenderModeInstance = "instance"
@@ -75,6 +78,7 @@ var predefinedTemplateProperties = []string{
 	"type", "template", "name",
 	ModbusParamNameId, ModbusParamNameDevice, ModbusParamNameBaudrate, ModbusParamNameComset,
 	ModbusParamNameURI, ModbusParamNameHost, ModbusParamNamePort, ModbusParamNameRTU,
+	ModbusParamNamePriority, ModbusParamNameFrameDelay,
 	ModbusKeyTCPIP, ModbusKeyRS485Serial, ModbusKeyRS485TCPIP,
 }
 